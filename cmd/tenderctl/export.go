@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"tender_management/models"
+	"tender_management/pkg/ocds"
+	"time"
+)
+
+const exportBatchSize = 500
+
+// exportFlags adds -o to fs and parses it. The returned writer is stdout
+// unless -o names a file, and close must be called once written.
+func exportFlags(fs *flag.FlagSet, args []string) (w io.Writer, close func() error, err error) {
+	out := fs.String("o", "", "output file, stdout if empty")
+	if err := parse(fs, args); err != nil {
+		return nil, nil, err
+	}
+
+	if *out == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// exportTenders writes every live tender as one JSON object per line.
+func exportTenders(a *app, args []string) (err error) {
+	w, close, err := exportFlags(flag.NewFlagSet("export tenders", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+	defer closeExport(close, &err)
+
+	enc := json.NewEncoder(w)
+	return a.store.Tenders().Each(exportBatchSize, func(tenders []models.Tenders) error {
+		for _, t := range tenders {
+			if err := enc.Encode(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// exportOffers writes every live offer as one JSON object per line.
+func exportOffers(a *app, args []string) (err error) {
+	w, close, err := exportFlags(flag.NewFlagSet("export offers", flag.ExitOnError), args)
+	if err != nil {
+		return err
+	}
+	defer closeExport(close, &err)
+
+	enc := json.NewEncoder(w)
+	return a.store.Offers().Each(exportBatchSize, func(offers []models.Offers) error {
+		for _, o := range offers {
+			if err := enc.Encode(o); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// exportOCDS writes an OCDS release package, or a record package with
+// -records, of the tenders changed in the date range.
+func exportOCDS(a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("export ocds", flag.ExitOnError)
+	records := fs.Bool("records", false, "write a record package instead of a release package")
+	fromFlag := fs.String("from", "", "releases dated on or after this date, YYYY-MM-DD")
+	toFlag := fs.String("to", "", "releases dated before this date, YYYY-MM-DD")
+	w, close, err := exportFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	defer closeExport(close, &err)
+
+	from, err := parseDate(*fromFlag)
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(*toFlag)
+	if err != nil {
+		return err
+	}
+
+	builder := ocds.NewBuilder(a.db, ocds.Publication{
+		Prefix:        a.cfg.OCDSPrefix,
+		PublisherName: a.cfg.OCDSPublisherName,
+		PublisherURI:  a.cfg.OCDSPublisherURI,
+		BaseURL:       a.cfg.PublicURL,
+	})
+
+	uri := a.cfg.PublicURL + "/ocds/releases"
+	if *records {
+		uri = a.cfg.PublicURL + "/ocds/records"
+		return builder.WriteRecordPackage(w, uri, ocds.Changed(a.db, from, to))
+	}
+	return builder.WriteReleasePackage(w, uri, ocds.Changed(a.db, from, to))
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// closeExport closes the output and reports its error unless an earlier
+// one is already returned.
+func closeExport(close func() error, err *error) {
+	if closeErr := close(); closeErr != nil && *err == nil {
+		*err = closeErr
+	}
+}
@@ -0,0 +1,159 @@
+// Command tenderctl runs operational tasks against the server's database
+// without the HTTP server. It reads the same .env configuration and goes
+// through the same migrations, repositories and services.
+//
+//	tenderctl [-json] <command> <subcommand> [flags]
+//
+//	migrate up | down [-steps n] | status
+//	seed    categories -file codes.csv | demo [-password p]
+//	user    create -email e -phone p -name n -role r -password p [-active]
+//	user    activate -email e | reset-password -email e -password p
+//	tender  overdue [-grace d] | close-overdue [-grace d]
+//	notif   reprocess
+//	rates   import -file rates.json | list [-date d]
+//	export  tenders | offers | ocds [-records] [-from d] [-to d]  [-o file]
+//
+// With -json every command prints a single JSON value, and errors go to
+// stderr with a non-zero exit status either way. Exports always write JSON.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"tender_management/config"
+	"tender_management/pkg/db"
+	"tender_management/pkg/migrate"
+	"tender_management/storage"
+
+	"gorm.io/gorm"
+)
+
+type app struct {
+	cfg    config.Config
+	db     *gorm.DB
+	store  storage.Store
+	asJSON bool
+}
+
+type command func(a *app, args []string) error
+
+var commands = map[string]map[string]command{
+	"migrate": {"up": migrateUp, "down": migrateDown, "status": migrateStatus},
+	"seed":    {"categories": seedCategories, "demo": seedDemo},
+	"user":    {"create": userCreate, "activate": userActivate, "reset-password": userResetPassword},
+	"tender":  {"overdue": tenderOverdue, "close-overdue": tenderCloseOverdue},
+	"notif":   {"reprocess": notifReprocess},
+	"rates":   {"import": ratesImport, "list": ratesList},
+	"export":  {"tenders": exportTenders, "offers": exportOffers, "ocds": exportOCDS},
+}
+
+func main() {
+	asJSON := flag.Bool("json", false, "print results as JSON")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)][flag.Arg(1)]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	a := &app{cfg: config.LoadConfig(), asJSON: *asJSON}
+	if err := a.connect(flag.Arg(0) == "migrate"); err != nil {
+		fail(err)
+	}
+
+	if err := cmd(a, flag.Args()[2:]); err != nil {
+		fail(err)
+	}
+}
+
+// connect opens the database. Except for migrations, the schema must be
+// the one this binary expects.
+func (a *app) connect(migrating bool) error {
+	conn, err := db.Open(a.cfg)
+	if err != nil {
+		return fmt.Errorf("connecting database: %w", err)
+	}
+
+	if !migrating {
+		if err := migrate.Check(conn); err != nil {
+			return err
+		}
+	}
+
+	a.db = conn
+	a.store = storage.New(conn)
+	return nil
+}
+
+// print writes v as JSON with -json, and the text lines otherwise.
+func (a *app) print(v interface{}, lines ...string) error {
+	if a.asJSON {
+		return json.NewEncoder(os.Stdout).Encode(v)
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// parse parses the flags of a subcommand and rejects stray arguments.
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
+
+// required reports the first of the named flags left empty.
+func required(values map[string]string) error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if values[name] == "" {
+			return fmt.Errorf("-%s is required", name)
+		}
+	}
+	return nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "tenderctl:", err)
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: tenderctl [-json] <command> <subcommand> [flags]")
+	fmt.Fprintln(os.Stderr)
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		subs := make([]string, 0, len(commands[name]))
+		for sub := range commands[name] {
+			subs = append(subs, sub)
+		}
+		sort.Strings(subs)
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", name, strings.Join(subs, " | "))
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"tender_management/pkg/migrate"
+	"time"
+)
+
+type migrationResult struct {
+	Version   uint       `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+}
+
+func migrateUp(a *app, args []string) error {
+	if err := parse(flag.NewFlagSet("migrate up", flag.ExitOnError), args); err != nil {
+		return err
+	}
+
+	applied, err := migrate.Up(a.db)
+	if printErr := a.printMigrations("applied", applied); printErr != nil && err == nil {
+		err = printErr
+	}
+	return err
+}
+
+func migrateDown(a *app, args []string) error {
+	fs := flag.NewFlagSet("migrate down", flag.ExitOnError)
+	steps := fs.Int("steps", 1, "number of migrations to revert, 0 for all")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	reverted, err := migrate.Down(a.db, *steps)
+	if printErr := a.printMigrations("reverted", reverted); printErr != nil && err == nil {
+		err = printErr
+	}
+	return err
+}
+
+func migrateStatus(a *app, args []string) error {
+	if err := parse(flag.NewFlagSet("migrate status", flag.ExitOnError), args); err != nil {
+		return err
+	}
+
+	statuses, err := migrate.Statuses(a.db)
+	if err != nil {
+		return err
+	}
+
+	results := make([]migrationResult, 0, len(statuses))
+	lines := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		results = append(results, migrationResult{Version: s.Version, Name: s.Name, AppliedAt: s.AppliedAt})
+
+		state := "pending"
+		if s.AppliedAt != nil {
+			state = "applied " + s.AppliedAt.Format(time.RFC3339)
+		}
+		lines = append(lines, fmt.Sprintf("%04d_%s\t%s", s.Version, s.Name, state))
+	}
+	return a.print(results, lines...)
+}
+
+// printMigrations reports the migrations applied or reverted, even when a
+// later one failed.
+func (a *app) printMigrations(verb string, migrations []migrate.Migration) error {
+	results := make([]migrationResult, 0, len(migrations))
+	lines := make([]string, 0, len(migrations))
+	for _, m := range migrations {
+		results = append(results, migrationResult{Version: m.Version, Name: m.Name})
+		lines = append(lines, fmt.Sprintf("%s %04d_%s", verb, m.Version, m.Name))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "nothing to do")
+	}
+	return a.print(results, lines...)
+}
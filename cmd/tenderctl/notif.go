@@ -0,0 +1,19 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"tender_management/service"
+)
+
+func notifReprocess(a *app, args []string) error {
+	if err := parse(flag.NewFlagSet("notif reprocess", flag.ExitOnError), args); err != nil {
+		return err
+	}
+
+	count, err := service.NewNotifService(a.store, a.cfg.WebhookAllowPrivate).Reprocess()
+	if err != nil {
+		return err
+	}
+	return a.print(map[string]int64{"queued": count}, fmt.Sprintf("queued %d failed messages for retry", count))
+}
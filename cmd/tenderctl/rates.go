@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender_management/pkg/rates"
+	"time"
+)
+
+func ratesImport(a *app, args []string) error {
+	fs := flag.NewFlagSet("rates import", flag.ExitOnError)
+	file := fs.String("file", "", "Central Bank rates, .json as published or .csv with a currency,rate,date[,nominal] header")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"file": *file}); err != nil {
+		return err
+	}
+
+	read := rates.ParseCSV
+	switch strings.ToLower(filepath.Ext(*file)) {
+	case ".csv":
+	case ".json":
+		read = rates.ParseJSON
+	default:
+		return errors.New("only .csv and .json files are supported")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := read(f)
+	if err != nil {
+		return err
+	}
+
+	count, err := rates.Import(a.db, rows, filepath.Base(*file))
+	if err != nil {
+		return err
+	}
+	return a.print(map[string]int{"imported": count}, fmt.Sprintf("imported %d rates", count))
+}
+
+func ratesList(a *app, args []string) error {
+	fs := flag.NewFlagSet("rates list", flag.ExitOnError)
+	date := fs.String("date", "", "rates in force on this date, YYYY-MM-DD; today when empty")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	asOf := time.Now()
+	if *date != "" {
+		t, err := time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return fmt.Errorf("-date: %w", err)
+		}
+		asOf = t
+	}
+
+	table, err := rates.Load(a.db, a.cfg.Currency, asOf)
+	if err != nil {
+		return err
+	}
+
+	list := table.Rates()
+	lines := make([]string, 0, len(list))
+	for _, rate := range list {
+		lines = append(lines, fmt.Sprintf("%s  %s %s  %s", rate.Currency, rate.Rate.String(), table.Base, rate.Date.Format(time.DateOnly)))
+	}
+	return a.print(list, lines...)
+}
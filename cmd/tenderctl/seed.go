@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/categories"
+	"tender_management/service"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func seedCategories(a *app, args []string) error {
+	fs := flag.NewFlagSet("seed categories", flag.ExitOnError)
+	file := fs.String("file", "", "code list, .csv with a code,name[,parent_code] header or .json")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"file": *file}); err != nil {
+		return err
+	}
+
+	read := categories.ParseCSV
+	switch strings.ToLower(filepath.Ext(*file)) {
+	case ".csv":
+	case ".json":
+		read = categories.ParseJSON
+	default:
+		return errors.New("only .csv and .json files are supported")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rows, err := read(f)
+	if err != nil {
+		return err
+	}
+
+	count, err := categories.Import(a.db, rows)
+	if err != nil {
+		return err
+	}
+	return a.print(map[string]int{"imported": count}, fmt.Sprintf("imported %d categories", count))
+}
+
+// demoUsers are the accounts seed demo creates, one per role.
+var demoUsers = []models.UserRegister{
+	{FirstName: "Admin", Email: "admin@demo.local", PhoneNumber: "+998900000001", Role: constants.RoleAdmin},
+	{FirstName: "Client", Email: "client@demo.local", PhoneNumber: "+998900000002", Role: constants.RoleClient},
+	{FirstName: "Contractor", Email: "contractor@demo.local", PhoneNumber: "+998900000003", Role: constants.RoleContractor},
+}
+
+type demoResult struct {
+	Users    []userResult `json:"users"`
+	TenderID uint         `json:"tender_id,omitempty"`
+	OfferID  uint         `json:"offer_id,omitempty"`
+}
+
+// seedDemo creates active demo accounts for every role that do not exist
+// yet and, the first time, an open tender of the client with an offer of
+// the contractor.
+func seedDemo(a *app, args []string) error {
+	fs := flag.NewFlagSet("seed demo", flag.ExitOnError)
+	password := fs.String("password", "demo12345", "password of the demo accounts")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	users := service.NewUserService(a.store, nil)
+
+	var result demoResult
+	var lines []string
+	created := map[string]models.Users{}
+	for _, req := range demoUsers {
+		user, err := users.FindByEmail(req.Email)
+		if service.KindOf(err) == service.NotFound {
+			req.Password = *password
+			user, err = users.CreateAccount(req, true)
+			created[user.Role] = user
+		}
+		if err != nil {
+			return err
+		}
+
+		result.Users = append(result.Users, newUserResult(user))
+		lines = append(lines, fmt.Sprintf("%s user %d <%s>", user.Role, user.ID, user.Email))
+	}
+
+	client, okClient := created[constants.RoleClient]
+	contractor, okContractor := created[constants.RoleContractor]
+	if okClient && okContractor {
+		now := time.Now()
+
+		tender, err := service.NewTenderService(a.store, a.cfg.Currency).Create(client.ID, models.TenderRequest{
+			Title:       "Office furniture supply",
+			Description: "Desks, chairs and cabinets for a 40 seat office, delivered and assembled.",
+			Deadline:    now.AddDate(0, 0, 14).Format(constants.Layout),
+			Budget:      decimal.NewFromInt(1500000000),
+		})
+		if err != nil {
+			return err
+		}
+
+		offer, err := service.NewOfferService(a.store, a.cfg.Currency).Create(contractor.ID, models.OffersRequest{
+			TenderID:     tender.ID,
+			ContractorID: contractor.ID,
+			Price:        decimal.NewFromInt(1385000000),
+			DeliveryTime: now.AddDate(0, 0, 30).Format(constants.Layout),
+			Comments:     "Delivery and assembly included.",
+			Status:       true,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.TenderID, result.OfferID = tender.ID, offer.ID
+		lines = append(lines, fmt.Sprintf("tender %d with offer %d", tender.ID, offer.ID))
+	}
+
+	return a.print(result, lines...)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"tender_management/models"
+	"tender_management/service"
+	"time"
+)
+
+type tenderResult struct {
+	ID       uint       `json:"id"`
+	Title    string     `json:"title"`
+	ClientID uint       `json:"client_id"`
+	State    string     `json:"state"`
+	Deadline *time.Time `json:"deadline"`
+}
+
+// overdueFlags parses the grace period after the deadline past which an
+// open tender counts as stuck.
+func overdueFlags(name string, args []string) (time.Time, error) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	grace := fs.Duration("grace", 7*24*time.Hour, "time after the deadline before an open tender is stuck")
+	if err := parse(fs, args); err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-*grace), nil
+}
+
+func tenderOverdue(a *app, args []string) error {
+	before, err := overdueFlags("tender overdue", args)
+	if err != nil {
+		return err
+	}
+
+	tenders, err := service.NewTenderService(a.store, a.cfg.Currency).Overdue(before)
+	if err != nil {
+		return err
+	}
+	return a.printTenders("", tenders)
+}
+
+func tenderCloseOverdue(a *app, args []string) error {
+	before, err := overdueFlags("tender close-overdue", args)
+	if err != nil {
+		return err
+	}
+
+	tenders, err := service.NewTenderService(a.store, a.cfg.Currency).CancelOverdue(before)
+	if printErr := a.printTenders("cancelled ", tenders); printErr != nil && err == nil {
+		err = printErr
+	}
+	return err
+}
+
+func (a *app) printTenders(verb string, tenders []models.Tenders) error {
+	results := make([]tenderResult, 0, len(tenders))
+	lines := make([]string, 0, len(tenders))
+	for _, t := range tenders {
+		results = append(results, tenderResult{ID: t.ID, Title: t.Title, ClientID: t.ClientID, State: t.State, Deadline: t.Deadline})
+		lines = append(lines, fmt.Sprintf("%stender %d %q, deadline %s", verb, t.ID, t.Title, t.Deadline.Format(time.RFC3339)))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "no overdue tenders")
+	}
+	return a.print(results, lines...)
+}
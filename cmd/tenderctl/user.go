@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"tender_management/models"
+	"tender_management/service"
+)
+
+// userResult is a user without its password hash.
+type userResult struct {
+	ID       uint   `json:"id"`
+	Email    string `json:"email"`
+	Name     string `json:"first_name"`
+	Role     string `json:"role"`
+	IsActive bool   `json:"is_active"`
+}
+
+func newUserResult(user models.Users) userResult {
+	return userResult{ID: user.ID, Email: user.Email, Name: user.FirstName, Role: user.Role, IsActive: user.IsActive}
+}
+
+func userCreate(a *app, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ExitOnError)
+	var req models.UserRegister
+	fs.StringVar(&req.Email, "email", "", "email address")
+	fs.StringVar(&req.PhoneNumber, "phone", "", "phone number, +998XXXXXXXXX")
+	fs.StringVar(&req.FirstName, "name", "", "first name")
+	fs.StringVar(&req.Role, "role", "", "admin, client or contractor")
+	fs.StringVar(&req.Password, "password", "", "initial password")
+	active := fs.Bool("active", true, "skip phone verification")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{
+		"email": req.Email, "phone": req.PhoneNumber, "name": req.FirstName, "role": req.Role, "password": req.Password,
+	}); err != nil {
+		return err
+	}
+
+	user, err := service.NewUserService(a.store, nil).CreateAccount(req, *active)
+	if err != nil {
+		return err
+	}
+	return a.print(newUserResult(user), fmt.Sprintf("created %s user %d <%s>", user.Role, user.ID, user.Email))
+}
+
+func userActivate(a *app, args []string) error {
+	fs := flag.NewFlagSet("user activate", flag.ExitOnError)
+	email := fs.String("email", "", "email address")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"email": *email}); err != nil {
+		return err
+	}
+
+	user, err := service.NewUserService(a.store, nil).Activate(*email)
+	if err != nil {
+		return err
+	}
+	return a.print(newUserResult(user), fmt.Sprintf("activated user %d <%s>", user.ID, user.Email))
+}
+
+func userResetPassword(a *app, args []string) error {
+	fs := flag.NewFlagSet("user reset-password", flag.ExitOnError)
+	email := fs.String("email", "", "email address")
+	password := fs.String("password", "", "new password")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(map[string]string{"email": *email, "password": *password}); err != nil {
+		return err
+	}
+
+	users := service.NewUserService(a.store, nil)
+	user, err := users.FindByEmail(*email)
+	if err != nil {
+		return err
+	}
+	if err := users.SetPassword(user.ID, *password); err != nil {
+		return err
+	}
+	return a.print(newUserResult(user), fmt.Sprintf("reset password of user %d <%s>", user.ID, user.Email))
+}
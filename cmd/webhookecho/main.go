@@ -0,0 +1,50 @@
+// Command webhookecho is a local stand-in for a webhook receiver. It checks
+// the signature of every request with WEBHOOK_SECRET and logs the event.
+// Set WEBHOOK_FAIL=1 to answer 500 and exercise retries and dead letters.
+//
+//	WEBHOOK_SECRET=... go run ./cmd/webhookecho -addr :9090
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"tender_management/pkg/outbox"
+	"tender_management/pkg/webhooks"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":9090", "listen address")
+	flag.Parse()
+
+	secret := os.Getenv("WEBHOOK_SECRET")
+	fail := os.Getenv("WEBHOOK_FAIL") == "1"
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		err = webhooks.Verify(secret, r.Header.Get(webhooks.TimestampHeader), r.Header.Get(webhooks.SignatureHeader), body, 5*time.Minute)
+		if err != nil {
+			log.Printf("[ERROR] Rejected %s: %v\n", r.Header.Get(outbox.IdempotencyHeader), err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		log.Printf("[INFO] %s %s: %s\n", r.Header.Get(webhooks.EventHeader), r.Header.Get(outbox.IdempotencyHeader), body)
+		if fail {
+			http.Error(w, "failing on purpose", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	log.Printf("[INFO] Listening on %s\n", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -17,6 +19,41 @@ type Config struct {
 	SecretKey   []byte
 	AppPassword string
 	AppEmail    string
+
+	StorageDriver   string
+	StorageLocalDir string
+	S3Endpoint      string
+	S3AccessKey     string
+	S3SecretKey     string
+	S3Bucket        string
+	S3UseSSL        bool
+	MaxUploadSize   int64
+
+	TelegramBotToken string
+	TelegramBotName  string
+	TelegramAPIURL   string
+
+	PublicURL         string
+	PublicRateLimit   int64
+	// Currency is the ISO 4217 code of tenders that do not name one.
+	Currency          string
+	OCDSPrefix        string
+	OCDSPublisherName string
+	OCDSPublisherURI  string
+
+	// WebhookAllowPrivate lets webhook and notification URLs point to
+	// loopback and private addresses, for local testing only.
+	WebhookAllowPrivate bool
+
+	// TrustedProxies are the addresses or CIDR ranges of the reverse
+	// proxies whose X-Forwarded-For header gives the client IP, which rate
+	// limits are keyed on. None are trusted by default, so the client IP is
+	// the address of the connection.
+	TrustedProxies []string
+
+	// MigrateOnStart applies pending migrations at startup; when off the
+	// server refuses to start until they are applied with migrate up.
+	MigrateOnStart bool
 }
 
 func LoadConfig() Config {
@@ -35,6 +72,59 @@ func LoadConfig() Config {
 		SecretKey:   []byte(os.Getenv("SEKRET_KEY")),
 		AppPassword: os.Getenv("APP_PASSWORD"),
 		AppEmail: os.Getenv("APP_EMAIL"),
+
+		StorageDriver:   getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir: getEnv("STORAGE_LOCAL_DIR", "uploads"),
+		S3Endpoint:      getEnv("S3_ENDPOINT", "localhost:9000"),
+		S3AccessKey:     os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:     os.Getenv("S3_SECRET_KEY"),
+		S3Bucket:        getEnv("S3_BUCKET", "tender-attachments"),
+		S3UseSSL:        os.Getenv("S3_USE_SSL") == "true",
+		MaxUploadSize:   getEnvInt("MAX_UPLOAD_SIZE", 20<<20),
+
+		TelegramBotToken: os.Getenv("TELEGRAM_BOT_TOKEN"),
+		TelegramBotName:  os.Getenv("TELEGRAM_BOT_NAME"),
+		TelegramAPIURL:   getEnv("TELEGRAM_API_URL", "https://api.telegram.org"),
+
+		PublicURL:         os.Getenv("PUBLIC_URL"),
+		PublicRateLimit:   getEnvInt("PUBLIC_RATE_LIMIT", 60),
+		Currency:          getEnv("CURRENCY", "UZS"),
+		OCDSPrefix:        getEnv("OCDS_PREFIX", "ocds-tender"),
+		OCDSPublisherName: getEnv("OCDS_PUBLISHER_NAME", "Tender Management"),
+		OCDSPublisherURI:  os.Getenv("OCDS_PUBLISHER_URI"),
+
+		WebhookAllowPrivate: os.Getenv("WEBHOOK_ALLOW_PRIVATE") == "true",
+
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+
+		MigrateOnStart: os.Getenv("MIGRATE_ON_START") != "false",
 	}
 	return config
 }
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvList splits a comma separated variable, returning nil when it is
+// unset or empty.
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
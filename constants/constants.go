@@ -8,4 +8,16 @@ const(
 	ErrInvalidInput   = "invalid input provided. please check your data."
 	ErrFormatInput = "invalid date format. please use the format 'YYYY-MM-DD HH:MM:SS'"
 	ErrRecordNotFound = "Failed to fetch data"
-)
\ No newline at end of file
+)
+
+const (
+	CtxUserID = "user_id"
+	CtxEmail  = "email"
+	CtxRole   = "role"
+
+	RoleAdmin      = "admin"
+	RoleClient     = "client"
+	RoleContractor = "contractor"
+
+	TwoFactorIssuer = "Tender Management"
+)
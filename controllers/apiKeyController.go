@@ -0,0 +1,192 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/utils"
+	"tender_management/validation"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type APIKeyController struct {
+	Storage  *gorm.DB
+	Enforcer *casbin.Enforcer
+}
+
+func NewAPIKeyController(storage *gorm.DB, enforcer *casbin.Enforcer) *APIKeyController {
+	return &APIKeyController{
+		Storage:  storage,
+		Enforcer: enforcer,
+	}
+}
+
+// CreateAPIKey godoc
+// @Summary      Create an API key
+// @Description  Creates an API key for the authenticated user, limited to the given "METHOD /path" scopes.
+// @Description  Every scope must be allowed for the user's role. The key is only returned once. API keys cannot call
+// @Description  /api-keys or /auth/2fa/, so requests to these must use a token, and scopes on them are rejected.
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.APIKeyRequest true "API key request"
+// @Success      201 {object} Response "Created key"
+// @Failure      400 {object} Response "Bad request"
+// @Failure      403 {object} Response "Scope not allowed for role"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /api-keys [post]
+func (a *APIKeyController) CreateAPIKey(c *gin.Context) {
+	var body models.APIKeyRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+	role := c.GetString(constants.CtxRole)
+
+	scopes := make([]string, 0, len(body.Scopes))
+	for _, scope := range body.Scopes {
+		path, method, ok := models.ParseScope(scope)
+		if !ok {
+			handleError(c, http.StatusBadRequest, "Invalid scope, expected \"METHOD /path\": "+scope, nil)
+			return
+		}
+		if models.CredentialPath(path) {
+			handleError(c, http.StatusBadRequest, "API keys cannot manage API keys or two-factor authentication: "+scope, nil)
+			return
+		}
+
+		allowed, err := a.Enforcer.Enforce(role, path, method)
+		if err != nil {
+			handleError(c, http.StatusInternalServerError, "Casbin enforcement error", err)
+			return
+		}
+		if !allowed {
+			handleError(c, http.StatusForbidden, "Scope not allowed for your role: "+scope, nil)
+			return
+		}
+
+		scopes = append(scopes, method+" "+path)
+	}
+
+	var expiresAt *time.Time
+	if body.ExpiresAt != "" {
+		t, err := validation.ParseFutureTime(body.ExpiresAt)
+		if err != nil {
+			handleError(c, http.StatusBadRequest, "Invalid expires_at", err)
+			return
+		}
+		expiresAt = t
+	}
+
+	key, lookup, err := utils.GenerateAPIKey()
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate API key", err)
+		return
+	}
+
+	apiKey := models.APIKey{
+		UserID:    userID,
+		Name:      body.Name,
+		Lookup:    lookup,
+		KeyHash:   utils.HashToken(key),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+
+	if err := a.Storage.Create(&apiKey).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to create API key", err)
+		return
+	}
+	apiKey.ScopeList = scopes
+
+	HandleResponse(c, http.StatusCreated, gin.H{
+		"key":     key,
+		"api_key": apiKey,
+	})
+}
+
+// GetAPIKeys godoc
+// @Summary      List API keys
+// @Description  Lists the authenticated user's API keys, including revoked and expired ones.
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} models.APIKey
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /api-keys [get]
+func (a *APIKeyController) GetAPIKeys(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var keys []models.APIKey
+	if err := a.Storage.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch API keys", err)
+		return
+	}
+
+	for i := range keys {
+		keys[i].ScopeList = keys[i].SplitScopes()
+	}
+
+	HandleResponse(c, http.StatusOK, keys)
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Revokes one of the authenticated user's API keys. Revoked keys are rejected immediately.
+// @Tags         api-keys
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "API key ID"
+// @Success      200 {string} string "API key revoked successfully"
+// @Failure      404 {object} Response "API key not found"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /api-keys/{id} [delete]
+func (a *APIKeyController) RevokeAPIKey(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var apiKey models.APIKey
+	if err := a.Storage.Where("id = ? AND user_id = ?", id, userID).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			handleError(c, http.StatusNotFound, "API key not found", err)
+			return
+		}
+		handleError(c, http.StatusInternalServerError, "Failed to fetch API key", err)
+		return
+	}
+
+	if apiKey.RevokedAt != nil {
+		handleError(c, http.StatusBadRequest, "API key already revoked", nil)
+		return
+	}
+
+	if err := a.Storage.Model(&apiKey).Update("revoked_at", time.Now()).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to revoke API key", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "API key revoked successfully")
+}
@@ -0,0 +1,390 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"tender_management/config"
+	"tender_management/models"
+	"tender_management/pkg/filestore"
+	"tender_management/pkg/search"
+	"tender_management/pkg/utils"
+	"tender_management/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// allowedAttachmentTypes maps accepted file extensions to the content types
+// http.DetectContentType may report for them. Office formats are zip
+// containers (docx, xlsx) or OLE files reported as octet-stream (doc, xls).
+var allowedAttachmentTypes = map[string][]string{
+	".pdf":  {"application/pdf"},
+	".png":  {"image/png"},
+	".jpg":  {"image/jpeg"},
+	".jpeg": {"image/jpeg"},
+	".txt":  {"text/plain"},
+	".csv":  {"text/plain"},
+	".zip":  {"application/zip"},
+	".docx": {"application/zip"},
+	".xlsx": {"application/zip"},
+	".doc":  {"application/octet-stream"},
+	".xls":  {"application/octet-stream"},
+}
+
+// maxIndexedText caps how much of a plain text tender attachment is kept
+// for full-text search.
+const maxIndexedText = 1 << 20
+
+type AttachmentController struct {
+	Storage *gorm.DB
+	Files   filestore.Storage
+	Access  *service.AccessService
+	Config  *config.Config
+}
+
+func NewAttachmentController(storage *gorm.DB, files filestore.Storage, access *service.AccessService, cfg *config.Config) *AttachmentController {
+	return &AttachmentController{
+		Storage: storage,
+		Files:   files,
+		Access:  access,
+		Config:  cfg,
+	}
+}
+
+// UploadAttachment godoc
+// @Summary      Upload an attachment
+// @Description  Uploads a file to a tender or an offer the user manages, while the tender is open and before its
+// @Description  deadline. Size and content type are limited, and a SHA-256 checksum is recorded.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        owner_type formData string true "tender or offer"
+// @Param        owner_id formData int true "Tender or offer ID"
+// @Param        file formData file true "File"
+// @Success      201 {object} models.Attachment
+// @Failure      400 {object} Response "Bad request, or the tender is closed"
+// @Failure      403 {object} Response "Not allowed"
+// @Failure      404 {object} Response "Owner not found"
+// @Failure      413 {object} Response "File too large"
+// @Failure      415 {object} Response "Unsupported file type"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /attachments [post]
+func (a *AttachmentController) UploadAttachment(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.Config.MaxUploadSize+1<<20)
+
+	ownerType := c.PostForm("owner_type")
+	ownerID, err := strconv.ParseUint(c.PostForm("owner_id"), 10, 64)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid owner_id", err)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	if fileHeader.Size > a.Config.MaxUploadSize {
+		handleError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("File exceeds the maximum size of %d bytes", a.Config.MaxUploadSize), nil)
+		return
+	}
+
+	if !a.canChangeOwner(c, ownerType, uint(ownerID)) {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to read file", err)
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		handleError(c, http.StatusBadRequest, "Failed to read file", err)
+		return
+	}
+	head = head[:n]
+
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	contentType, ok := detectAttachmentType(ext, head)
+	if !ok {
+		handleError(c, http.StatusUnsupportedMediaType, "Unsupported file type", nil)
+		return
+	}
+
+	random, err := utils.RandomHex(16)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate storage key", err)
+		return
+	}
+	key := fmt.Sprintf("%s/%d/%s%s", ownerType, ownerID, random, ext)
+
+	hash := sha256.New()
+	var sink io.Writer = hash
+	var text limitedBuffer
+	if ownerType == models.AttachmentOwnerTender && strings.HasPrefix(contentType, "text/plain") {
+		text.max = maxIndexedText
+		sink = io.MultiWriter(hash, &text)
+	}
+	body := io.TeeReader(io.MultiReader(bytes.NewReader(head), file), sink)
+
+	if err := a.Files.Put(c, key, body, fileHeader.Size, contentType); err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to store file", err)
+		return
+	}
+
+	userID, _ := getUserID(c)
+	attachment := models.Attachment{
+		OwnerType:   ownerType,
+		OwnerID:     uint(ownerID),
+		FileName:    filepath.Base(fileHeader.Filename),
+		ContentType: contentType,
+		Size:        fileHeader.Size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+		UploadedBy:  userID,
+		TextContent: strings.ToValidUTF8(strings.ReplaceAll(text.String(), "\x00", ""), ""),
+	}
+
+	err = a.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attachment).Error; err != nil {
+			return err
+		}
+		return a.reindexOwner(tx, attachment)
+	})
+	if err != nil {
+		a.Files.Delete(c, key)
+		handleError(c, http.StatusInternalServerError, "Failed to save attachment", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusCreated, attachment)
+}
+
+// GetAttachments godoc
+// @Summary      List attachments
+// @Description  Lists the attachments of a tender or an offer. Offer attachments are sealed: the tender's client
+// @Description  only sees them after the tender deadline.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        owner_type query string true "tender or offer"
+// @Param        owner_id query int true "Tender or offer ID"
+// @Success      200 {array} models.Attachment
+// @Failure      403 {object} Response "Not allowed"
+// @Failure      404 {object} Response "Owner not found"
+// @Router       /attachments [get]
+func (a *AttachmentController) GetAttachments(c *gin.Context) {
+	ownerType := c.Query("owner_type")
+	ownerID, err := strconv.ParseUint(c.Query("owner_id"), 10, 64)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid owner_id", err)
+		return
+	}
+
+	allowed, err := a.canViewOwner(c, ownerType, uint(ownerID))
+	if err != nil {
+		handleError(c, http.StatusNotFound, "Attachment owner not found", err)
+		return
+	}
+	if !allowed {
+		handleError(c, http.StatusForbidden, "You are not allowed to see these attachments", nil)
+		return
+	}
+
+	var attachments []models.Attachment
+	if err := a.Storage.Where("owner_type = ? AND owner_id = ? AND deleted_at IS NULL", ownerType, ownerID).
+		Order("created_at ASC").Find(&attachments).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch attachments", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, attachments)
+}
+
+// DownloadAttachment godoc
+// @Summary      Download an attachment
+// @Description  Streams an attachment after checking the same visibility rules as the listing.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Produce      octet-stream
+// @Param        id path string true "Attachment ID"
+// @Success      200 {file} file
+// @Failure      403 {object} Response "Not allowed"
+// @Failure      404 {object} Response "Attachment not found"
+// @Router       /attachments/{id}/download [get]
+func (a *AttachmentController) DownloadAttachment(c *gin.Context) {
+	var attachment models.Attachment
+
+	if err := getByID(a.Storage, c.Param("id"), &attachment); err != nil {
+		handleError(c, http.StatusNotFound, "Attachment not found", err)
+		return
+	}
+
+	allowed, err := a.canViewOwner(c, attachment.OwnerType, attachment.OwnerID)
+	if err != nil {
+		handleError(c, http.StatusNotFound, "Attachment owner not found", err)
+		return
+	}
+	if !allowed {
+		handleError(c, http.StatusForbidden, "You are not allowed to download this attachment", nil)
+		return
+	}
+
+	file, err := a.Files.Open(c, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, filestore.ErrNotFound) {
+			handleError(c, http.StatusNotFound, "Attachment file missing", err)
+			return
+		}
+		handleError(c, http.StatusInternalServerError, "Failed to open attachment", err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", attachment.FileName),
+		"X-Checksum-SHA256":   attachment.SHA256,
+	})
+}
+
+// DeleteAttachment godoc
+// @Summary      Delete an attachment
+// @Description  Removes an attachment from a tender or offer the user manages, while the tender is open and before
+// @Description  its deadline.
+// @Tags         attachments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Attachment ID"
+// @Success      200 {string} string "Attachment deleted successfully"
+// @Failure      400 {object} Response "Tender is closed"
+// @Failure      403 {object} Response "Not allowed"
+// @Failure      404 {object} Response "Attachment not found"
+// @Router       /attachments/{id} [delete]
+func (a *AttachmentController) DeleteAttachment(c *gin.Context) {
+	var attachment models.Attachment
+
+	if err := getByID(a.Storage, c.Param("id"), &attachment); err != nil {
+		handleError(c, http.StatusNotFound, "Attachment not found", err)
+		return
+	}
+
+	if !a.canChangeOwner(c, attachment.OwnerType, attachment.OwnerID) {
+		return
+	}
+
+	err := a.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&attachment).Update("deleted_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return a.reindexOwner(tx, attachment)
+	})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to delete attachment", err)
+		return
+	}
+
+	if err := a.Files.Delete(c, attachment.StorageKey); err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to delete attachment file", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Attachment deleted successfully")
+}
+
+// reindexOwner refreshes the search vector of the tender an attachment
+// belongs to.
+func (a *AttachmentController) reindexOwner(tx *gorm.DB, attachment models.Attachment) error {
+	if attachment.OwnerType != models.AttachmentOwnerTender || attachment.TextContent == "" {
+		return nil
+	}
+	return search.RefreshTender(tx, attachment.OwnerID)
+}
+
+// limitedBuffer keeps the first max bytes written to it and drops the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// canChangeOwner checks that the user may add or remove attachments of
+// the owner, answering the request when not.
+func (a *AttachmentController) canChangeOwner(c *gin.Context, ownerType string, ownerID uint) bool {
+	userID, _ := getUserID(c)
+
+	var err error
+	switch ownerType {
+	case models.AttachmentOwnerTender:
+		err = a.Access.ChangesTender(userID, ownerID)
+	case models.AttachmentOwnerOffer:
+		err = a.Access.ChangesOffer(userID, ownerID)
+	default:
+		handleError(c, http.StatusBadRequest, "owner_type must be tender or offer", nil)
+		return false
+	}
+	if err != nil {
+		respondError(c, err)
+		return false
+	}
+	return true
+}
+
+// canViewOwner applies sealed-bid visibility: tender attachments are visible
+// to every authenticated user, offer attachments only to the offer's owners
+// and, once the deadline has passed, to whoever manages the tender.
+func (a *AttachmentController) canViewOwner(c *gin.Context, ownerType string, ownerID uint) (bool, error) {
+	userID, _ := getUserID(c)
+
+	switch ownerType {
+	case models.AttachmentOwnerTender:
+		var tender models.Tenders
+		if err := a.Storage.Where("id = ? AND deleted_at IS NULL", ownerID).First(&tender).Error; err != nil {
+			return false, err
+		}
+		return true, nil
+	case models.AttachmentOwnerOffer:
+		return a.Access.SeesOffer(userID, ownerID)
+	default:
+		return false, errors.New("owner_type must be tender or offer")
+	}
+}
+
+func detectAttachmentType(ext string, head []byte) (string, bool) {
+	accepted, ok := allowedAttachmentTypes[ext]
+	if !ok {
+		return "", false
+	}
+
+	sniffed := http.DetectContentType(head)
+	for _, t := range accepted {
+		if strings.HasPrefix(sniffed, t) {
+			return sniffed, true
+		}
+	}
+	return "", false
+}
@@ -1,36 +1,36 @@
 package controllers
 
 import (
-	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"net/smtp"
 	"tender_management/config"
 	"tender_management/models"
-	"tender_management/pkg/db/password"
 	"tender_management/pkg/redise"
 	"tender_management/pkg/utils"
+	"tender_management/service"
 	"tender_management/validation"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/goccy/go-json"
 	"github.com/redis/go-redis/v9"
-	"gorm.io/gorm"
 )
 
+// AuthController handles registration, login, passwords and two-factor
+// authentication through Users.
 type AuthController struct {
-	Storage *gorm.DB
-	Redis   *redise.RedisDB
-	Config  *config.Config
+	Users  *service.UserService
+	Redis  *redise.RedisDB
+	Config *config.Config
 }
 
-func NewAuthController(storage *gorm.DB, redis *redise.RedisDB, cfg *config.Config) *AuthController {
+func NewAuthController(users *service.UserService, redis *redise.RedisDB, cfg *config.Config) *AuthController {
 	return &AuthController{
-		Storage: storage,
-		Redis:   redis,
-		Config:  cfg,
+		Users:  users,
+		Redis:  redis,
+		Config: cfg,
 	}
 }
 
@@ -56,19 +56,9 @@ func (ac *AuthController) CreateUser(c *gin.Context) {
 		return
 	}
 
-	if !validation.IsValidPhoneNumber(user.PhoneNumber) {
-		handleError(c, http.StatusBadRequest, "Invalid phone number format. Must start with +998 and be 12 digits long", nil)
-		return
-	}
-
-	if err := validation.ValidatePassword(user.Password); err != nil {
-		handleError(c, http.StatusBadRequest, "Password validation failed", err)
-		return
-	}
-
-	hashedPassword, err := password.HashPassword(user.Password)
+	hashedPassword, err := ac.Users.CheckRegistration(user)
 	if err != nil {
-		handleError(c, http.StatusInternalServerError, "Password hashing failed", err)
+		respondError(c, err)
 		return
 	}
 
@@ -166,8 +156,8 @@ func (ac *AuthController) VerifyCode(c *gin.Context) {
 		IsActive:    true,
 	}
 
-	if err := ac.Storage.Create(&users).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to create user", err)
+	if _, err := ac.Users.Create(users); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -181,6 +171,8 @@ func (ac *AuthController) VerifyCode(c *gin.Context) {
 // LoginUser godoc
 // @Summary      Login a user
 // @Description  Allows a user to log in using email and password. If valid, returns a JWT token.
+// @Description  Users with 2FA enabled get an mfa_token instead, to be exchanged at /auth/login/2fa.
+// @Description  Users whose role requires 2FA but who have not enrolled get a token limited to /auth/2fa.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -193,29 +185,59 @@ func (ac *AuthController) VerifyCode(c *gin.Context) {
 // @Router       /auth/login [post]
 func (ac *AuthController) LoginUser(c *gin.Context) {
 	var login models.LoginRequest
-	var user models.Users
 
 	if err := c.ShouldBindJSON(&login); err != nil {
 		handleError(c, http.StatusBadRequest, "Failed to parse request login", err)
 		return
 	}
 
-	if err := ac.Storage.Where("email = ?", login.Email).First(&user).Error; err != nil {
-		handleError(c, http.StatusNotFound, "User not found", err)
+	user, err := ac.Users.Authenticate(login.Email, login.Password)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		mfaToken, err := utils.RandomHex(32)
+		if err != nil {
+			handleError(c, http.StatusInternalServerError, "Failed to generate mfa token", err)
+			return
+		}
+
+		if err := ac.Redis.SetEx(c, mfaKey(mfaToken), user.ID, mfaTokenTTL); err != nil {
+			handleError(c, http.StatusInternalServerError, "Failed to save mfa token in Redis", err)
+			return
+		}
+
+		HandleResponse(c, http.StatusOK, gin.H{
+			"mfa_required": true,
+			"mfa_token":    mfaToken,
+			"expires_in":   int(mfaTokenTTL.Seconds()),
+		})
 		return
 	}
 
-	if !user.IsActive {
-		handleError(c, http.StatusUnauthorized, "User is not verified yet", nil)
+	required, err := ac.Users.TwoFactorRequired(user.Role)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	if !password.CheckPasswordHash(login.Password, user.Password) {
-		handleError(c, http.StatusUnauthorized, "Invalid email or password", nil)
+	if required {
+		token, err := utils.GenerateEnrollToken(ac.Config.SecretKey, user.ID, user.Email, user.Role)
+		if err != nil {
+			handleError(c, http.StatusInternalServerError, "Failed to generate token", err)
+			return
+		}
+
+		HandleResponse(c, http.StatusOK, gin.H{
+			"token":                   token,
+			"mfa_enrollment_required": true,
+		})
 		return
 	}
 
-	token, err := utils.GenerateToken(user.Email, user.Role)
+	token, err := utils.GenerateToken(ac.Config.SecretKey, user.ID, user.Email, user.Role)
 	if err != nil {
 		handleError(c, http.StatusInternalServerError, "Failed to generate token", err)
 		return
@@ -241,7 +263,6 @@ func (ac *AuthController) LoginUser(c *gin.Context) {
 // @Router /auth/reset-password [post]
 func (ac *AuthController) ResetPassword(c *gin.Context) {
 	var body models.ResetPassword
-	var user models.Users
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
@@ -249,26 +270,8 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 		return
 	}
 
-	if err = ac.Storage.Where("id = ? and is_active = ?", body.UserID, true).First(&user).Error; err != nil {
-		handleError(c, http.StatusNotFound, "User not found for reset", err)
-		return
-	}
-
-	if !password.CheckPasswordHash(body.ConfirmPassword, user.Password) {
-		handleError(c, http.StatusUnauthorized, "Invalid password", nil)
-		return
-	}
-
-	hashedPassword, err := password.HashPassword(body.NewPassword)
-	if err != nil {
-		handleError(c, http.StatusInternalServerError, "Password hashing failed", err)
-		return
-	}
-
-	user.Password = hashedPassword
-
-	if err = ac.Storage.Save(&user).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to reset password user", err)
+	if err := ac.Users.ChangePassword(body.UserID, body.ConfirmPassword, body.NewPassword); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -288,7 +291,6 @@ func (ac *AuthController) ResetPassword(c *gin.Context) {
 // @Router       /auth/forgot-password [post]
 func (ac *AuthController) ForGotPassword(c *gin.Context) {
 	var body models.ForgotPassword
-	var user models.Users
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
@@ -296,17 +298,9 @@ func (ac *AuthController) ForGotPassword(c *gin.Context) {
 		return
 	}
 
-	if !validation.IsValidPhoneNumber(body.PhoneNumber) {
-		handleError(c, http.StatusBadRequest, "Invalid phone number format. Must start with +998 and be 12 digits long", nil)
-		return
-	}
-
-	if err := ac.Storage.Where("phone_number = ?", body.PhoneNumber).First(&user).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			handleError(c, http.StatusNotFound, "User not found with this phone number", nil)
-		} else {
-			handleError(c, http.StatusInternalServerError, "Database error", err)
-		}
+	user, err := ac.Users.FindByPhone(body.PhoneNumber)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -393,7 +387,6 @@ func (ac *AuthController) VerifyForgotPassword(c *gin.Context) {
 // @Router       /auth/new-password [post]
 func (ac *AuthController) NewPassword(c *gin.Context) {
 	var body models.NewPassword
-	var user models.Users
 
 	err := c.ShouldBindJSON(&body)
 	if err != nil {
@@ -401,26 +394,8 @@ func (ac *AuthController) NewPassword(c *gin.Context) {
 		return
 	}
 
-	if err = validation.ValidatePassword(body.NewPassword); err != nil {
-		handleError(c, http.StatusBadRequest, "NewPassword validation failed", err)
-		return
-	}
-
-	hashedPassword, err := password.HashPassword(body.NewPassword)
-	if err != nil {
-		handleError(c, http.StatusInternalServerError, "NewPassword hashing failed", err)
-		return
-	}
-
-	if err = ac.Storage.Where("id = ? and is_active = ?", body.UserID, true).First(&user).Error; err != nil {
-		handleError(c, http.StatusNotFound, "User not found for forgot password", err)
-		return
-	}
-
-	user.Password = hashedPassword
-
-	if err = ac.Storage.Save(&user).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to reset password user", err)
+	if err := ac.Users.SetPassword(body.UserID, body.NewPassword); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"tender_management/models"
+	"tender_management/pkg/categories"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CategoryController struct {
+	Storage *gorm.DB
+}
+
+func NewCategoryController(storage *gorm.DB) *CategoryController {
+	return &CategoryController{
+		Storage: storage,
+	}
+}
+
+// ListCategories godoc
+// @Summary      List categories
+// @Description  Returns the children of a category (or the top level when parent is omitted), or searches codes and names with q.
+// @Tags         categories
+// @Security     BearerAuth
+// @Produce      json
+// @Param        parent  query  string  false  "Parent category code"
+// @Param        q       query  string  false  "Search code or name"
+// @Success      200 {array} models.Category
+// @Failure      404 {object} Response "Parent category not found"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /categories [get]
+func (ct *CategoryController) ListCategories(c *gin.Context) {
+	query := ct.Storage.Model(&models.Category{})
+
+	switch {
+	case c.Query("q") != "":
+		pattern := "%" + c.Query("q") + "%"
+		query = query.Where("code LIKE ? OR name ILIKE ?", pattern, pattern).Limit(100)
+	case c.Query("parent") != "":
+		var parent models.Category
+		if err := ct.Storage.Where("code = ?", categories.NormalizeCode(c.Query("parent"))).First(&parent).Error; err != nil {
+			handleError(c, http.StatusNotFound, "Parent category not found", err)
+			return
+		}
+		query = query.Where("parent_id = ?", parent.ID)
+	default:
+		query = query.Where("parent_id IS NULL")
+	}
+
+	var cats []models.Category
+	if err := query.Order("code ASC").Find(&cats).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch categories", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, cats)
+}
+
+// ImportCategories godoc
+// @Summary      Import categories
+// @Description  Creates or renames categories from a CPV-style code list. Upload a .csv file with a
+// @Description  "code,name[,parent_code]" header or a .json array of {code, name, parent_code}.
+// @Description  Without parent_code the parent is derived from the CPV code, e.g. 45210000 under 45200000.
+// @Tags         categories
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "Code list (.csv or .json)"
+// @Success      200 {object} Response "Number of imported categories"
+// @Failure      400 {object} Response "Invalid file"
+// @Router       /admin/categories/import [post]
+func (ct *CategoryController) ImportCategories(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to read file", err)
+		return
+	}
+	defer file.Close()
+
+	var parse func(io.Reader) ([]models.CategoryRow, error)
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".csv":
+		parse = categories.ParseCSV
+	case ".json":
+		parse = categories.ParseJSON
+	default:
+		handleError(c, http.StatusBadRequest, "Only .csv and .json files are supported", nil)
+		return
+	}
+
+	rows, err := parse(file)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid file: "+err.Error(), err)
+		return
+	}
+
+	count, err := categories.Import(ct.Storage, rows)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Import failed: "+err.Error(), err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{"imported": count})
+}
+
+// replaceCategories sets the many2many categories association of owner.
+func replaceCategories(tx *gorm.DB, owner interface{}, association string, cats []models.Category) error {
+	if len(cats) == 0 {
+		return tx.Model(owner).Association(association).Clear()
+	}
+	return tx.Model(owner).Association(association).Replace(cats)
+}
@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/categories"
+	"tender_management/validation"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ContractorController struct {
+	Storage *gorm.DB
+}
+
+func NewContractorController(storage *gorm.DB) *ContractorController {
+	return &ContractorController{
+		Storage: storage,
+	}
+}
+
+// SaveProfile godoc
+// @Summary      Create or update my contractor profile
+// @Description  Saves the authenticated contractor's company profile. The tax ID must be a 9 digit Uzbek INN.
+// @Description  categories lists the category codes the contractor serves.
+// @Tags         contractors
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.ContractorProfileRequest true "Profile"
+// @Success      200 {object} models.ContractorProfile
+// @Failure      400 {object} Response "Bad request"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /contractors/profile [put]
+func (ct *ContractorController) SaveProfile(c *gin.Context) {
+	var body models.ContractorProfileRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	if !validation.IsValidINN(body.TaxID) {
+		handleError(c, http.StatusBadRequest, "Invalid tax ID. INN must be 9 digits and not start with 0", nil)
+		return
+	}
+
+	cats, err := categories.Resolve(ct.Storage, body.Categories)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	specialisations := make([]string, 0, len(body.Specialisations))
+	for _, s := range body.Specialisations {
+		if s = strings.TrimSpace(s); s != "" {
+			specialisations = append(specialisations, strings.ReplaceAll(s, ",", " "))
+		}
+	}
+
+	profile := models.ContractorProfile{UserID: userID}
+	err = ct.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).
+			Assign(models.ContractorProfile{
+				LegalName:       body.LegalName,
+				TaxID:           body.TaxID,
+				Address:         body.Address,
+				Specialisations: strings.Join(specialisations, ","),
+				YearsInBusiness: body.YearsInBusiness,
+			}).
+			FirstOrCreate(&profile).Error; err != nil {
+			return err
+		}
+		return replaceCategories(tx, &profile, "Categories", cats)
+	})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to save contractor profile", err)
+		return
+	}
+	profile.SpecialisationList = profile.SplitSpecialisations()
+	profile.Categories = cats
+
+	HandleResponse(c, http.StatusOK, profile)
+}
+
+// GetMyProfile godoc
+// @Summary      Get my contractor profile
+// @Description  Returns the authenticated contractor's profile and qualification documents.
+// @Tags         contractors
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} Response "Profile and documents"
+// @Failure      404 {object} Response "Profile not found"
+// @Router       /contractors/profile [get]
+func (ct *ContractorController) GetMyProfile(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	ct.respondProfile(c, userID)
+}
+
+// GetProfile godoc
+// @Summary      Get a contractor's profile
+// @Description  Returns a contractor's company profile and qualification documents, so clients can assess bidders.
+// @Tags         contractors
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Contractor user ID"
+// @Success      200 {object} Response "Profile and documents"
+// @Failure      404 {object} Response "Profile not found"
+// @Router       /contractors/{id}/profile [get]
+func (ct *ContractorController) GetProfile(c *gin.Context) {
+	ct.respondProfile(c, c.Param("id"))
+}
+
+// AddDocument godoc
+// @Summary      Add a qualification document
+// @Description  Records a licence or certificate held by the authenticated contractor.
+// @Tags         contractors
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.QualificationDocumentRequest true "Document"
+// @Success      201 {object} models.QualificationDocument
+// @Failure      400 {object} Response "Bad request"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /contractors/documents [post]
+func (ct *ContractorController) AddDocument(c *gin.Context) {
+	var body models.QualificationDocumentRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	expiresAt, err := validation.ParseFutureTime(body.ExpiresAt)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid expires_at", err)
+		return
+	}
+
+	var issuedAt *time.Time
+	if body.IssuedAt != "" {
+		t, err := time.Parse(constants.Layout, body.IssuedAt)
+		if err != nil {
+			handleError(c, http.StatusBadRequest, "Invalid issued_at", errors.New(constants.ErrFormatInput))
+			return
+		}
+		issuedAt = &t
+	}
+
+	doc := models.QualificationDocument{
+		UserID:        userID,
+		Type:          body.Type,
+		Qualification: validation.NormalizeQualification(body.Qualification),
+		Number:        body.Number,
+		FileURL:       body.FileURL,
+		IssuedAt:      issuedAt,
+		ExpiresAt:     expiresAt,
+	}
+
+	if err := ct.Storage.Create(&doc).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to create document", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusCreated, doc)
+}
+
+// DeleteDocument godoc
+// @Summary      Delete a qualification document
+// @Description  Removes one of the authenticated contractor's documents.
+// @Tags         contractors
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Document ID"
+// @Success      200 {string} string "Document deleted successfully"
+// @Failure      404 {object} Response "Document not found"
+// @Router       /contractors/documents/{id} [delete]
+func (ct *ContractorController) DeleteDocument(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	result := ct.Storage.Where("id = ? AND user_id = ?", c.Param("id"), userID).Delete(&models.QualificationDocument{})
+	if result.Error != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to delete document", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		handleError(c, http.StatusNotFound, "Document not found", nil)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Document deleted successfully")
+}
+
+func (ct *ContractorController) respondProfile(c *gin.Context, userID interface{}) {
+	var profile models.ContractorProfile
+	var docs []models.QualificationDocument
+
+	if err := ct.Storage.Preload("Categories").Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Contractor profile not found", err)
+		return
+	}
+	profile.SpecialisationList = profile.SplitSpecialisations()
+
+	if err := ct.Storage.Where("user_id = ?", userID).Order("expires_at ASC").Find(&docs).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch documents", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"profile":   profile,
+		"documents": docs,
+	})
+}
@@ -1,8 +1,12 @@
 package controllers
 
 type Controller struct {
-	Auth   *AuthController
-	Tender *TenderController
-	Offer  *OfferController
-	Notif  *NotifController
+	Auth       *AuthController
+	Tender     *TenderController
+	Offer      *OfferController
+	Notif      *NotifController
+	APIKey     *APIKeyController
+	Org        *OrganizationController
+	Contractor *ContractorController
+	Attachment *AttachmentController
 }
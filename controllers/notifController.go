@@ -2,26 +2,29 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 	"tender_management/models"
+	"tender_management/service"
+	"tender_management/storage"
 
 	"github.com/gin-gonic/gin"
-	"errors"
-	"gorm.io/gorm"
 )
 
 type NotifController struct {
-	Storage *gorm.DB
+	Notifs *service.NotifService
 }
 
-func NewNotifController(storage *gorm.DB) *NotifController {
+func NewNotifController(notifs *service.NotifService) *NotifController {
 	return &NotifController{
-		Storage: storage,
+		Notifs: notifs,
 	}
 }
 
 // @Summary      Create Notification
-// @Description  Yangi xabar yaratish (Client yoki Contractor uchun)
+// @Description  Yangi xabar yaratish (Client yoki Contractor uchun). Faqat admin uchun:
+// @Description  odatiy xabarlar tender va taklif hodisalaridan avtomatik yaratiladi.
 // @Tags         Notifications
+// @Security     BearerAuth
 // @Accept       json
 // @Produce      json
 // @Param        body  body  models.NotifRequest  true  "Notification Body"
@@ -38,49 +41,259 @@ func (n *NotifController) CreateNotif(c *gin.Context) {
 		return
 	}
 
-	notif := models.Notif{
-		UserID: body.UserID,
-		Message: body.Message,
-		RelationID: body.RelationID,
-		Type: body.Type,
-	}
-
-	if err := n.Storage.Create(&notif).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to create notification", err)
+	notif, err := n.Notifs.Create(body)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
 	HandleResponse(c, http.StatusOK, notif)
-} 
+}
 
 // @Summary      Get User Notification
-// @Description  User uchun o‘ziga tegishli xabarni olish
+// @Description  User uchun o‘ziga tegishli xabarlarni olish. Faqat token egasining xabarlari qaytariladi.
 // @Tags         Notifications
 // @Produce      json
 // @Security     BearerAuth
 // @Param        user_id      path  string  true  "User ID"
 // @Param        relation_id  path  string  true  "Relation ID"
-// @Success      200  {object}  models.Notif
+// @Success      200  {array}   models.Notif
+// @Failure      403  {object}  Response  "Forbidden"
 // @Failure      404  {object}  Response  "Notif not found"
 // @Failure      400  {object}  Response  "Bad Request"
 // @Router       /notifs/{user_id}/{relation_id} [get]
 func (n *NotifController) GetNotifsUser(c *gin.Context) {
-	userID := c.Param("user_id")
-	relationID := c.Param("relation_id")
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
 
-	var notif models.Notif
+	if c.Param("user_id") != strconv.FormatUint(uint64(userID), 10) {
+		handleError(c, http.StatusForbidden, "You can only read your own notifications", nil)
+		return
+	}
 
-	if err := n.Storage.Where("user_id = ? AND relation_id = ?", userID, relationID).First(&notif).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			handleError(c, http.StatusNotFound, "Notif not found", err)
-			return
-		}
-		handleError(c, http.StatusBadRequest, "Something went wrong while fetching the notification", err)
+	relationID, ok := pathID(c, "relation_id", "Notif not found")
+	if !ok {
 		return
 	}
-	
-	HandleResponse(c, http.StatusOK, notif)
+
+	notifs, err := n.Notifs.ForRelation(userID, relationID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, notifs)
+}
+
+// @Summary      Notification inbox
+// @Description  Token egasining xabarlari, sahifalab. type va unread bo‘yicha filtrlash mumkin.
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query  int     false  "Page number"
+// @Param        pageSize  query  int     false  "Page size"
+// @Param        type      query  string  false  "Notification type"
+// @Param        unread    query  bool    false  "Only unread notifications"
+// @Param        archived  query  bool    false  "Show archived notifications instead of the inbox"
+// @Success      200  {object}  Response
+// @Failure      500  {object}  Response  "Internal Server Error"
+// @Router       /notifs [get]
+func (n *NotifController) GetInbox(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	page, pageSize := getPaginationParams(c)
+
+	notifs, totalRecords, err := n.Notifs.Inbox(userID, storage.InboxFilter{
+		Type:     models.NotifType(c.Query("type")),
+		Unread:   c.Query("unread") == "true",
+		Archived: c.Query("archived") == "true",
+	}, page, pageSize)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"totalRecords": totalRecords,
+		"currentPage":  page,
+		"pageSize":     pageSize,
+		"notifs":       notifs,
+	})
+}
+
+// @Summary      Unread notification count
+// @Description  O‘qilmagan xabarlar soni (badge uchun).
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  Response
+// @Failure      500  {object}  Response  "Internal Server Error"
+// @Router       /notifs/unread-count [get]
+func (n *NotifController) GetUnreadCount(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	count, err := n.Notifs.UnreadCount(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"unread": count,
+	})
+}
+
+// @Summary      Mark notification as read
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Notification ID"
+// @Success      200  {string}  string  "Notification marked as read"
+// @Failure      404  {object}  Response  "Notif not found"
+// @Router       /notifs/{id}/read [patch]
+func (n *NotifController) MarkRead(c *gin.Context) {
+	n.updateOwn(c, n.Notifs.MarkRead, "Notification marked as read")
 }
 
+// @Summary      Mark all notifications as read
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  Response
+// @Failure      500  {object}  Response  "Internal Server Error"
+// @Router       /notifs/read-all [patch]
+func (n *NotifController) MarkAllRead(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	updated, err := n.Notifs.MarkAllRead(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
+	HandleResponse(c, http.StatusOK, gin.H{
+		"updated": updated,
+	})
+}
 
+// @Summary      Archive notification
+// @Description  Xabarni arxivlash: u inboxdan yo‘qoladi, lekin archived=true bilan ko‘rinadi.
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Notification ID"
+// @Success      200  {string}  string  "Notification archived"
+// @Failure      404  {object}  Response  "Notif not found"
+// @Router       /notifs/{id}/archive [patch]
+func (n *NotifController) ArchiveNotif(c *gin.Context) {
+	n.updateOwn(c, n.Notifs.Archive, "Notification archived")
+}
+
+// @Summary      Delete notification
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  string  true  "Notification ID"
+// @Success      200  {string}  string  "Notification deleted"
+// @Failure      404  {object}  Response  "Notif not found"
+// @Router       /notifs/{id} [delete]
+func (n *NotifController) DeleteNotif(c *gin.Context) {
+	n.updateOwn(c, n.Notifs.Delete, "Notification deleted")
+}
+
+// updateOwn applies update to one of the authenticated user's notifications.
+// Notifications of other users are reported as not found.
+func (n *NotifController) updateOwn(c *gin.Context, update func(userID, id uint) error, message string) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	id, ok := pathID(c, "id", "Notif not found")
+	if !ok {
+		return
+	}
+
+	if err := update(userID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, message)
+}
+
+// @Summary      Get notification preferences
+// @Description  Har bir xabar turi uchun yetkazish kanallari (in-app, email, SMS, webhook) va rejimi.
+// @Description  Sozlanmagan turlar uchun standart qiymat: faqat in-app, darhol.
+// @Tags         Notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.NotifPreference
+// @Failure      500  {object}  Response  "Internal Server Error"
+// @Router       /notifs/preferences [get]
+func (n *NotifController) GetPreferences(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	prefs, err := n.Notifs.Preferences(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, prefs)
+}
+
+// @Summary      Set notification preference
+// @Description  Bitta xabar turi uchun kanallar va rejimni saqlash. digest rejimi faqat emailga tegishli:
+// @Description  xabarlar kuniga bir marta bitta emailga yig‘iladi.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        body  body  models.NotifPreferenceRequest  true  "Preference"
+// @Success      200  {object}  models.NotifPreference
+// @Failure      400  {object}  Response  "Bad Request"
+// @Failure      500  {object}  Response  "Internal Server Error"
+// @Router       /notifs/preferences [put]
+func (n *NotifController) SetPreference(c *gin.Context) {
+	var body models.NotifPreferenceRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	pref, err := n.Notifs.SetPreference(userID, body)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, pref)
+}
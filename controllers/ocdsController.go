@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"tender_management/config"
+	"tender_management/pkg/ocds"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OCDSController struct {
+	Storage *gorm.DB
+	Config  *config.Config
+	Builder *ocds.Builder
+}
+
+func NewOCDSController(storage *gorm.DB, cfg *config.Config) *OCDSController {
+	return &OCDSController{
+		Storage: storage,
+		Config:  cfg,
+		Builder: ocds.NewBuilder(storage, ocds.Publication{
+			Prefix:        cfg.OCDSPrefix,
+			PublisherName: cfg.OCDSPublisherName,
+			PublisherURI:  cfg.OCDSPublisherURI,
+			BaseURL:       cfg.PublicURL,
+		}),
+	}
+}
+
+// GetReleasePackage godoc
+// @Summary      OCDS release package
+// @Description  Streams an Open Contracting Data Standard 1.1 release package with one release per tender whose
+// @Description  release date falls in the range: tenders updated in it and tenders whose bids were unsealed in it.
+// @Description  Bids, tenderers and awards only appear after the tender deadline.
+// @Tags         ocds
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query  string  false  "Releases dated on or after this date, YYYY-MM-DD"
+// @Param        to    query  string  false  "Releases dated before this date, YYYY-MM-DD"
+// @Success      200  {object}  ocds.ReleasePackage
+// @Failure      400  {object}  Response  "Invalid date"
+// @Router       /ocds/releases [get]
+func (o *OCDSController) GetReleasePackage(c *gin.Context) {
+	from, to, ok := dateRange(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.Status(http.StatusOK)
+	if err := o.Builder.WriteReleasePackage(c.Writer, o.packageURI(c), ocds.Changed(o.Storage, from, to)); err != nil {
+		log.Printf("Failed to stream OCDS release package: %v", err)
+	}
+}
+
+// GetRecordPackage godoc
+// @Summary      OCDS record package
+// @Description  Streams an OCDS 1.1 record package for the same tenders as the release package, one record per
+// @Description  tender with its release embedded and compiled.
+// @Tags         ocds
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from  query  string  false  "Releases dated on or after this date, YYYY-MM-DD"
+// @Param        to    query  string  false  "Releases dated before this date, YYYY-MM-DD"
+// @Success      200  {object}  ocds.RecordPackage
+// @Failure      400  {object}  Response  "Invalid date"
+// @Router       /ocds/records [get]
+func (o *OCDSController) GetRecordPackage(c *gin.Context) {
+	from, to, ok := dateRange(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	c.Status(http.StatusOK)
+	if err := o.Builder.WriteRecordPackage(c.Writer, o.packageURI(c), ocds.Changed(o.Storage, from, to)); err != nil {
+		log.Printf("Failed to stream OCDS record package: %v", err)
+	}
+}
+
+// GetTenderRelease godoc
+// @Summary      OCDS release of a tender
+// @Description  The current release of one tender, wrapped in a release package.
+// @Tags         ocds
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id  path  int  true  "Tender ID"
+// @Success      200  {object}  ocds.ReleasePackage
+// @Failure      404  {object}  Response  "Tender not found"
+// @Failure      500  {object}  Response  "Internal server error"
+// @Router       /tenders/{id}/ocds [get]
+func (o *OCDSController) GetTenderRelease(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid tender ID", err)
+		return
+	}
+
+	release, err := o.Builder.Release(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			handleError(c, http.StatusNotFound, "Tender not found", err)
+			return
+		}
+		handleError(c, http.StatusInternalServerError, "Failed to build release", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, o.Builder.NewReleasePackage(o.packageURI(c), release))
+}
+
+// packageURI is the address the package was requested from.
+func (o *OCDSController) packageURI(c *gin.Context) string {
+	return publicBaseURL(c, o.Config) + c.Request.URL.RequestURI()
+}
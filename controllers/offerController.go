@@ -4,24 +4,47 @@ import (
 	"net/http"
 	"tender_management/constants"
 	"tender_management/models"
-	"time"
+	"tender_management/pkg/listing"
+	"tender_management/service"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
+// offerListing is the filter, sort and fields whitelist of offer lists.
+var offerListing = listing.Resource{
+	IDColumn:    "offers.id",
+	DefaultSort: "-created_at",
+	Fields: map[string]listing.Field{
+		"id":               {Column: "offers.id", Kind: listing.Number, Filterable: true, Sortable: true},
+		"tender_id":        {Column: "offers.tender_id", Kind: listing.Number, Filterable: true, Sortable: true},
+		"contractor_id":    {Column: "offers.contractor_id", Kind: listing.Number, Filterable: true, Sortable: true},
+		"price":            {Column: "offers.price", Kind: listing.Decimal, Filterable: true, Sortable: true},
+		"currency":         {Column: "offers.currency", Kind: listing.String, Filterable: true},
+		"normalized_price": {Column: "offers.normalized_price", Kind: listing.Decimal, Filterable: true, Sortable: true},
+		"exchange_rate":    {Column: "offers.exchange_rate", Kind: listing.Decimal},
+		"rate_date":        {Column: "offers.rate_date", Kind: listing.Time, Filterable: true},
+		"delivery_time":    {Column: "offers.delivery_time", Kind: listing.Time, Filterable: true, Sortable: true},
+		"comments":         {Column: "offers.comments", Kind: listing.String, Filterable: true},
+		"status":           {Column: "offers.status", Kind: listing.Bool, Filterable: true},
+		"organization_id":  {Column: "offers.organization_id", Kind: listing.Number, Filterable: true},
+		"created_at":       {Column: "offers.created_at", Kind: listing.Time, Filterable: true, Sortable: true},
+		"updated_at":       {Column: "offers.updated_at", Kind: listing.Time, Filterable: true, Sortable: true},
+	},
+}
+
 type OfferController struct {
-	Storage *gorm.DB
+	Offers *service.OfferService
 }
 
-func NewOfferController(storage *gorm.DB) *OfferController {
+func NewOfferController(offers *service.OfferService) *OfferController {
 	return &OfferController{
-		Storage: storage,
+		Offers: offers,
 	}
 }
 
 // @Summary      Create a new offer
-// @Description  This endpoint creates a new offer with the provided details.
+// @Description  This endpoint creates a new offer with the provided details. contractor_id must be the caller, or a
+// @Description  member of the organization_id the caller edits.
 // @Tags         offers
 // @Security 	 BearerAuth
 // @Accept       json
@@ -39,22 +62,10 @@ func (o *OfferController) CreateOffer(c *gin.Context) {
 		return
 	}
 
-	deliveryTime, err := parseValidateDeliveryTime(body.DeliveryTime)
+	userID, _ := getUserID(c)
+	offer, err := o.Offers.Create(userID, body)
 	if err != nil {
-		handleError(c, http.StatusBadRequest, "invalid delivery time format", err)
-		return
-	}
-	offer := models.Offers{
-		TenderID:     body.TenderID,
-		ContractorID: body.ContractorID,
-		Price:        body.Price,
-		DeliveryTime: deliveryTime,
-		Comments:     body.Comments,
-		Status:       body.Status,
-	}
-
-	if err := o.Storage.Create(&offer).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to create offer", err)
+		respondError(c, err)
 		return
 	}
 
@@ -62,49 +73,50 @@ func (o *OfferController) CreateOffer(c *gin.Context) {
 }
 
 // @Summary      Get all offers
-// @Description  Retrieve a paginated list of all offers.
+// @Description  Retrieve offers with filtering, sorting, field selection and pagination, e.g.
+// @Description  ?filter[tender_id]=5&filter[price][lte]=1000&sort=price,-delivery_time&fields=id,price.
+// @Description  Filterable: id, tender_id, contractor_id, price, delivery_time, comments, status, organization_id,
+// @Description  created_at, updated_at. Sortable: id, tender_id, contractor_id, price, delivery_time, created_at, updated_at.
+// @Description  Only the caller's offers, their own or their organizations', are listed, and the offers on tenders the
+// @Description  caller manages once their deadline has passed; offers stay sealed until then.
 // @Tags         offers
 // @Security 	 BearerAuth
 // @Produce      json
+// @Param        filter[field][op]  query  string  false  "Filter, e.g. filter[price][lte]=1000; op is eq (default), ne, gt, gte, lt, lte, in or contains"
+// @Param        sort      query     string  false  "Comma separated fields, - for descending, e.g. price,-delivery_time"
+// @Param        fields    query     string  false  "Comma separated fields to return, e.g. id,price"
 // @Param        page      query     int  false  "Page number"
-// @Param        pageSize  query     int  false  "Page size"
+// @Param        pageSize  query     int  false  "Page size (max 100)"
+// @Param        cursor    query     string  false  "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor"
+// @Param        total     query     bool  false  "With cursor, also count totalRecords"
 // @Success      200       {array}   models.Offers
+// @Failure      400       {object}  Response  "Invalid filter, sort or fields"
 // @Failure      500       {object}  Response  "Failed to fetch offers"
 // @Router       /offers [get]
 func (o *OfferController) GetAllOffers(c *gin.Context) {
-
-	page, pageSize := getPaginationParams(c)
-
-	offset := (page - 1) * pageSize
-
-	var offer []models.Offers
-
-	if err := o.Storage.Where("deleted_at IS NULL").Limit(pageSize).Offset(offset).Find(&offer).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, constants.ErrRecordNotFound, err)
-		return
-	}
-
-	HandleResponse(c, http.StatusOK, gin.H{
-		"count": len(offer),
-		"offers": offer,
-	})
+	o.listOffers(c, offerListing)
 }
 
 // @Summary      Get a specific offer
-// @Description  Retrieve an offer by its ID.
+// @Description  Retrieve an offer of a contractor, who must be the caller.
 // @Tags         offers
 // @Security 	 BearerAuth
 // @Produce      json
 // @Param        contractor_id  path      string  true  "Contractor ID"
 // @Success      200            {object}  models.Offers
+// @Failure      403            {object}  Response "You can only fetch your own offers"
 // @Failure      404            {object}  Response "Offer not found"
 // @Router       /offers/{contractor_id} [get]
 func (o *OfferController) GetOffer(c *gin.Context) {
-	contractorID := c.Param("contractor_id")
-	var offer models.Offers
+	contractorID, ok := pathID(c, "contractor_id", constants.ErrRecordNotFound)
+	if !ok {
+		return
+	}
 
-	if err := o.Storage.Where("contractor_id = ? AND deleted_at IS NULL", contractorID).First(&offer).Error; err != nil {
-		handleError(c, http.StatusNotFound, constants.ErrRecordNotFound, err)
+	userID, _ := getUserID(c)
+	offer, err := o.Offers.ByContractor(userID, contractorID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 	HandleResponse(c, http.StatusOK, offer)
@@ -112,94 +124,92 @@ func (o *OfferController) GetOffer(c *gin.Context) {
 
 // GetFilterSort    godoc
 // @Summary 		Get filtered and sorted offers with pagination
-// @Description 	This endpoint retrieves a list of offers with pagination, sorted by price and delivery time.
-// It also provides the total number of offers matching the filters (excluding deleted offers).
+// @Description 	This endpoint retrieves a list of offers with pagination, sorted by normalized price and delivery time
+// @Description 	unless sort is given. The normalized price is the offer price in its tender's currency, at the exchange
+// @Description 	rate stored with the offer: the latest rate when it was submitted, revalued at the deadline rate shortly
+// @Description 	after the tender deadline passes. It takes the same filter, sort and fields parameters as GET /offers and
+// @Description 	also provides the total number of offers matching the filters (excluding deleted offers). Like GET /offers
+// @Description 	it only lists the caller's offers and the unsealed offers on tenders the caller manages.
 // @Tags            offers
 // @Security 		BearerAuth
 // @Accept  		json
 // @Produce 		json
+// @Param 			filter[field][op] query string false "Filter, e.g. filter[tender_id]=5"
+// @Param 			sort query string false "Comma separated fields, - for descending; normalized_price,delivery_time when omitted"
+// @Param 			fields query string false "Comma separated fields to return"
 // @Param 			page query int false "Page number"
-// @Param 			pageSize query int false "Number of offers per page"
+// @Param 			pageSize query int false "Number of offers per page (max 100)"
+// @Param 			cursor query string false "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor"
+// @Param 			total query bool false "With cursor, also count totalRecords"
 // @Success 		200 {object} Response "Successful response with offers and total count"
+// @Failure 		400 {object} Response "Invalid filter, sort or fields"
 // @Failure 		500 {object} Response "Internal server error"
 // @Router          /offers/sorted [get]
 func (o *OfferController) GetFilterSort(c *gin.Context) {
-	var offers []models.Offers
-	var totalRecords int64
-
-	page, pageSize := getPaginationParams(c)
-
-	offset := (page - 1) * pageSize
+	resource := offerListing
+	resource.DefaultSort = "normalized_price,delivery_time"
+	o.listOffers(c, resource)
+}
 
-	if err := o.Storage.Model(&offers).
-		Where("deleted_at IS NULL").Count(&totalRecords).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to count offers", err)
+// listOffers writes one page of the live offers the caller may see matching the
+// request.
+func (o *OfferController) listOffers(c *gin.Context, resource listing.Resource) {
+	params, err := listing.Parse(c.Request.URL.Query(), resource)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
-	if err := o.Storage.Model(&offers).
-		Where("deleted_at IS NULL").Limit(pageSize).
-		Offset(offset).Order("price ASC").Order("delivery_time ASC").
-		Find(&offers).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to fetch offers", err)
+	userID, _ := getUserID(c)
+	offers, meta, err := o.Offers.List(userID, params)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
-		
-	HandleResponse(c, http.StatusOK, gin.H{
-		"totalRecords": totalRecords,
-		"currentPage":  page,
-		"pageSize":     pageSize,
-		"offers":       offers,
-	})
+
+	respondList(c, "offers", offers, params, meta)
 }
 
 // GetMaxMinfilter godoc
-// @Summary     Get min, max prices and delivery times with filtered count
-// @Description Retrieves offers with minimum and maximum prices and delivery times, along with their counts.
+// @Summary     Get offer statistics per tender
+// @Description Returns, for every tender with matching offers, the offer count, min/max/mean/median price, the spread
+// @Description against the budget, the delivery distribution and the offers at each extreme. Only tenders the caller
+// @Description manages, as client or organization editor, whose deadline has passed are included, as offers stay
+// @Description sealed until then. Prices are normalized to the tender currency at the rates of its deadline.
+// @Description Statistics never mix tenders; tenders are not divided into lots, so there is no per-lot breakdown.
+// @Description The filter parameters of GET /offers narrow the offers, e.g. filter[tender_id][in]=4,5 or
+// @Description filter[created_at][gte]=2025-01-01.
 // @Tags        offers
 // @Security 	BearerAuth
 // @Accept      json
 // @Produce     json
-// @Success     200 {object} Response "Details of min/max offers and counts"
+// @Param       filter[field][op] query string false "Filter, e.g. filter[tender_id]=5"
+// @Success     200 {array} models.TenderStats
+// @Failure     400 {object} Response "Invalid filter"
 // @Failure     500 {object} Response "Error message"
 // @Router      /offers/filter [get]
 func (o *OfferController) GetMaxMinFilter(c *gin.Context) {
-	var offers []models.Offers
-	var stats models.Stats
-
-	query := `
-		SELECT 
-			MIN(price) AS min_price, 
-			MAX(price) AS max_price, 
-			MIN(delivery_time) AS min_delivery, 
-			MAX(delivery_time) AS max_delivery,
-			COUNT(*) AS total_records
-		FROM offers
-		WHERE deleted_at IS NULL
-	`
-	if err := o.Storage.Raw(query).Scan(&stats).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to fetch statistics", err)
+	params, err := listing.Parse(c.Request.URL.Query(), offerListing)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
-	if err := o.Storage.Model(&offers).
-		Where("deleted_at IS NULL").
-		Where("price = ? OR price = ? OR delivery_time = ? OR delivery_time = ?",
-			stats.MinPrice, stats.MaxPrice, stats.MinDelivery, stats.MaxDelivery).
-		Find(&offers).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to fetch filtered offers", err)
+	userID, _ := getUserID(c)
+	stats, err := o.Offers.Stats(userID, params)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
 	HandleResponse(c, http.StatusOK, gin.H{
-		"stats":  stats,
-		"count":  len(offers),
-		"offers": offers,
+		"count": len(stats),
+		"stats": stats,
 	})
 }
 
 // @Summary      Update an existing offer
-// @Description  Update the details of an offer by its ID.
+// @Description  Update the details of an offer by its ID. Its tender_id and contractor_id cannot change.
 // @Tags         offers
 // @Security 	 BearerAuth
 // @Accept       json
@@ -211,7 +221,10 @@ func (o *OfferController) GetMaxMinFilter(c *gin.Context) {
 // @Failure      404  {object}  Response  "Offer not found"
 // @Router       /offers/{id} [put]
 func (o *OfferController) UpdateOffer(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathID(c, "id", "Offer not found")
+	if !ok {
+		return
+	}
 
 	var newOffer models.OffersRequest
 
@@ -220,27 +233,26 @@ func (o *OfferController) UpdateOffer(c *gin.Context) {
 		return
 	}
 
-	deliveryTime, err := parseValidateDeliveryTime(newOffer.DeliveryTime)
+	userID, _ := getUserID(c)
+	offer, err := o.Offers.Update(userID, id, newOffer)
 	if err != nil {
-		handleError(c, http.StatusBadRequest, "invalid delivery time format", err)
+		respondError(c, err)
 		return
 	}
 
-	updatefields := map[string]interface{}{
-		"tender_id":     newOffer.TenderID,
-		"contractor_id": newOffer.ContractorID,
-		"price":         newOffer.Price,
-		"delivery_time": deliveryTime,
-		"comments":      newOffer.Comments,
-		"status":        newOffer.Status,
-	}
-
-	if err := o.Storage.Model(&models.Offers{}).Where("id = ?", id).Updates(updatefields).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to update offer", err)
-		return
-	}
-
-	HandleResponse(c, http.StatusOK, updatefields)
+	HandleResponse(c, http.StatusOK, gin.H{
+		"tender_id":        offer.TenderID,
+		"contractor_id":    offer.ContractorID,
+		"price":            offer.Price,
+		"currency":         offer.Currency,
+		"normalized_price": offer.NormalizedPrice,
+		"exchange_rate":    offer.ExchangeRate,
+		"rate_date":        offer.RateDate,
+		"delivery_time":    offer.DeliveryTime,
+		"comments":         offer.Comments,
+		"status":           offer.Status,
+		"organization_id":  offer.OrganizationID,
+	})
 }
 
 // @Summary      Soft delete an offer
@@ -252,25 +264,14 @@ func (o *OfferController) UpdateOffer(c *gin.Context) {
 // @Failure      404  {object}  Response  "Offer not found"
 // @Router       /offers/{id} [delete]
 func (o *OfferController) DeleteOffer(c *gin.Context) {
-	id := c.Param("id")
-
-	var offer models.Offers
-
-	if err := getByID(o.Storage, id, &offer); err != nil {
-		handleError(c, http.StatusNotFound, constants.ErrRecordNotFound, err)
-		return
-	}
-
-	if offer.DeletedAt != nil {
-		handleError(c, http.StatusNotFound, "Offer already deleted", nil)
+	id, ok := pathID(c, "id", constants.ErrRecordNotFound)
+	if !ok {
 		return
 	}
 
-	new := time.Now()
-	offer.DeletedAt = &new
-
-	if err := o.Storage.Save(&offer).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to soft delete offer", err)
+	userID, _ := getUserID(c)
+	if err := o.Offers.Delete(userID, id); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -285,25 +286,15 @@ func (o *OfferController) DeleteOffer(c *gin.Context) {
 // @Success      200  {string}  string  "Offer restored successfully"
 // @Failure      404  {object}  Response  "Offer not found"
 // @Router       /offers/restore/{id} [patch]
-func (t *OfferController) RestoreOffer(c *gin.Context) {
-	id := c.Param("id")
-
-	var offer models.Offers
-
-	if err := t.Storage.Unscoped().Where("id = ?", id).First(&offer).Error; err != nil {
-		handleError(c, http.StatusNotFound, "Failed to find offer or it may not be soft deleted", err)
-		return
-	}
-
-	if offer.DeletedAt == nil {
-		handleError(c, http.StatusBadRequest, "Offer is not soft deleted", nil)
+func (o *OfferController) RestoreOffer(c *gin.Context) {
+	id, ok := pathID(c, "id", "Failed to find offer or it may not be soft deleted")
+	if !ok {
 		return
 	}
 
-	offer.DeletedAt = nil
-
-	if err := t.Storage.Save(&offer).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to restore offer", err)
+	userID, _ := getUserID(c)
+	if err := o.Offers.Restore(userID, id); err != nil {
+		respondError(c, err)
 		return
 	}
 
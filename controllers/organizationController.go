@@ -0,0 +1,410 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"tender_management/config"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/outbox"
+	"tender_management/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+type OrganizationController struct {
+	Storage *gorm.DB
+	Config  *config.Config
+}
+
+func NewOrganizationController(storage *gorm.DB, cfg *config.Config) *OrganizationController {
+	return &OrganizationController{
+		Storage: storage,
+		Config:  cfg,
+	}
+}
+
+// CreateOrganization godoc
+// @Summary      Create an organization
+// @Description  Creates a company the authenticated user acts on behalf of. The creator becomes its owner.
+// @Description  The organization kind follows the creator's role (client or contractor).
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.OrganizationRequest true "Organization"
+// @Success      201 {object} models.Organization
+// @Failure      400 {object} Response "Bad request"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /organizations [post]
+func (o *OrganizationController) CreateOrganization(c *gin.Context) {
+	var body models.OrganizationRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	org := models.Organization{
+		Name: body.Name,
+		Kind: c.GetString(constants.CtxRole),
+	}
+
+	err := o.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&org).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: org.ID,
+			UserID:         userID,
+			Role:           models.OrgRoleOwner,
+		}).Error
+	})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to create organization", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusCreated, org)
+}
+
+// GetOrganizations godoc
+// @Summary      List my organizations
+// @Description  Lists the organizations the authenticated user is a member of.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} models.Organization
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /organizations [get]
+func (o *OrganizationController) GetOrganizations(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var orgs []models.Organization
+	if err := o.Storage.
+		Joins("JOIN organization_members ON organization_members.organization_id = organizations.id").
+		Where("organization_members.user_id = ? AND organizations.deleted_at IS NULL", userID).
+		Find(&orgs).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch organizations", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, orgs)
+}
+
+// GetOrganization godoc
+// @Summary      Get an organization
+// @Description  Returns an organization with its members. Only visible to members.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Success      200 {object} models.Organization
+// @Failure      403 {object} Response "Not a member"
+// @Failure      404 {object} Response "Organization not found"
+// @Router       /organizations/{id} [get]
+func (o *OrganizationController) GetOrganization(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var org models.Organization
+	if err := o.Storage.Preload("Members").Where("id = ? AND deleted_at IS NULL", id).First(&org).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Organization not found", err)
+		return
+	}
+
+	if orgMemberRole(org.Members, userID) == "" {
+		handleError(c, http.StatusForbidden, "You are not a member of this organization", nil)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, org)
+}
+
+// InviteMember godoc
+// @Summary      Invite a member
+// @Description  Emails an invitation token to join the organization with the given role. Owners only.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        body body models.InvitationRequest true "Invitation"
+// @Success      201 {object} models.OrganizationInvitation
+// @Failure      400 {object} Response "Bad request"
+// @Failure      403 {object} Response "Owners only"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /organizations/{id}/invitations [post]
+func (o *OrganizationController) InviteMember(c *gin.Context) {
+	var body models.InvitationRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	org, userID, ok := o.requireOwner(c)
+	if !ok {
+		return
+	}
+
+	token, err := utils.RandomHex(32)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate invitation token", err)
+		return
+	}
+
+	expiresAt := time.Now().Add(invitationTTL)
+	invitation := models.OrganizationInvitation{
+		OrganizationID: org.ID,
+		Email:          strings.ToLower(body.Email),
+		Role:           body.Role,
+		TokenHash:      utils.HashToken(token),
+		InvitedBy:      userID,
+		ExpiresAt:      &expiresAt,
+	}
+
+	message := fmt.Sprintf("You have been invited to join %s as %s.\n\nYour invitation token is: %s\n\nIt expires on %s.",
+		org.Name, body.Role, token, expiresAt.Format(constants.Layout))
+
+	err = o.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&invitation).Error; err != nil {
+			return err
+		}
+		return outbox.Email(tx, invitation.Email, "Organization invitation", message)
+	})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to create invitation", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusCreated, invitation)
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept an invitation
+// @Description  Joins the organization using the emailed token. The token must belong to the authenticated user's email.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.AcceptInvitationRequest true "Invitation token"
+// @Success      200 {object} models.OrganizationMember
+// @Failure      400 {object} Response "Invalid or expired invitation"
+// @Failure      403 {object} Response "Invitation addressed to another user"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /organizations/invitations/accept [post]
+func (o *OrganizationController) AcceptInvitation(c *gin.Context) {
+	var body models.AcceptInvitationRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var invitation models.OrganizationInvitation
+	if err := o.Storage.Preload("Organization").
+		Where("token_hash = ? AND accepted_at IS NULL AND expires_at > ?", utils.HashToken(body.Token), time.Now()).
+		First(&invitation).Error; err != nil {
+		handleError(c, http.StatusBadRequest, "Invitation not found or expired", err)
+		return
+	}
+
+	if !strings.EqualFold(invitation.Email, c.GetString(constants.CtxEmail)) {
+		handleError(c, http.StatusForbidden, "Invitation was sent to another email", nil)
+		return
+	}
+
+	if invitation.Organization == nil || invitation.Organization.Kind != c.GetString(constants.CtxRole) {
+		handleError(c, http.StatusForbidden, "Your role cannot join this organization", nil)
+		return
+	}
+
+	member := models.OrganizationMember{
+		OrganizationID: invitation.OrganizationID,
+		UserID:         userID,
+		Role:           invitation.Role,
+	}
+
+	err := o.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ? AND user_id = ?", member.OrganizationID, userID).
+			Assign(models.OrganizationMember{Role: member.Role}).
+			FirstOrCreate(&member).Error; err != nil {
+			return err
+		}
+		return tx.Model(&invitation).Update("accepted_at", time.Now()).Error
+	})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to accept invitation", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, member)
+}
+
+// UpdateMemberRole godoc
+// @Summary      Change a member's role
+// @Description  Changes the in-organization role of a member. Owners only. The last owner cannot be demoted.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        user_id path string true "User ID"
+// @Param        body body models.MemberRoleRequest true "Role"
+// @Success      200 {object} models.OrganizationMember
+// @Failure      400 {object} Response "Bad request"
+// @Failure      403 {object} Response "Owners only"
+// @Failure      404 {object} Response "Member not found"
+// @Router       /organizations/{id}/members/{user_id} [put]
+func (o *OrganizationController) UpdateMemberRole(c *gin.Context) {
+	var body models.MemberRoleRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	org, _, ok := o.requireOwner(c)
+	if !ok {
+		return
+	}
+
+	var member models.OrganizationMember
+	if err := o.Storage.Where("organization_id = ? AND user_id = ?", org.ID, c.Param("user_id")).First(&member).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Member not found", err)
+		return
+	}
+
+	if member.Role == models.OrgRoleOwner && body.Role != models.OrgRoleOwner && countOwners(org.Members) == 1 {
+		handleError(c, http.StatusBadRequest, "Organization must keep at least one owner", nil)
+		return
+	}
+
+	if err := o.Storage.Model(&member).Update("role", body.Role).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to update member role", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, member)
+}
+
+// RemoveMember godoc
+// @Summary      Remove a member
+// @Description  Removes a member from the organization. Owners can remove anyone; members can remove themselves.
+// @Tags         organizations
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Organization ID"
+// @Param        user_id path string true "User ID"
+// @Success      200 {string} string "Member removed successfully"
+// @Failure      400 {object} Response "Last owner cannot leave"
+// @Failure      403 {object} Response "Not allowed"
+// @Failure      404 {object} Response "Member not found"
+// @Router       /organizations/{id}/members/{user_id} [delete]
+func (o *OrganizationController) RemoveMember(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var org models.Organization
+	if err := o.Storage.Preload("Members").Where("id = ? AND deleted_at IS NULL", c.Param("id")).First(&org).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Organization not found", err)
+		return
+	}
+
+	var member models.OrganizationMember
+	if err := o.Storage.Where("organization_id = ? AND user_id = ?", org.ID, c.Param("user_id")).First(&member).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Member not found", err)
+		return
+	}
+
+	if member.UserID != userID && orgMemberRole(org.Members, userID) != models.OrgRoleOwner {
+		handleError(c, http.StatusForbidden, "Only owners can remove other members", nil)
+		return
+	}
+
+	if member.Role == models.OrgRoleOwner && countOwners(org.Members) == 1 {
+		handleError(c, http.StatusBadRequest, "Organization must keep at least one owner", nil)
+		return
+	}
+
+	if err := o.Storage.Delete(&member).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to remove member", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Member removed successfully")
+}
+
+// requireOwner loads the organization from the :id path parameter and
+// checks that the authenticated user owns it, writing the error response
+// itself when not.
+func (o *OrganizationController) requireOwner(c *gin.Context) (models.Organization, uint, bool) {
+	var org models.Organization
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return org, 0, false
+	}
+
+	if err := o.Storage.Preload("Members").Where("id = ? AND deleted_at IS NULL", c.Param("id")).First(&org).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Organization not found", err)
+		return org, 0, false
+	}
+
+	if orgMemberRole(org.Members, userID) != models.OrgRoleOwner {
+		handleError(c, http.StatusForbidden, "Only organization owners can do this", nil)
+		return org, 0, false
+	}
+
+	return org, userID, true
+}
+
+func orgMemberRole(members []models.OrganizationMember, userID uint) string {
+	for _, m := range members {
+		if m.UserID == userID {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+func countOwners(members []models.OrganizationMember) int {
+	n := 0
+	for _, m := range members {
+		if m.Role == models.OrgRoleOwner {
+			n++
+		}
+	}
+	return n
+}
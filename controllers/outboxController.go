@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"net/http"
+	"tender_management/models"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type OutboxController struct {
+	Storage *gorm.DB
+}
+
+func NewOutboxController(storage *gorm.DB) *OutboxController {
+	return &OutboxController{
+		Storage: storage,
+	}
+}
+
+// ListMessages godoc
+// @Summary      List outbox messages
+// @Description  Events, emails and webhooks waiting for or already delivered by the outbox relay, newest first. Admins only.
+// @Tags         outbox
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page      query  int     false  "Page number"
+// @Param        pageSize  query  int     false  "Page size"
+// @Param        status    query  string  false  "pending, sent or failed"
+// @Param        kind      query  string  false  "event, email, webhook or notif.delivery"
+// @Param        key       query  string  false  "Idempotency key"
+// @Success      200  {object}  Response
+// @Failure      500  {object}  Response  "Internal server error"
+// @Router       /admin/outbox [get]
+func (o *OutboxController) ListMessages(c *gin.Context) {
+	page, pageSize := getPaginationParams(c)
+	offset := (page - 1) * pageSize
+
+	query := o.Storage.Model(&models.OutboxMessage{})
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if key := c.Query("key"); key != "" {
+		query = query.Where("idempotency_key = ?", key)
+	}
+
+	var totalRecords int64
+	if err := query.Count(&totalRecords).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to count outbox messages", err)
+		return
+	}
+
+	var msgs []models.OutboxMessage
+	if err := query.Order("id DESC").Limit(pageSize).Offset(offset).Find(&msgs).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch outbox messages", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"totalRecords": totalRecords,
+		"currentPage":  page,
+		"pageSize":     pageSize,
+		"messages":     msgs,
+	})
+}
+
+// GetMessage godoc
+// @Summary      Get an outbox message
+// @Description  Returns one outbox message with its payload, attempts and last error. Admins only.
+// @Tags         outbox
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Message ID"
+// @Success      200 {object} models.OutboxMessage
+// @Failure      404 {object} Response "Message not found"
+// @Router       /admin/outbox/{id} [get]
+func (o *OutboxController) GetMessage(c *gin.Context) {
+	var msg models.OutboxMessage
+	if err := o.Storage.First(&msg, c.Param("id")).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Outbox message not found", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, msg)
+}
+
+// RetryMessage godoc
+// @Summary      Retry a failed outbox message
+// @Description  Puts a message that ran out of attempts back in the queue with a fresh attempt budget. Admins only.
+// @Tags         outbox
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Message ID"
+// @Success      200 {string} string "Message queued for retry"
+// @Failure      404 {object} Response "Failed message not found"
+// @Router       /admin/outbox/{id}/retry [post]
+func (o *OutboxController) RetryMessage(c *gin.Context) {
+	result := o.Storage.Model(&models.OutboxMessage{}).
+		Where("id = ? AND status = ?", c.Param("id"), models.OutboxFailed).
+		Updates(map[string]interface{}{
+			"status":          models.OutboxPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	if result.Error != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to retry outbox message", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		handleError(c, http.StatusNotFound, "Failed outbox message not found", nil)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Message queued for retry")
+}
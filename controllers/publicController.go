@@ -0,0 +1,336 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"tender_management/config"
+	"tender_management/models"
+	"tender_management/pkg/categories"
+	"tender_management/pkg/feed"
+	"tender_management/pkg/filestore"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	// publicMaxAge is how long, in seconds, clients and proxies may cache
+	// portal responses.
+	publicMaxAge = 60
+	// feedSize is the number of newest tenders in the feeds.
+	feedSize = 50
+)
+
+// PublicController serves the unauthenticated portal. It only ever shows
+// open tenders and never anything about offers.
+type PublicController struct {
+	Storage *gorm.DB
+	Files   filestore.Storage
+	Config  *config.Config
+}
+
+func NewPublicController(storage *gorm.DB, files filestore.Storage, cfg *config.Config) *PublicController {
+	return &PublicController{
+		Storage: storage,
+		Files:   files,
+		Config:  cfg,
+	}
+}
+
+// ListTenders godoc
+// @Summary      List open tenders
+// @Description  Published tenders still accepting offers, newest first. No authentication; responses may be cached
+// @Description  for a minute and requests are rate limited per IP.
+// @Tags         public
+// @Produce      json
+// @Param        category  query  string  false  "Comma separated category codes; tenders in any of them or their subcategories"
+// @Param        page      query  int     false  "Page number"
+// @Param        pageSize  query  int     false  "Page size (max 100)"
+// @Success      200  {array}   models.PublicTender
+// @Failure      400  {object}  Response  "Unknown category"
+// @Failure      429  {object}  Response  "Too many requests"
+// @Router       /public/tenders [get]
+func (p *PublicController) ListTenders(c *gin.Context) {
+	page, pageSize := getPaginationParams(c)
+
+	query := p.openTenders()
+	if codes := c.Query("category"); codes != "" {
+		cats, err := categories.Resolve(p.Storage, strings.Split(codes, ","))
+		if err != nil {
+			handleError(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		query = query.Where("id IN (?)", categories.TenderIDs(p.Storage, cats))
+	}
+
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to count tenders", err)
+		return
+	}
+
+	var tenders []models.Tenders
+	if err := query.Preload("Categories").Order("created_at DESC, id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).Find(&tenders).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch tenders", err)
+		return
+	}
+
+	public, err := p.publicTenders(c, tenders)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch attachments", err)
+		return
+	}
+
+	p.respondJSON(c, gin.H{
+		"totalRecords": totalRecords,
+		"currentPage":  page,
+		"pageSize":     pageSize,
+		"tenders":      public,
+	})
+}
+
+// GetTender godoc
+// @Summary      Get an open tender
+// @Description  One published tender still accepting offers. No authentication.
+// @Tags         public
+// @Produce      json
+// @Param        id  path  int  true  "Tender ID"
+// @Success      200  {object}  models.PublicTender
+// @Failure      404  {object}  Response  "Tender not found"
+// @Failure      429  {object}  Response  "Too many requests"
+// @Router       /public/tenders/{id} [get]
+func (p *PublicController) GetTender(c *gin.Context) {
+	var tender models.Tenders
+	if err := p.openTenders().Preload("Categories").Where("id = ?", c.Param("id")).First(&tender).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Tender not found", err)
+		return
+	}
+
+	public, err := p.publicTenders(c, []models.Tenders{tender})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch attachments", err)
+		return
+	}
+
+	p.respondJSON(c, public[0])
+}
+
+// DownloadAttachment godoc
+// @Summary      Download a tender attachment
+// @Description  Streams an attachment of an open tender. No authentication.
+// @Tags         public
+// @Produce      octet-stream
+// @Param        id             path  int  true  "Tender ID"
+// @Param        attachment_id  path  int  true  "Attachment ID"
+// @Success      200  {file}    file
+// @Failure      404  {object}  Response  "Attachment not found"
+// @Failure      429  {object}  Response  "Too many requests"
+// @Router       /public/tenders/{id}/attachments/{attachment_id} [get]
+func (p *PublicController) DownloadAttachment(c *gin.Context) {
+	var attachment models.Attachment
+	err := p.Storage.
+		Where("id = ? AND owner_type = ? AND owner_id = ? AND deleted_at IS NULL",
+			c.Param("attachment_id"), models.AttachmentOwnerTender, c.Param("id")).
+		Where("owner_id IN (?)", p.openTenders().Select("id")).
+		First(&attachment).Error
+	if err != nil {
+		handleError(c, http.StatusNotFound, "Attachment not found", err)
+		return
+	}
+
+	file, err := p.Files.Open(c, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, filestore.ErrNotFound) {
+			handleError(c, http.StatusNotFound, "Attachment file missing", err)
+			return
+		}
+		handleError(c, http.StatusInternalServerError, "Failed to open attachment", err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", attachment.FileName),
+		"Cache-Control":       fmt.Sprintf("public, max-age=%d", publicMaxAge),
+		"ETag":                fmt.Sprintf("%q", attachment.SHA256),
+		"X-Checksum-SHA256":   attachment.SHA256,
+	})
+}
+
+// GetRSSFeed godoc
+// @Summary      RSS feed of new tenders
+// @Description  The newest open tenders as an RSS 2.0 feed. No authentication.
+// @Tags         public
+// @Produce      xml
+// @Success      200  {string}  string  "RSS document"
+// @Failure      429  {object}  Response  "Too many requests"
+// @Router       /public/feed.rss [get]
+func (p *PublicController) GetRSSFeed(c *gin.Context) {
+	p.respondFeed(c, "application/rss+xml; charset=utf-8", feed.WriteRSS)
+}
+
+// GetAtomFeed godoc
+// @Summary      Atom feed of new tenders
+// @Description  The newest open tenders as an Atom 1.0 feed. No authentication.
+// @Tags         public
+// @Produce      xml
+// @Success      200  {string}  string  "Atom document"
+// @Failure      429  {object}  Response  "Too many requests"
+// @Router       /public/feed.atom [get]
+func (p *PublicController) GetAtomFeed(c *gin.Context) {
+	p.respondFeed(c, "application/atom+xml; charset=utf-8", feed.WriteAtom)
+}
+
+func (p *PublicController) respondFeed(c *gin.Context, contentType string, write func(w io.Writer, f feed.Feed) error) {
+	var tenders []models.Tenders
+	if err := p.openTenders().Preload("Categories").Order("created_at DESC, id DESC").
+		Limit(feedSize).Find(&tenders).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch tenders", err)
+		return
+	}
+
+	base := publicBaseURL(c, p.Config)
+	f := feed.Feed{
+		Title:       "Open tenders",
+		Description: "Newly published tenders accepting offers",
+		Link:        base + "/public/tenders",
+		Self:        base + c.Request.URL.Path,
+		Updated:     time.Now().UTC(),
+	}
+	for i, t := range tenders {
+		entry := feed.Entry{
+			ID:      fmt.Sprintf("%s/public/tenders/%d", base, t.ID),
+			Title:   t.Title,
+			Summary: t.Description,
+			Link:    fmt.Sprintf("%s/public/tenders/%d", base, t.ID),
+		}
+		if t.CreatedAt != nil {
+			entry.Published = *t.CreatedAt
+		}
+		entry.Updated = entry.Published
+		if t.UpdatedAt != nil {
+			entry.Updated = *t.UpdatedAt
+		}
+		if i == 0 || entry.Updated.After(f.Updated) {
+			f.Updated = entry.Updated
+		}
+		for _, cat := range t.Categories {
+			entry.Category = append(entry.Category, cat.Code)
+		}
+		f.Entries = append(f.Entries, entry)
+	}
+
+	var buf bytes.Buffer
+	if err := write(&buf, f); err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to build feed", err)
+		return
+	}
+	respondCached(c, contentType, buf.Bytes())
+}
+
+// openTenders selects the tenders the portal may show.
+func (p *PublicController) openTenders() *gorm.DB {
+	return p.Storage.Model(&models.Tenders{}).
+		Where("state = ? AND deleted_at IS NULL AND deadline > ?", models.TenderStateOpen, time.Now())
+}
+
+// publicTenders copies the public fields of tenders, which must have their
+// Categories loaded, and lists their attachments.
+func (p *PublicController) publicTenders(c *gin.Context, tenders []models.Tenders) ([]models.PublicTender, error) {
+	ids := make([]uint, len(tenders))
+	for i, t := range tenders {
+		ids[i] = t.ID
+	}
+
+	byTender := make(map[uint][]models.PublicAttachment)
+	if len(ids) > 0 {
+		var attachments []models.Attachment
+		if err := p.Storage.Where("owner_type = ? AND owner_id IN ? AND deleted_at IS NULL", models.AttachmentOwnerTender, ids).
+			Order("id").Find(&attachments).Error; err != nil {
+			return nil, err
+		}
+
+		base := publicBaseURL(c, p.Config)
+		for _, a := range attachments {
+			byTender[a.OwnerID] = append(byTender[a.OwnerID], models.PublicAttachment{
+				ID:          a.ID,
+				FileName:    a.FileName,
+				ContentType: a.ContentType,
+				Size:        a.Size,
+				URL:         fmt.Sprintf("%s/public/tenders/%d/attachments/%d", base, a.OwnerID, a.ID),
+			})
+		}
+	}
+
+	public := make([]models.PublicTender, len(tenders))
+	for i, t := range tenders {
+		public[i] = models.PublicTender{
+			ID:                 t.ID,
+			Title:              t.Title,
+			Description:        t.Description,
+			Currency:           t.Currency,
+			AcceptedCurrencies: t.SplitAcceptedCurrencies(),
+			Deadline:           t.Deadline,
+			PublishedAt:        t.CreatedAt,
+			UpdatedAt:          t.UpdatedAt,
+			Categories:         []models.PublicCategory{},
+			Attachments:        byTender[t.ID],
+		}
+		if !t.HideBudget {
+			budget := t.Budget
+			public[i].Budget = &budget
+		}
+		for _, cat := range t.Categories {
+			public[i].Categories = append(public[i].Categories, models.PublicCategory{Code: cat.Code, Name: cat.Name})
+		}
+		if public[i].Attachments == nil {
+			public[i].Attachments = []models.PublicAttachment{}
+		}
+	}
+	return public, nil
+}
+
+func (p *PublicController) respondJSON(c *gin.Context, message interface{}) {
+	body, err := json.Marshal(Response{Message: message})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to encode response", err)
+		return
+	}
+	respondCached(c, "application/json; charset=utf-8", body)
+}
+
+// respondCached writes body with caching headers, or 304 Not Modified when
+// the client already holds it.
+func respondCached(c *gin.Context, contentType string, body []byte) {
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", publicMaxAge))
+	c.Header("ETag", etag)
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// publicBaseURL is the configured public URL, or else the address the
+// request came in on.
+func publicBaseURL(c *gin.Context, cfg *config.Config) string {
+	if cfg.PublicURL != "" {
+		return strings.TrimRight(cfg.PublicURL, "/")
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
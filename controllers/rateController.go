@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"tender_management/config"
+	"tender_management/models"
+	"tender_management/pkg/rates"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RateController struct {
+	Storage *gorm.DB
+	Config  *config.Config
+}
+
+func NewRateController(storage *gorm.DB, cfg *config.Config) *RateController {
+	return &RateController{
+		Storage: storage,
+		Config:  cfg,
+	}
+}
+
+// ListRates godoc
+// @Summary      List exchange rates
+// @Description  Returns the latest rate of each currency published on or before date, today when omitted. A rate is
+// @Description  the value of one unit of the currency in the base currency, the configured default (UZS).
+// @Tags         exchange-rates
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date  query  string  false  "Date, YYYY-MM-DD"
+// @Success      200 {object} Response "Base currency, date and rates"
+// @Failure      400 {object} Response "Invalid date"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /exchange-rates [get]
+func (rc *RateController) ListRates(c *gin.Context) {
+	asOf := time.Now()
+	if value := c.Query("date"); value != "" {
+		t, err := time.Parse(dateLayout, value)
+		if err != nil {
+			handleError(c, http.StatusBadRequest, "date must be a date in the format YYYY-MM-DD", nil)
+			return
+		}
+		asOf = t
+	}
+
+	table, err := rates.Load(rc.Storage, rc.Config.Currency, asOf)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch exchange rates", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"base":  table.Base,
+		"date":  table.AsOf.Format(dateLayout),
+		"rates": table.Rates(),
+	})
+}
+
+// ImportRates godoc
+// @Summary      Import exchange rates
+// @Description  Creates or replaces exchange rates from a Central Bank file. Upload a .json array as published by the
+// @Description  Central Bank of Uzbekistan, [{"Ccy": "USD", "Nominal": "1", "Rate": "12739.22", "Date": "17.10.2025"}],
+// @Description  or a .csv file with a "currency,rate,date[,nominal]" header. Rates quoted per nominal are stored per
+// @Description  unit. Dates are YYYY-MM-DD or DD.MM.YYYY.
+// @Tags         exchange-rates
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "Rate file (.csv or .json)"
+// @Success      200 {object} Response "Number of imported rates"
+// @Failure      400 {object} Response "Invalid file"
+// @Router       /admin/exchange-rates/import [post]
+func (rc *RateController) ImportRates(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "File is required", err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to read file", err)
+		return
+	}
+	defer file.Close()
+
+	var parse func(io.Reader) ([]models.ExchangeRateRow, error)
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".csv":
+		parse = rates.ParseCSV
+	case ".json":
+		parse = rates.ParseJSON
+	default:
+		handleError(c, http.StatusBadRequest, "Only .csv and .json files are supported", nil)
+		return
+	}
+
+	rows, err := parse(file)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid file: "+err.Error(), err)
+		return
+	}
+
+	count, err := rates.Import(rc.Storage, rows, filepath.Base(header.Filename))
+	if err != nil {
+		handleError(c, http.StatusBadRequest, "Import failed: "+err.Error(), err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{"imported": count})
+}
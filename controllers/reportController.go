@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/reports"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultReportLimit = 10
+	maxReportLimit     = 100
+)
+
+type ReportController struct {
+	Storage *gorm.DB
+}
+
+func NewReportController(storage *gorm.DB) *ReportController {
+	return &ReportController{
+		Storage: storage,
+	}
+}
+
+// GetSummary godoc
+// @Summary      Procurement summary report
+// @Description  Tenders, awards, savings against budget, average bids and average days to award, grouped by month,
+// @Description  category, client or organization. Clients see their own and their organizations' tenders, admins
+// @Description  everything. Figures come from a snapshot refreshed every few minutes; see refreshed_at. Amounts are
+// @Description  in the tender currency and never summed across currencies: each group has a row per currency.
+// @Tags         report
+// @Security     BearerAuth
+// @Produce      json
+// @Produce      text/csv
+// @Param        group_by  query  string  false  "month (default), category, client or organization"
+// @Param        from      query  string  false  "Tenders published on or after this date, YYYY-MM-DD"
+// @Param        to        query  string  false  "Tenders published before this date, YYYY-MM-DD"
+// @Param        format    query  string  false  "json (default) or csv"
+// @Success      200  {object}  models.Report
+// @Failure      400  {object}  Response  "Invalid parameters"
+// @Failure      500  {object}  Response  "Internal server error"
+// @Router       /reports/summary [get]
+func (r *ReportController) GetSummary(c *gin.Context) {
+	q, format, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+	q.GroupBy = c.DefaultQuery("group_by", models.ReportByMonth)
+
+	report, err := reports.Summary(r.Storage, q)
+	if err != nil {
+		if errors.Is(err, reports.ErrGroupBy) {
+			handleError(c, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		handleError(c, http.StatusInternalServerError, "Failed to build report", err)
+		return
+	}
+
+	if format == "csv" {
+		writeReportCSV(c, "summary-"+q.GroupBy, func() error {
+			return reports.WriteSummaryCSV(c.Writer, report.Rows.([]models.ReportRow))
+		})
+		return
+	}
+	HandleResponse(c, http.StatusOK, report)
+}
+
+// GetTopContractors godoc
+// @Summary      Top contractors report
+// @Description  Contractors ranked by tenders won, with awarded value and savings against budget. Scoped and
+// @Description  refreshed like the summary report. Contractors have a row per tender currency they won in.
+// @Tags         report
+// @Security     BearerAuth
+// @Produce      json
+// @Produce      text/csv
+// @Param        limit   query  int     false  "Number of contractors, default 10, at most 100"
+// @Param        from    query  string  false  "Tenders published on or after this date, YYYY-MM-DD"
+// @Param        to      query  string  false  "Tenders published before this date, YYYY-MM-DD"
+// @Param        format  query  string  false  "json (default) or csv"
+// @Success      200  {object}  models.Report
+// @Failure      400  {object}  Response  "Invalid parameters"
+// @Failure      500  {object}  Response  "Internal server error"
+// @Router       /reports/top-contractors [get]
+func (r *ReportController) GetTopContractors(c *gin.Context) {
+	q, format, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultReportLimit)))
+	if err != nil || limit < 1 || limit > maxReportLimit {
+		handleError(c, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxReportLimit), nil)
+		return
+	}
+
+	report, err := reports.TopContractors(r.Storage, q, limit)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to build report", err)
+		return
+	}
+
+	if format == "csv" {
+		writeReportCSV(c, "top-contractors", func() error {
+			return reports.WriteContractorsCSV(c.Writer, report.Rows.([]models.ContractorReportRow))
+		})
+		return
+	}
+	HandleResponse(c, http.StatusOK, report)
+}
+
+// RefreshReports godoc
+// @Summary      Refresh the report snapshot
+// @Description  Rebuilds the analytics snapshot now instead of waiting for the scheduled refresh. Admins only.
+// @Tags         report
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200  {object}  Response
+// @Failure      500  {object}  Response  "Internal server error"
+// @Router       /admin/reports/refresh [post]
+func (r *ReportController) RefreshReports(c *gin.Context) {
+	if err := reports.Refresh(r.Storage.WithContext(c.Request.Context())); err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to refresh reports", err)
+		return
+	}
+	HandleResponse(c, http.StatusOK, "Reports refreshed")
+}
+
+// reportQuery reads the parameters shared by the reports and scopes
+// non-admins to their own tenders.
+func reportQuery(c *gin.Context) (reports.Query, string, bool) {
+	var q reports.Query
+
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		handleError(c, http.StatusBadRequest, "format must be json or csv", nil)
+		return q, "", false
+	}
+
+	var ok bool
+	if q.From, q.To, ok = dateRange(c); !ok {
+		return q, "", false
+	}
+
+	if c.GetString(constants.CtxRole) != constants.RoleAdmin {
+		userID, ok := getUserID(c)
+		if !ok {
+			handleError(c, http.StatusUnauthorized, "Unauthorized", nil)
+			return q, "", false
+		}
+		q.UserID = userID
+	}
+	return q, format, true
+}
+
+func writeReportCSV(c *gin.Context, name string, write func() error) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	if err := write(); err != nil {
+		log.Printf("Failed to write report CSV: %v", err)
+	}
+}
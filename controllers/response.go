@@ -3,14 +3,20 @@ package controllers
 import (
 	"errors"
 	"log"
+	"net/http"
 	"strconv"
 	"tender_management/constants"
+	"tender_management/pkg/listing"
+	"tender_management/service"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
+// dateLayout is the format of date-only query parameters.
+const dateLayout = "2006-01-02"
+
 type Response struct {
 	Message interface{} `json:"message"`
 }
@@ -25,15 +31,38 @@ func handleError(c *gin.Context, statuscode int, message string, err error) {
 	HandleResponse(c, statuscode, message)
 }
 
-func parseValidateDeliveryTime(deliveryTimeStr string) (*time.Time, error) {
-	deliveryTime, err := time.Parse(constants.Layout, deliveryTimeStr)
-	if err != nil {
-		return nil, errors.New(constants.ErrFormatInput)
+// respondError writes the error a service returned with the status its
+// kind maps to.
+func respondError(c *gin.Context, err error) {
+	var e *service.Error
+	if !errors.As(err, &e) {
+		handleError(c, http.StatusInternalServerError, "Internal server error", err)
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch e.Kind {
+	case service.Invalid:
+		status = http.StatusBadRequest
+	case service.Unauthorized:
+		status = http.StatusUnauthorized
+	case service.Forbidden:
+		status = http.StatusForbidden
+	case service.NotFound:
+		status = http.StatusNotFound
 	}
-	if !deliveryTime.After(time.Now()) {
-		return nil, errors.New(constants.ErrDeadlinePassed)
+	handleError(c, status, e.Message, e.Err)
+}
+
+// pathID reads a numeric ID path parameter. An ID that is not a number
+// names nothing, so it is answered with notFound.
+func pathID(c *gin.Context, name, notFound string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil {
+		handleError(c, http.StatusNotFound, notFound, err)
+		return 0, false
 	}
-	return &deliveryTime, nil
+	return uint(id), true
 }
 
 func getPaginationParams(c *gin.Context) (int, int) {
@@ -46,10 +75,87 @@ func getPaginationParams(c *gin.Context) (int, int) {
 	if err != nil || pageSize < 1 {
 		pageSize = 10
 	}
+	if pageSize > listing.MaxPageSize {
+		pageSize = listing.MaxPageSize
+	}
 
 	return page, pageSize
 }
 
+// respondList writes a page of a list endpoint: the pagination metadata and
+// the items under key, reduced to the requested fields. Keyset pages carry
+// next and prev links with the cursor filled in, null at either end.
+func respondList(c *gin.Context, key string, items interface{}, params listing.Params, meta listing.Meta) {
+	projected, err := params.Project(items)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to encode "+key, err)
+		return
+	}
+
+	body := gin.H{
+		"pageSize": meta.PageSize,
+		key:        projected,
+	}
+	if meta.TotalRecords != nil {
+		body["totalRecords"] = *meta.TotalRecords
+	}
+
+	if params.Keyset() {
+		body["nextCursor"] = meta.NextCursor
+		body["prevCursor"] = meta.PrevCursor
+		body["next"] = pageLink(c, meta.NextCursor)
+		body["prev"] = pageLink(c, meta.PrevCursor)
+	} else {
+		body["currentPage"] = meta.CurrentPage
+		body["totalPages"] = meta.TotalPages
+	}
+
+	HandleResponse(c, http.StatusOK, body)
+}
+
+// pageLink is the current request URI with its cursor replaced by token.
+func pageLink(c *gin.Context, token string) interface{} {
+	if token == "" {
+		return nil
+	}
+
+	u := *c.Request.URL
+	query := u.Query()
+	query.Set("cursor", token)
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// dateRange reads the optional from and to query dates, YYYY-MM-DD, as a
+// half-open range.
+func dateRange(c *gin.Context) (from, to *time.Time, ok bool) {
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := c.Query(bound.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(dateLayout, value)
+		if err != nil {
+			handleError(c, http.StatusBadRequest, bound.name+" must be a date in the format YYYY-MM-DD", nil)
+			return nil, nil, false
+		}
+		*bound.dst = &t
+	}
+	return from, to, true
+}
+
+func getUserID(c *gin.Context) (uint, bool) {
+	id, ok := c.Get(constants.CtxUserID)
+	if !ok {
+		return 0, false
+	}
+	userID, ok := id.(uint)
+	return userID, ok && userID != 0
+}
+
 func getByID(db *gorm.DB, id string, model interface{}) error {
 	err := db.Where("id = ? AND deleted_at IS NULL", id).First(&model).Error
 	if err != nil {
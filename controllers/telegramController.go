@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"tender_management/config"
+	"tender_management/models"
+	"tender_management/pkg/redise"
+	"tender_management/pkg/telegram"
+	"tender_management/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TelegramController struct {
+	Storage *gorm.DB
+	Redis   *redise.RedisDB
+	Config  *config.Config
+}
+
+func NewTelegramController(storage *gorm.DB, redis *redise.RedisDB, cfg *config.Config) *TelegramController {
+	return &TelegramController{
+		Storage: storage,
+		Redis:   redis,
+		Config:  cfg,
+	}
+}
+
+// CreateLinkCode godoc
+// @Summary      Get a Telegram link code
+// @Description  Returns a one-time code, valid for 10 minutes, to send to the bot as "/link <code>".
+// @Description  When the bot name is configured a t.me deep link that sends it automatically is included.
+// @Tags         telegram
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} Response "Code, deep link and expiry"
+// @Failure      503 {object} Response "Telegram bot is not configured"
+// @Router       /telegram/link-code [post]
+func (t *TelegramController) CreateLinkCode(c *gin.Context) {
+	if t.Config.TelegramBotToken == "" {
+		handleError(c, http.StatusServiceUnavailable, "Telegram bot is not configured", nil)
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	code, err := utils.RandomHex(4)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate code", err)
+		return
+	}
+	code = strings.ToUpper(code)
+
+	if err := t.Redis.SetEx(c, telegram.LinkCodeKey(code), userID, telegram.LinkCodeTTL); err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to store code", err)
+		return
+	}
+
+	response := gin.H{
+		"code":       code,
+		"expires_at": time.Now().Add(telegram.LinkCodeTTL),
+	}
+	if t.Config.TelegramBotName != "" {
+		response["link"] = "https://t.me/" + t.Config.TelegramBotName + "?start=" + code
+	}
+
+	HandleResponse(c, http.StatusOK, response)
+}
+
+// GetLink godoc
+// @Summary      Get my Telegram link
+// @Description  Shows whether the authenticated user has linked a Telegram chat.
+// @Tags         telegram
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} models.TelegramLink
+// @Failure      404 {object} Response "Not linked"
+// @Router       /telegram/link [get]
+func (t *TelegramController) GetLink(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var link models.TelegramLink
+	if err := t.Storage.Where("user_id = ?", userID).First(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			handleError(c, http.StatusNotFound, "Telegram is not linked", err)
+			return
+		}
+		handleError(c, http.StatusInternalServerError, "Failed to fetch Telegram link", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, link)
+}
+
+// Unlink godoc
+// @Summary      Unlink Telegram
+// @Description  Stops all bot messages to the authenticated user.
+// @Tags         telegram
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {string} string "Telegram unlinked"
+// @Failure      404 {object} Response "Not linked"
+// @Router       /telegram/link [delete]
+func (t *TelegramController) Unlink(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	result := t.Storage.Where("user_id = ?", userID).Delete(&models.TelegramLink{})
+	if result.Error != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to unlink Telegram", result.Error)
+		return
+	}
+	if result.RowsAffected == 0 {
+		handleError(c, http.StatusNotFound, "Telegram is not linked", nil)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Telegram unlinked")
+}
@@ -1,22 +1,69 @@
 package controllers
 
 import (
+	"bytes"
+	"fmt"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"tender_management/constants"
 	"tender_management/models"
-	"time"
+	"tender_management/pkg/evaluation"
+	"tender_management/pkg/listing"
+	"tender_management/service"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
+// tenderListing is the filter, sort and fields whitelist of tender lists.
+var tenderListing = listing.Resource{
+	IDColumn:    "tenders.id",
+	DefaultSort: "-created_at",
+	Fields: map[string]listing.Field{
+		"id":                  {Column: "tenders.id", Kind: listing.Number, Filterable: true, Sortable: true},
+		"title":               {Column: "tenders.title", Kind: listing.String, Filterable: true, Sortable: true},
+		"description":         {Column: "tenders.description", Kind: listing.String, Filterable: true},
+		"deadline":            {Column: "tenders.deadline", Kind: listing.Time, Filterable: true, Sortable: true},
+		"budget":              {Column: "tenders.budget", Kind: listing.Decimal, Filterable: true, Sortable: true},
+		"currency":            {Column: "tenders.currency", Kind: listing.String, Filterable: true},
+		"accepted_currencies": {Column: "tenders.accepted_currencies", Kind: listing.String},
+		"file_url":            {Column: "tenders.file_url", Kind: listing.String},
+		"status":              {Column: "tenders.status", Kind: listing.Bool, Filterable: true},
+		"state":               {Column: "tenders.state", Kind: listing.String, Filterable: true, Sortable: true},
+		"client_id":           {Column: "tenders.client_id", Kind: listing.Number, Filterable: true, Sortable: true},
+		"organization_id":     {Column: "tenders.organization_id", Kind: listing.Number, Filterable: true},
+		"awarded_offer_id":    {Column: "tenders.awarded_offer_id", Kind: listing.Number, Filterable: true},
+		"created_at":          {Column: "tenders.created_at", Kind: listing.Time, Filterable: true, Sortable: true},
+		"updated_at":          {Column: "tenders.updated_at", Kind: listing.Time, Filterable: true, Sortable: true},
+		"qualifications":      {},
+		"categories":          {},
+		"criteria":            {},
+	},
+}
+
+// tenderSearchListing orders search hits by relevance unless a sort is
+// given, and selects the fields of models.TenderSearchHit.
+var tenderSearchListing = func() listing.Resource {
+	r := listing.Resource{IDColumn: tenderListing.IDColumn, Fields: map[string]listing.Field{}}
+	for name, field := range tenderListing.Fields {
+		if field.Column != "" {
+			r.Fields[name] = field
+		}
+	}
+	for _, name := range []string{"rank", "headline", "snippet"} {
+		r.Fields[name] = listing.Field{}
+	}
+	return r
+}()
+
 type TenderController struct {
-	Storage *gorm.DB
+	Tenders *service.TenderService
 }
 
-func NewTenderController(storage *gorm.DB) *TenderController {
+func NewTenderController(tenders *service.TenderService) *TenderController {
 	return &TenderController{
-		Storage: storage,
+		Tenders: tenders,
 	}
 }
 
@@ -40,23 +87,10 @@ func (t *TenderController) CreateTender(c *gin.Context) {
 		return
 	}
 
-	deadline, err := parseValidateDeliveryTime(body.Deadline)
+	userID, _ := getUserID(c)
+	tender, err := t.Tenders.Create(userID, body)
 	if err != nil {
-		handleError(c, http.StatusBadRequest, "invalid deadline time format", err)
-		return
-	}
-
-	tender := models.Tenders{
-		Title:       body.Title,
-		Description: body.Description,
-		Deadline:    deadline,
-		Budget:      body.Budget,
-		FileURL:     body.FileURL,
-		ClientID:    body.ClientID,
-	}
-
-	if err := t.Storage.Create(&tender).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to create tender", err)
+		respondError(c, err)
 		return
 	}
 
@@ -65,58 +99,268 @@ func (t *TenderController) CreateTender(c *gin.Context) {
 
 // GetTenders 	godoc
 // @Summary 	Get tenders by client ID
-// @Description Retrieve all tenders for a specific client
+// @Description Retrieve all tenders for a specific client. Accepts the same filter, sort and fields parameters as GET /tenders.
 // @Security 	BearerAuth
 // @Tags 		tender
 // @Produce 	json
 // @Param 		client_id path string true "Client ID"
-// @Success 	200 {object} models.Tenders
+// @Param 		filter[field][op] query string false "Filter, e.g. filter[budget][gte]=1000; op is eq (default), ne, gt, gte, lt, lte, in or contains"
+// @Param 		sort query string false "Comma separated fields, - for descending, e.g. -deadline,budget"
+// @Param 		fields query string false "Comma separated fields to return, e.g. id,title"
+// @Param 		page query int false "Page number"
+// @Param 		pageSize query int false "Page size (max 100)"
+// @Param 		cursor query string false "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor"
+// @Param 		total query bool false "With cursor, also count totalRecords"
+// @Success 	200 {array} models.Tenders
+// @Failure 	400 {object} Response "Invalid filter, sort or fields"
 // @Failure 	500 {object} Response "Internal Server Error"
 // @Router 		/tenders/{client_id} [get]
 func (t *TenderController) GetTenders(c *gin.Context) {
-	clientID := c.Param("client_id")
+	params, err := listing.Parse(c.Request.URL.Query(), tenderListing)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
 
-	var tenders models.Tenders
-	if err := t.Storage.Where("client_id = ? and deleted_at IS NULL", clientID).First(&tenders).Error; err != nil {
-		handleError(c, http.StatusNotFound, "Failed to fetch tenders", err)
+	// The route is /tenders/:id because gin needs one wildcard name per
+	// segment and /tenders/:id/offers shares it; the value is a client ID.
+	clientID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || clientID == 0 {
+		handleError(c, http.StatusBadRequest, "Invalid client id", err)
+		return
+	}
+
+	tenders, meta, err := t.Tenders.List(uint(clientID), nil, params)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	HandleResponse(c, http.StatusOK, tenders)
+	respondList(c, "tenders", tenders, params, meta)
 }
 
 // GetAllTenders 	godoc
 // @Summary 		Get all tenders with pagination
-// @Description 	Retrieve all tenders with pagination support
+// @Description 	Retrieve tenders with filtering, sorting, field selection and pagination, e.g.
+// @Description 	?filter[budget][gte]=1000&filter[state]=open&sort=-deadline,budget&fields=id,title.
+// @Description 	Filterable: id, title, description, deadline, budget, status, state, client_id, organization_id,
+// @Description 	awarded_offer_id, created_at, updated_at. Sortable: id, title, deadline, budget, state, client_id,
+// @Description 	created_at, updated_at.
 // @Tags 			tender
 // @Security 		BearerAuth
 // @Produce 		json
+// @Param 			filter[field][op] query string false "Filter, e.g. filter[budget][gte]=1000; op is eq (default), ne, gt, gte, lt, lte, in or contains"
+// @Param 			sort query string false "Comma separated fields, - for descending, e.g. -deadline,budget"
+// @Param 			fields query string false "Comma separated fields to return, e.g. id,title"
 // @Param 			page query int false "Page number"
-// @Param 			pageSize query int false "Page size"
+// @Param 			pageSize query int false "Page size (max 100)"
+// @Param 			cursor query string false "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor"
+// @Param 			total query bool false "With cursor, also count totalRecords"
+// @Param 			category query string false "Comma separated category codes; tenders in any of them or their subcategories"
 // @Success 		200 {array} models.Tenders
+// @Failure 		400 {object} Response "Invalid filter, sort or fields, or unknown category"
 // @Failure 		500 {object} Response "Internal Server Error"
 // @Router 			/tenders [get]
 func (t *TenderController) GetAllTenders(c *gin.Context) {
+	params, err := listing.Parse(c.Request.URL.Query(), tenderListing)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
 
-	page, pageSize := getPaginationParams(c)
+	tenders, meta, err := t.Tenders.List(0, categoryCodes(c), params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
-	offset := (page - 1) * pageSize
+	respondList(c, "tenders", tenders, params, meta)
+}
 
-	var tenders []models.Tenders
-	if err := t.Storage.Where("deleted_at IS NULL").Limit(pageSize).Offset(offset).Find(&tenders).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to fetch tenders", err)
+// categoryCodes reads the comma separated category query parameter.
+func categoryCodes(c *gin.Context) []string {
+	if codes := c.Query("category"); codes != "" {
+		return strings.Split(codes, ",")
+	}
+	return nil
+}
+
+// SearchTenders 	godoc
+// @Summary 		Search tenders
+// @Description 	Full-text search over tender titles, descriptions and plain text attachments, ranked by relevance.
+// @Description 	q accepts web search syntax: "quoted phrases", or, and -excluded words. The headline and snippet
+// @Description 	are HTML: the text is escaped and matches are wrapped in <b>. Filters compose with the text query and take the same
+// @Description 	filter[field][op] parameters as GET /tenders; sort replaces the relevance order.
+// @Tags 			tender
+// @Security 		BearerAuth
+// @Produce 		json
+// @Param 			q query string true "Search text"
+// @Param 			lang query string false "Query language: uz, ru or en; all three when omitted"
+// @Param 			filter[field][op] query string false "Filter, e.g. filter[budget][gte]=1000 or filter[deadline][lte]=2025-01-01"
+// @Param 			category query string false "Comma separated category codes; tenders in any of them or their subcategories"
+// @Param 			sort query string false "Comma separated fields, - for descending; relevance when omitted"
+// @Param 			fields query string false "Comma separated fields to return, e.g. id,headline"
+// @Param 			page query int false "Page number"
+// @Param 			pageSize query int false "Page size (max 100)"
+// @Param 			cursor query string false "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor"
+// @Param 			total query bool false "With cursor, also count totalRecords"
+// @Success 		200 {array} models.TenderSearchHit
+// @Failure 		400 {object} Response "Invalid query or filter"
+// @Failure 		500 {object} Response "Internal Server Error"
+// @Router 			/tenders/search [get]
+func (t *TenderController) SearchTenders(c *gin.Context) {
+	params, err := listing.Parse(c.Request.URL.Query(), tenderSearchListing)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
-	HandleResponse(c, http.StatusOK, gin.H{
-		"count": len(tenders),
-		"offers": tenders,
-	})
+	hits, meta, err := t.Tenders.Search(c.Query("q"), c.Query("lang"), categoryCodes(c), params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondList(c, "tenders", hits, params, meta)
+}
+
+// GetTenderOffers 	godoc
+// @Summary 		List the offers on a tender
+// @Description 	Lists the bids on a tender for the client managing it. Bids are sealed until the deadline.
+// @Description 	Takes the filter, sort, fields and paging parameters of GET /offers.
+// @Tags 			tender
+// @Security 		BearerAuth
+// @Produce 		json
+// @Param 			id path int true "Tender ID"
+// @Param 			filter[field][op] query string false "Filter, e.g. filter[price][lte]=1000"
+// @Param 			sort query string false "Comma separated fields, - for descending; price,delivery_time when omitted"
+// @Param 			fields query string false "Comma separated fields to return"
+// @Param 			page query int false "Page number"
+// @Param 			pageSize query int false "Page size (max 100)"
+// @Param 			cursor query string false "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor"
+// @Param 			total query bool false "With cursor, also count totalRecords"
+// @Success 		200 {array} models.Offers
+// @Failure 		400 {object} Response "Invalid filter, sort or fields"
+// @Failure 		403 {object} Response "Not the tender's client, or bids still sealed"
+// @Failure 		404 {object} Response "Tender not found"
+// @Router 			/tenders/{id}/offers [get]
+func (t *TenderController) GetTenderOffers(c *gin.Context) {
+	id, ok := pathID(c, "id", "Tender not found")
+	if !ok {
+		return
+	}
+
+	resource := offerListing
+	resource.DefaultSort = "price,delivery_time"
+	params, err := listing.Parse(c.Request.URL.Query(), resource)
+	if err != nil {
+		handleError(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	userID, _ := getUserID(c)
+	offers, meta, err := t.Tenders.Offers(userID, id, params)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondList(c, "offers", offers, params, meta)
+}
+
+// CompareOffers 	godoc
+// @Summary 		Compare the offers on a tender
+// @Description 	Lays the bids on a tender side by side for the client managing it: price, deviation from the budget,
+// @Description 	delivery, a 0-100 score per criterion, the weighted total and the rank. Bids in other accepted
+// @Description 	currencies are ranked on their price in the tender currency at the exchange rates of the deadline;
+// @Description 	each row shows the rate and rate date used, which the award fixes. Bids are sealed until the
+// @Description 	deadline. format=csv or format=xlsx downloads the matrix.
+// @Tags 			tender
+// @Security 		BearerAuth
+// @Produce 		json
+// @Produce 		text/csv
+// @Produce 		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param 			id path int true "Tender ID"
+// @Param 			format query string false "json (default), csv or xlsx"
+// @Success 		200 {object} models.TenderComparison
+// @Failure 		400 {object} Response "Unknown format"
+// @Failure 		403 {object} Response "Not the tender's client, or bids still sealed"
+// @Failure 		404 {object} Response "Tender not found"
+// @Failure 		500 {object} Response "Internal Server Error"
+// @Router 			/tenders/{id}/comparison [get]
+func (t *TenderController) CompareOffers(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "xlsx" {
+		handleError(c, http.StatusBadRequest, "format must be json, csv or xlsx", nil)
+		return
+	}
+
+	id, ok := pathID(c, "id", "Tender not found")
+	if !ok {
+		return
+	}
+
+	userID, _ := getUserID(c)
+	cmp, err := t.Tenders.Compare(userID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("tender-%d-comparison.%s", id, format)
+	switch format {
+	case "csv":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		if err := evaluation.WriteCSV(c.Writer, cmp); err != nil {
+			log.Printf("Failed to write comparison CSV: %v", err)
+		}
+	case "xlsx":
+		var buf bytes.Buffer
+		if err := evaluation.WriteXLSX(&buf, cmp); err != nil {
+			handleError(c, http.StatusInternalServerError, "Failed to build spreadsheet", err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+	default:
+		HandleResponse(c, http.StatusOK, cmp)
+	}
+}
+
+// GetTenderStats 	godoc
+// @Summary 		Offer statistics of a tender
+// @Description 	Offer count, min/max/mean/median price, spread against the budget, delivery distribution and the
+// @Description 	offers at each extreme, for the client managing the tender. Prices are in the tender currency at the
+// @Description 	exchange rates of the deadline. Bids are sealed until the deadline.
+// @Tags 			tender
+// @Security 		BearerAuth
+// @Produce 		json
+// @Param 			id path int true "Tender ID"
+// @Success 		200 {object} models.TenderStats
+// @Failure 		403 {object} Response "Not the tender's client, or bids still sealed"
+// @Failure 		404 {object} Response "Tender not found"
+// @Failure 		500 {object} Response "Internal Server Error"
+// @Router 			/tenders/{id}/stats [get]
+func (t *TenderController) GetTenderStats(c *gin.Context) {
+	id, ok := pathID(c, "id", "Tender not found")
+	if !ok {
+		return
+	}
+
+	userID, _ := getUserID(c)
+	stats, err := t.Tenders.Stats(userID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, stats)
 }
 
 // UpdateTender 	godoc
 // @Summary 		Update an existing tender
-// @Description 	Updates the details of an existing tender
+// @Description 	Updates the details of an existing tender while it is open and before its deadline.
 // @Tags 			tender
 // @Security 		BearerAuth
 // @Accept 			json
@@ -124,12 +368,15 @@ func (t *TenderController) GetAllTenders(c *gin.Context) {
 // @Param 			id path string true "Tender ID"
 // @Param 			body body models.TenderRequest true "Updated Tender Body"
 // @Success 		200 {object} Response
-// @Failure 		400 {object} Response "Bad Request"
+// @Failure 		400 {object} Response "Bad Request, or the tender is closed or past its deadline"
 // @Failure 		404 {object} Response "Tender not found"
 // @Failure 		500 {object} Response "Internal Server Error"
 // @Router 			/tenders/{id} [put]
 func (t *TenderController) UpdateTender(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathID(c, "id", "Tender not found")
+	if !ok {
+		return
+	}
 
 	var newtender models.TenderRequest
 
@@ -138,27 +385,28 @@ func (t *TenderController) UpdateTender(c *gin.Context) {
 		return
 	}
 
-	deadline, err := parseValidateDeliveryTime(newtender.Deadline)
+	userID, _ := getUserID(c)
+	tender, err := t.Tenders.Update(userID, id, newtender)
 	if err != nil {
-		handleError(c, http.StatusBadRequest, "Invalid deadline format", err)
+		respondError(c, err)
 		return
 	}
 
-	updatefields := map[string]interface{}{
-		"title":       newtender.Title,
-		"description": newtender.Description,
-		"deadline":    deadline,
-		"budget":      newtender.Budget,
-		"file_url":    newtender.FileURL,
-		"client_id":   newtender.ClientID,
-	}
-
-	if err := t.Storage.Model(&models.Tenders{}).Where("id = ?", id).Updates(updatefields).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to update offer", err)
-		return
-	}
-
-	HandleResponse(c, http.StatusOK, updatefields)
+	HandleResponse(c, http.StatusOK, gin.H{
+		"title":               tender.Title,
+		"description":         tender.Description,
+		"deadline":            tender.Deadline,
+		"budget":              tender.Budget,
+		"currency":            tender.Currency,
+		"accepted_currencies": tender.AcceptedCurrencyList,
+		"hide_budget":         tender.HideBudget,
+		"file_url":            tender.FileURL,
+		"client_id":           tender.ClientID,
+		"organization_id":     tender.OrganizationID,
+		"qualifications":      newtender.Qualifications,
+		"categories":          tender.Categories,
+		"criteria":            tender.Criteria,
+	})
 }
 
 // DeleteTender 	godoc
@@ -175,25 +423,14 @@ func (t *TenderController) UpdateTender(c *gin.Context) {
 // @Failure 		500 {object} Response "Internal server error"
 // @Router 			/tenders/{id} [delete]
 func (t *TenderController) DeleteTender(c *gin.Context) {
-	id := c.Param("id")
-
-	var tender models.Tenders
-
-	if err := getByID(t.Storage, id, &tender); err != nil {
-		handleError(c, http.StatusNotFound, constants.ErrRecordNotFound, err)
-		return
-	}
-
-	if tender.DeletedAt != nil {
-		handleError(c, http.StatusNotFound, "Tender has been deleted", nil)
+	id, ok := pathID(c, "id", constants.ErrRecordNotFound)
+	if !ok {
 		return
 	}
 
-	new := time.Now()
-	tender.DeletedAt = &new
-
-	if err := t.Storage.Save(&tender).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to soft delete tender", err)
+	userID, _ := getUserID(c)
+	if err := t.Tenders.Delete(userID, id); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -213,26 +450,83 @@ func (t *TenderController) DeleteTender(c *gin.Context) {
 // @Failure 		500 {object} Response "Internal server error"
 // @Router 			/tenders/restore/{id} [patch]
 func (t *TenderController) RestoreTender(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := pathID(c, "id", "Failed to find tender or it may not be soft deleted")
+	if !ok {
+		return
+	}
 
-	var tender models.Tenders
+	userID, _ := getUserID(c)
+	if err := t.Tenders.Restore(userID, id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Tender restored successfully")
+}
+
+// AwardTender 	godoc
+// @Summary 		Award a tender to an offer
+// @Description 	Closes an open tender after its deadline and awards it to one of its offers.
+// @Description 	The winning bidder and the other bidders are notified.
+// @Tags 			tender
+// @Security 		BearerAuth
+// @Accept 			json
+// @Produce 		json
+// @Param 			id path string true "Tender ID"
+// @Param 			body body models.AwardRequest true "Winning offer"
+// @Success 		200 {object} models.Tenders
+// @Failure 		400 {object} Response "Tender cannot be awarded"
+// @Failure 		403 {object} Response "Not allowed"
+// @Failure 		404 {object} Response "Tender or offer not found"
+// @Failure 		500 {object} Response "Internal server error"
+// @Router 			/tenders/{id}/award [post]
+func (t *TenderController) AwardTender(c *gin.Context) {
+	id, ok := pathID(c, "id", "Tender not found")
+	if !ok {
+		return
+	}
 
-	if err := t.Storage.Unscoped().Where("id = ?", id).First(&tender).Error; err != nil {
-		handleError(c, http.StatusNotFound, "Failed to find tender or it may not be soft deleted", err)
+	var body models.AwardRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
 		return
 	}
 
-	if tender.DeletedAt == nil {
-		handleError(c, http.StatusBadRequest, "Tender is not soft deleted", nil)
+	userID, _ := getUserID(c)
+	tender, err := t.Tenders.Award(userID, id, body.OfferID)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	tender.DeletedAt = nil
+	HandleResponse(c, http.StatusOK, tender)
+}
 
-	if err := t.Storage.Save(&tender).Error; err != nil {
-		handleError(c, http.StatusInternalServerError, "Failed to restore tender", err)
+// CancelTender 	godoc
+// @Summary 		Cancel a tender
+// @Description 	Cancels an open tender. Every bidder is notified.
+// @Tags 			tender
+// @Security 		BearerAuth
+// @Produce 		json
+// @Param 			id path string true "Tender ID"
+// @Success 		200 {object} models.Tenders
+// @Failure 		400 {object} Response "Tender is not open"
+// @Failure 		403 {object} Response "Not allowed"
+// @Failure 		404 {object} Response "Tender not found"
+// @Failure 		500 {object} Response "Internal server error"
+// @Router 			/tenders/{id}/cancel [post]
+func (t *TenderController) CancelTender(c *gin.Context) {
+	id, ok := pathID(c, "id", "Tender not found")
+	if !ok {
 		return
 	}
 
-	HandleResponse(c, http.StatusOK, "Tender restored successfully")
+	userID, _ := getUserID(c)
+	tender, err := t.Tenders.Cancel(userID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, tender)
 }
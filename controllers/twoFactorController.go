@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"tender_management/models"
+	"tender_management/pkg/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func mfaKey(token string) string {
+	return "mfa:" + token
+}
+
+const (
+	// mfaTokenTTL is how long the mfa_token of a password login is valid.
+	mfaTokenTTL = 5 * time.Minute
+	// mfaMaxAttempts wrong codes invalidate an mfa_token.
+	mfaMaxAttempts = 5
+)
+
+func mfaAttemptsKey(token string) string {
+	return "mfa:attempts:" + token
+}
+
+// EnrollTwoFactor godoc
+// @Summary      Start two-factor enrolment
+// @Description  Generates a new TOTP secret for the authenticated user and returns it with an otpauth URI.
+// @Description  2FA is not active until the secret is confirmed with /auth/2fa/confirm.
+// @Tags         auth
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} Response "Secret and otpauth URI"
+// @Failure      400 {object} Response "Two-factor authentication already enabled"
+// @Failure      401 {object} Response "Unauthorized"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /auth/2fa/enroll [post]
+func (ac *AuthController) EnrollTwoFactor(c *gin.Context) {
+	userID, _ := getUserID(c)
+	secret, uri, err := ac.Users.EnrollTwoFactor(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"secret":      secret,
+		"otpauth_uri": uri,
+	})
+}
+
+// ConfirmTwoFactor godoc
+// @Summary      Confirm two-factor enrolment
+// @Description  Verifies a TOTP code against the pending secret, enables 2FA and returns one-time recovery codes.
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.TwoFactorCodeRequest true "TOTP code"
+// @Success      200 {object} Response "Recovery codes"
+// @Failure      400 {object} Response "Invalid code or enrolment not started"
+// @Failure      401 {object} Response "Unauthorized"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /auth/2fa/confirm [post]
+func (ac *AuthController) ConfirmTwoFactor(c *gin.Context) {
+	var body models.TwoFactorCodeRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, _ := getUserID(c)
+	codes, err := ac.Users.ConfirmTwoFactor(userID, body.Code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"message":        "Two-factor authentication enabled. Log in again to continue",
+		"recovery_codes": codes,
+	})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate recovery codes
+// @Description  Invalidates all existing recovery codes and issues a new set. Requires a valid TOTP code.
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.TwoFactorCodeRequest true "TOTP code"
+// @Success      200 {object} Response "Recovery codes"
+// @Failure      400 {object} Response "Invalid code or 2FA not enabled"
+// @Failure      401 {object} Response "Unauthorized"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /auth/2fa/recovery-codes [post]
+func (ac *AuthController) RegenerateRecoveryCodes(c *gin.Context) {
+	var body models.TwoFactorCodeRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, _ := getUserID(c)
+	codes, err := ac.Users.RegenerateRecoveryCodes(userID, body.Code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactor godoc
+// @Summary      Disable two-factor authentication
+// @Description  Turns off 2FA after checking the password and a TOTP or recovery code.
+// @Description  Not allowed when the user's role requires 2FA.
+// @Tags         auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.TwoFactorDisableRequest true "Password and code"
+// @Success      200 {object} Response "Two-factor authentication disabled"
+// @Failure      400 {object} Response "Bad request"
+// @Failure      401 {object} Response "Invalid password or code"
+// @Failure      403 {object} Response "Two-factor authentication is mandatory for this role"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /auth/2fa/disable [post]
+func (ac *AuthController) DisableTwoFactor(c *gin.Context) {
+	var body models.TwoFactorDisableRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	userID, _ := getUserID(c)
+	if err := ac.Users.DisableTwoFactor(c, userID, body.Password, body.Code); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Two-factor authentication disabled")
+}
+
+// LoginTwoFactor godoc
+// @Summary      Complete login with a second factor
+// @Description  Exchanges the mfa_token returned by /auth/login and a TOTP or recovery code for a JWT token.
+// @Description  After 5 wrong codes the mfa_token is invalidated and the user has to log in again.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.TwoFactorLoginRequest true "MFA token and code"
+// @Success      200 {object} Response "JWT token"
+// @Failure      400 {object} Response "Bad request"
+// @Failure      401 {object} Response "Invalid or expired mfa token or code"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /auth/login/2fa [post]
+func (ac *AuthController) LoginTwoFactor(c *gin.Context) {
+	var body models.TwoFactorLoginRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	value, err := ac.Redis.Get(c, mfaKey(body.MFAToken))
+	if err == redis.Nil {
+		handleError(c, http.StatusUnauthorized, "MFA token not found or expired", err)
+		return
+	} else if err != nil {
+		handleError(c, http.StatusInternalServerError, "Redis server error", err)
+		return
+	}
+
+	userID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Invalid mfa token payload", err)
+		return
+	}
+
+	user, ok, err := ac.Users.SecondFactor(c, uint(userID), body.Code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if !ok {
+		attempts, err := ac.Redis.Incr(c, mfaAttemptsKey(body.MFAToken), mfaTokenTTL)
+		if err != nil {
+			handleError(c, http.StatusInternalServerError, "Redis server error", err)
+			return
+		}
+		if attempts >= mfaMaxAttempts {
+			if err := ac.Redis.Delete(c, mfaKey(body.MFAToken)); err != nil {
+				handleError(c, http.StatusInternalServerError, "Failed to delete mfa token", err)
+				return
+			}
+			handleError(c, http.StatusUnauthorized, "Too many invalid codes, log in again", nil)
+			return
+		}
+		handleError(c, http.StatusUnauthorized, "Invalid two-factor code", nil)
+		return
+	}
+
+	if err := ac.Redis.Delete(c, mfaKey(body.MFAToken)); err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to delete mfa token", err)
+		return
+	}
+
+	token, err := utils.GenerateToken(ac.Config.SecretKey, user.ID, user.Email, user.Role)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate token", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"token": token,
+	})
+}
+
+// SetTwoFactorPolicy godoc
+// @Summary      Set two-factor policy for a role
+// @Description  Makes 2FA mandatory (or optional again) for every user with the given role.
+// @Tags         admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.TwoFactorPolicyRequest true "Policy"
+// @Success      200 {object} models.TwoFactorPolicy
+// @Failure      400 {object} Response "Bad request"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /admin/2fa-policy [put]
+func (ac *AuthController) SetTwoFactorPolicy(c *gin.Context) {
+	var body models.TwoFactorPolicyRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	policy, err := ac.Users.SetTwoFactorPolicy(body.Role, body.Required)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, policy)
+}
+
+// GetTwoFactorPolicies godoc
+// @Summary      List two-factor policies
+// @Description  Returns the 2FA policy of every role that has one.
+// @Tags         admin
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} models.TwoFactorPolicy
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /admin/2fa-policy [get]
+func (ac *AuthController) GetTwoFactorPolicies(c *gin.Context) {
+	policies, err := ac.Users.TwoFactorPolicies()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, policies)
+}
@@ -0,0 +1,379 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"tender_management/config"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/utils"
+	"tender_management/pkg/webhooks"
+	"tender_management/service"
+	"tender_management/validation"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WebhookController struct {
+	Storage *gorm.DB
+	Sender  *webhooks.Sender
+	Access  *service.AccessService
+	Config  *config.Config
+}
+
+func NewWebhookController(storage *gorm.DB, sender *webhooks.Sender, access *service.AccessService, cfg *config.Config) *WebhookController {
+	return &WebhookController{
+		Storage: storage,
+		Sender:  sender,
+		Access:  access,
+		Config:  cfg,
+	}
+}
+
+// CreateEndpoint godoc
+// @Summary      Register a webhook endpoint
+// @Description  Subscribes a URL to tender and offer events. Pass organization_id to register it for an organization you edit.
+// @Description  The signing secret is returned only once. Each request carries X-Tender-Timestamp and
+// @Description  X-Tender-Signature: "sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+// @Description  The URL must be http(s) on a public host; loopback, private and link-local addresses are rejected.
+// @Description  tender.published reaches every subscribed endpoint; other events only reach the tender's client and
+// @Description  its bidders, and offer events only the offer's own bidder.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        body body models.WebhookEndpointRequest true "Endpoint"
+// @Success      201 {object} Response "Endpoint and its secret"
+// @Failure      400 {object} Response "Bad request"
+// @Failure      403 {object} Response "Not an editor of the organization"
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /webhooks [post]
+func (w *WebhookController) CreateEndpoint(c *gin.Context) {
+	var body models.WebhookEndpointRequest
+
+	if err := c.ShouldBindJSON(&body); err != nil {
+		handleError(c, http.StatusBadRequest, "Failed to parse request body", err)
+		return
+	}
+
+	if err := validation.ValidateWebhookURL(body.URL, w.Config.WebhookAllowPrivate); err != nil {
+		handleError(c, http.StatusBadRequest, "Invalid url: "+err.Error(), err)
+		return
+	}
+
+	types := make([]string, 0, len(body.EventTypes))
+	for _, t := range body.EventTypes {
+		if !knownEventType(t) {
+			handleError(c, http.StatusBadRequest, "Unknown event type: "+t, nil)
+			return
+		}
+		types = append(types, t)
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	if body.OrganizationID != nil {
+		allowed, err := w.Access.EditsOrganization(userID, *body.OrganizationID, c.GetString(constants.CtxRole))
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if !allowed {
+			handleError(c, http.StatusForbidden, "You are not an editor of this organization", nil)
+			return
+		}
+	}
+
+	secret, err := utils.RandomHex(32)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate secret", err)
+		return
+	}
+
+	endpoint := models.WebhookEndpoint{
+		UserID:         userID,
+		OrganizationID: body.OrganizationID,
+		URL:            body.URL,
+		Secret:         secret,
+		EventTypes:     strings.Join(types, ","),
+	}
+
+	if err := w.Storage.Create(&endpoint).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to create webhook endpoint", err)
+		return
+	}
+	endpoint.EventTypeList = types
+
+	HandleResponse(c, http.StatusCreated, gin.H{
+		"endpoint": endpoint,
+		"secret":   secret,
+	})
+}
+
+// ListEndpoints godoc
+// @Summary      List my webhook endpoints
+// @Description  Returns the caller's endpoints and those of organizations they edit.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {array} models.WebhookEndpoint
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /webhooks [get]
+func (w *WebhookController) ListEndpoints(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	var endpoints []models.WebhookEndpoint
+	if err := w.visibleEndpoints(userID).Order("id DESC").Find(&endpoints).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch webhook endpoints", err)
+		return
+	}
+	for i := range endpoints {
+		endpoints[i].EventTypeList = endpoints[i].SplitEventTypes()
+	}
+
+	HandleResponse(c, http.StatusOK, endpoints)
+}
+
+// DeleteEndpoint godoc
+// @Summary      Delete a webhook endpoint
+// @Description  Stops deliveries to the endpoint. Deliveries still pending are dropped.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Endpoint ID"
+// @Success      200 {string} string "Webhook endpoint deleted successfully"
+// @Failure      404 {object} Response "Endpoint not found"
+// @Router       /webhooks/{id} [delete]
+func (w *WebhookController) DeleteEndpoint(c *gin.Context) {
+	endpoint, ok := w.endpoint(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	if err := w.Storage.Model(&endpoint).Update("deleted_at", time.Now()).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to delete webhook endpoint", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Webhook endpoint deleted successfully")
+}
+
+// PingEndpoint godoc
+// @Summary      Send a test event
+// @Description  Immediately sends a signed "ping" event to the endpoint and reports the response status.
+// @Description  Useful to check signature verification against a local receiver.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Endpoint ID"
+// @Success      200 {object} Response "Response status"
+// @Failure      404 {object} Response "Endpoint not found"
+// @Failure      502 {object} Response "Endpoint did not accept the request"
+// @Router       /webhooks/{id}/ping [post]
+func (w *WebhookController) PingEndpoint(c *gin.Context) {
+	endpoint, ok := w.endpoint(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	key, err := utils.RandomHex(16)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to generate delivery key", err)
+		return
+	}
+
+	payload, _ := json.Marshal(gin.H{
+		"id":          "ping:" + key,
+		"type":        "ping",
+		"occurred_at": time.Now(),
+	})
+
+	status, err := w.Sender.Send(c, endpoint, "ping:"+key, "ping", payload)
+	if err != nil {
+		handleError(c, http.StatusBadGateway, "Webhook endpoint did not accept the ping: "+err.Error(), err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{"response_status": status})
+}
+
+// ListDeliveries godoc
+// @Summary      List deliveries of an endpoint
+// @Description  Deliveries to one endpoint, newest first, optionally filtered by status.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id        path   string  true   "Endpoint ID"
+// @Param        status    query  string  false  "pending, sent or dead"
+// @Param        page      query  int     false  "Page number"
+// @Param        pageSize  query  int     false  "Page size"
+// @Success      200 {object} Response
+// @Failure      404 {object} Response "Endpoint not found"
+// @Router       /webhooks/{id}/deliveries [get]
+func (w *WebhookController) ListDeliveries(c *gin.Context) {
+	endpoint, ok := w.endpoint(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	query := w.Storage.Model(&models.WebhookDelivery{}).Where("endpoint_id = ?", endpoint.ID)
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	w.respondDeliveries(c, query)
+}
+
+// ListDeadLetters godoc
+// @Summary      Dead-letter list
+// @Description  Deliveries to any of the caller's endpoints that failed every retry.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page      query  int  false  "Page number"
+// @Param        pageSize  query  int  false  "Page size"
+// @Success      200 {object} Response
+// @Failure      500 {object} Response "Internal server error"
+// @Router       /webhooks/dead-letters [get]
+func (w *WebhookController) ListDeadLetters(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		handleError(c, http.StatusUnauthorized, "User id missing from token", nil)
+		return
+	}
+
+	endpoints := w.visibleEndpoints(userID).Select("id")
+	query := w.Storage.Model(&models.WebhookDelivery{}).
+		Where("status = ? AND endpoint_id IN (?)", models.WebhookDeliveryDead, endpoints)
+
+	w.respondDeliveries(c, query)
+}
+
+// Redeliver godoc
+// @Summary      Redeliver a webhook
+// @Description  Queues a delivery again, typically one from the dead-letter list, with a fresh retry budget.
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path string true "Delivery ID"
+// @Success      200 {string} string "Delivery queued"
+// @Failure      400 {object} Response "Delivery already sent or endpoint deleted"
+// @Failure      404 {object} Response "Delivery not found"
+// @Router       /webhooks/deliveries/{id}/redeliver [post]
+func (w *WebhookController) Redeliver(c *gin.Context) {
+	var delivery models.WebhookDelivery
+	if err := w.Storage.First(&delivery, c.Param("id")).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Delivery not found", err)
+		return
+	}
+
+	endpoint, ok := w.endpoint(c, delivery.EndpointID)
+	if !ok {
+		return
+	}
+	if endpoint.DeletedAt != nil {
+		handleError(c, http.StatusBadRequest, "Webhook endpoint was deleted", nil)
+		return
+	}
+
+	err := w.Storage.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&delivery).Updates(map[string]interface{}{
+			"status":     models.WebhookDeliveryPending,
+			"last_error": "",
+		}).Error; err != nil {
+			return err
+		}
+		return webhooks.Enqueue(tx, delivery.ID)
+	})
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to queue delivery", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, "Delivery queued")
+}
+
+// visibleEndpoints returns the live endpoints the user owns, plus those of
+// organizations where the user is an owner or editor.
+func (w *WebhookController) visibleEndpoints(userID uint) *gorm.DB {
+	editable := w.Storage.Model(&models.OrganizationMember{}).
+		Select("organization_id").
+		Where("user_id = ? AND role IN ?", userID, []string{models.OrgRoleOwner, models.OrgRoleEditor})
+
+	return w.Storage.Model(&models.WebhookEndpoint{}).
+		Where("deleted_at IS NULL").
+		Where("(organization_id IS NULL AND user_id = ?) OR organization_id IN (?)", userID, editable)
+}
+
+// endpoint loads an endpoint the caller may manage. Deleted endpoints are
+// returned too, so that their deliveries stay visible.
+func (w *WebhookController) endpoint(c *gin.Context, id interface{}) (models.WebhookEndpoint, bool) {
+	var endpoint models.WebhookEndpoint
+	if err := w.Storage.First(&endpoint, id).Error; err != nil {
+		handleError(c, http.StatusNotFound, "Webhook endpoint not found", err)
+		return endpoint, false
+	}
+
+	userID, _ := getUserID(c)
+	allowed := endpoint.OrganizationID == nil && endpoint.UserID == userID
+	if endpoint.OrganizationID != nil {
+		var err error
+		allowed, err = w.Access.EditsOrganization(userID, *endpoint.OrganizationID, c.GetString(constants.CtxRole))
+		if err != nil {
+			respondError(c, err)
+			return endpoint, false
+		}
+	}
+	if !allowed {
+		handleError(c, http.StatusNotFound, "Webhook endpoint not found", nil)
+		return endpoint, false
+	}
+
+	endpoint.EventTypeList = endpoint.SplitEventTypes()
+	return endpoint, true
+}
+
+func (w *WebhookController) respondDeliveries(c *gin.Context, query *gorm.DB) {
+	page, pageSize := getPaginationParams(c)
+	offset := (page - 1) * pageSize
+
+	var totalRecords int64
+	if err := query.Count(&totalRecords).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to count deliveries", err)
+		return
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Order("id DESC").Limit(pageSize).Offset(offset).Find(&deliveries).Error; err != nil {
+		handleError(c, http.StatusInternalServerError, "Failed to fetch deliveries", err)
+		return
+	}
+
+	HandleResponse(c, http.StatusOK, gin.H{
+		"totalRecords": totalRecords,
+		"currentPage":  page,
+		"pageSize":     pageSize,
+		"deliveries":   deliveries,
+	})
+}
+
+func knownEventType(t string) bool {
+	for _, known := range events.Types {
+		if string(known) == t {
+			return true
+		}
+	}
+	return false
+}
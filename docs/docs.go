@@ -19,62 +19,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/forgot-password": {
-            "post": {
-                "description": "Initiates password reset process by sending a verification code to the user's email.",
-                "consumes": [
-                    "application/json"
+        "/admin/2fa-policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the 2FA policy of every role that has one.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Forgot Password",
-                "parameters": [
-                    {
-                        "description": "User phone number for password reset",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ForgotPassword"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "List two-factor policies",
                 "responses": {
                     "200": {
-                        "description": "Verification code sent successfully",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Failed to parse request or Invalid phone number format",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.Response"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TwoFactorPolicy"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Failed to send email or Database error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     }
                 }
-            }
-        },
-        "/auth/login": {
-            "post": {
-                "description": "Allows a user to log in using email and password. If valid, returns a JWT token.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes 2FA mandatory (or optional again) for every user with the given role.",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,41 +66,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Login a user",
+                "summary": "Set two-factor policy for a role",
                 "parameters": [
                     {
-                        "description": "Login Credentials",
-                        "name": "login",
+                        "description": "Policy",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.TwoFactorPolicyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully restored the category",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/controllers.Response"
+                            "$ref": "#/definitions/models.TwoFactorPolicy"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized: Invalid credentials",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -130,51 +102,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/new-password": {
+        "/admin/categories/import": {
             "post": {
-                "description": "Allows user to reset their password after successful OTP verification.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or renames categories from a CPV-style code list. Upload a .csv file with a\n\"code,name[,parent_code]\" header or a .json array of {code, name, parent_code}.\nWithout parent_code the parent is derived from the CPV code, e.g. 45210000 under 45200000.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "categories"
                 ],
-                "summary": "Set New Password",
+                "summary": "Import categories",
                 "parameters": [
                     {
-                        "description": "New password to set",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.NewPassword"
-                        }
+                        "type": "file",
+                        "description": "Code list (.csv or .json)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Forgotten password updated successfully",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "New password validation failed",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Number of imported categories",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
-                    "500": {
-                        "description": "Failed to reset password user",
+                    "400": {
+                        "description": "Invalid file",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -182,45 +145,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/admin/exchange-rates/import": {
             "post": {
-                "description": "Registers a new user by providing phone number, email, and password.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or replaces exchange rates from a Central Bank file. Upload a .json array as published by the\nCentral Bank of Uzbekistan, [{\"Ccy\": \"USD\", \"Nominal\": \"1\", \"Rate\": \"12739.22\", \"Date\": \"17.10.2025\"}],\nor a .csv file with a \"currency,rate,date[,nominal]\" header. Rates quoted per nominal are stored per\nunit. Dates are YYYY-MM-DD or DD.MM.YYYY.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "exchange-rates"
                 ],
-                "summary": "Create a new user",
+                "summary": "Import exchange rates",
                 "parameters": [
                     {
-                        "description": "User Registration Data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserRegister"
-                        }
+                        "type": "file",
+                        "description": "Rate file (.csv or .json)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created the user",
+                    "200": {
+                        "description": "Number of imported rates",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                        "description": "Invalid file",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -228,51 +188,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/reset-password": {
-            "post": {
-                "description": "This endpoint allows the user to reset their password.",
-                "consumes": [
-                    "application/json"
+        "/admin/outbox": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Events, emails and webhooks waiting for or already delivered by the outbox relay, newest first. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "outbox"
                 ],
-                "summary": "Reset user password",
+                "summary": "List outbox messages",
                 "parameters": [
                     {
-                        "description": "User Reset Password Request",
-                        "name": "requestBody",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ResetPassword"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, sent or failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "event, email, webhook or notif.delivery",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idempotency key",
+                        "name": "key",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password reset successfully",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Failed to parse request",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid password",
-                        "schema": {
-                            "$ref": "#/definitions/controllers.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -286,93 +251,110 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify": {
-            "post": {
-                "description": "Verifies the user's phone number using the code sent earlier. If valid, activates the user.",
-                "consumes": [
-                    "application/json"
+        "/admin/outbox/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns one outbox message with its payload, attempts and last error. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "outbox"
                 ],
-                "summary": "Verify phone number with the code",
+                "summary": "Get an outbox message",
                 "parameters": [
                     {
-                        "description": "Phone number and code verification data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.VerifyRequest"
-                        }
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User verified and activated successfully",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid or expired code",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.OutboxMessage"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Message not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     }
                 }
             }
         },
-        "/auth/verify-forgot-password": {
+        "/admin/outbox/{id}/retry": {
             "post": {
-                "description": "Verifies the OTP code sent to the user for password reset.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Puts a message that ran out of attempts back in the queue with a fresh attempt budget. Admins only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "outbox"
                 ],
-                "summary": "Verify Forgot Password",
+                "summary": "Retry a failed outbox message",
                 "parameters": [
                     {
-                        "description": "Verification code to verify user",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.VerifyRequest"
-                        }
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User verified successfully",
+                        "description": "Message queued for retry",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Verification code not found or expired or Wrong OTP code",
+                    "404": {
+                        "description": "Failed message not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rebuilds the analytics snapshot now instead of waiting for the scheduled refresh. Admins only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Refresh the report snapshot",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Redis server error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -380,9 +362,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifs": {
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the authenticated user's API keys, including revoked and expired ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.APIKey"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Yangi xabar yaratish (Client yoki Contractor uchun)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an API key for the authenticated user, limited to the given \"METHOD /path\" scopes.\nEvery scope must be allowed for the user's role. The key is only returned once. API keys cannot call\n/api-keys or /auth/2fa/, so requests to these must use a token, and scopes on them are rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -390,35 +409,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "api-keys"
                 ],
-                "summary": "Create Notification",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "Notification Body",
+                        "description": "API key request",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.NotifRequest"
+                            "$ref": "#/definitions/models.APIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created key",
                         "schema": {
-                            "$ref": "#/definitions/models.Notif"
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Scope not allowed for role",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -426,52 +451,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifs/{user_id}/{relation_id}": {
-            "get": {
+        "/api-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "User uchun o‘ziga tegishli xabarni olish",
+                "description": "Revokes one of the authenticated user's API keys. Revoked keys are rejected immediately.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "api-keys"
                 ],
-                "summary": "Get User Notification",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Relation ID",
-                        "name": "relation_id",
+                        "description": "API key ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "API key revoked successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Notif"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "API key not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
-                    "404": {
-                        "description": "Notif not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -479,33 +497,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/offers": {
+        "/attachments": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a paginated list of all offers.",
+                "description": "Lists the attachments of a tender or an offer. Offer attachments are sealed: the tender's client\nonly sees them after the tender deadline.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "offers"
+                    "attachments"
                 ],
-                "summary": "Get all offers",
+                "summary": "List attachments",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "tender or offer",
+                        "name": "owner_type",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "Tender or offer ID",
+                        "name": "owner_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -514,12 +534,18 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Offers"
+                                "$ref": "#/definitions/models.Attachment"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Failed to fetch offers",
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -532,43 +558,79 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint creates a new offer with the provided details.",
+                "description": "Uploads a file to a tender or an offer the user manages, while the tender is open and before its\ndeadline. Size and content type are limited, and a SHA-256 checksum is recorded.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "offers"
+                    "attachments"
                 ],
-                "summary": "Create a new offer",
+                "summary": "Upload an attachment",
                 "parameters": [
                     {
-                        "description": "Offer Request Body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.OffersRequest"
-                        }
+                        "type": "string",
+                        "description": "tender or offer",
+                        "name": "owner_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tender or offer ID",
+                        "name": "owner_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Offers"
+                            "$ref": "#/definitions/models.Attachment"
                         }
                     },
                     "400": {
-                        "description": "Failed to parse request body",
+                        "description": "Bad request, or the tender is closed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported file type",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create offer",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -576,33 +638,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/offers/filter": {
-            "get": {
+        "/attachments/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves offers with minimum and maximum prices and delivery times, along with their counts.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Removes an attachment from a tender or offer the user manages, while the tender is open and before\nits deadline.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "offers"
+                    "attachments"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get min, max prices and delivery times with filtered count",
                 "responses": {
                     "200": {
-                        "description": "Details of min/max offers and counts",
+                        "description": "Attachment deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Tender is closed",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
-                    "500": {
-                        "description": "Error message",
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -610,22 +690,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/offers/restore/{id}": {
-            "patch": {
+        "/attachments/{id}/download": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Restore an offer that was previously soft deleted.",
+                "description": "Streams an attachment after checking the same visibility rules as the listing.",
+                "produces": [
+                    "application/octet-stream"
+                ],
                 "tags": [
-                    "offers"
+                    "attachments"
                 ],
-                "summary": "Restore a soft-deleted offer",
+                "summary": "Download an attachment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Offer ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -633,13 +716,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Offer restored successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "404": {
-                        "description": "Offer not found",
+                        "description": "Attachment not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -647,14 +736,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/offers/sorted": {
-            "get": {
+        "/auth/2fa/confirm": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint retrieves a list of offers with pagination, sorted by price and delivery time.",
+                "description": "Verifies a TOTP code against the pending secret, enables 2FA and returns one-time recovery codes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -662,26 +751,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "offers"
+                    "auth"
                 ],
-                "summary": "Get filtered and sorted offers with pagination",
+                "summary": "Confirm two-factor enrolment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of offers per page",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "TOTP code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response with offers and total count",
+                        "description": "Recovery codes",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code or enrolment not started",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -695,39 +793,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/offers/{contractor_id}": {
-            "get": {
+        "/auth/2fa/disable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve an offer by its ID.",
+                "description": "Turns off 2FA after checking the password and a TOTP or recovery code.\nNot allowed when the user's role requires 2FA.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "offers"
+                    "auth"
                 ],
-                "summary": "Get a specific offer",
+                "summary": "Disable two-factor authentication",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Contractor ID",
-                        "name": "contractor_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Password and code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorDisableRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Two-factor authentication disabled",
                         "schema": {
-                            "$ref": "#/definitions/models.Offers"
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
-                    "404": {
-                        "description": "Offer not found",
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid password or code",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Two-factor authentication is mandatory for this role",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -735,92 +856,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/offers/{id}": {
-            "put": {
+        "/auth/2fa/enroll": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the details of an offer by its ID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Generates a new TOTP secret for the authenticated user and returns it with an otpauth URI.\n2FA is not active until the secret is confirmed with /auth/2fa/confirm.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "offers"
-                ],
-                "summary": "Update an existing offer",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Offer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated offer details",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.OffersRequest"
-                        }
-                    }
+                    "auth"
                 ],
+                "summary": "Start two-factor enrolment",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Secret and otpauth URI",
                         "schema": {
-                            "$ref": "#/definitions/models.OffersRequest"
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "400": {
-                        "description": "Failed to parse request body",
+                        "description": "Two-factor authentication already enabled",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
-                    "404": {
-                        "description": "Offer not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Mark an offer as deleted by setting the DeletedAt field.",
-                "tags": [
-                    "offers"
-                ],
-                "summary": "Soft delete an offer",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Offer ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Offer deleted successfully",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
-                    "404": {
-                        "description": "Offer not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -828,60 +899,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders": {
-            "get": {
+        "/auth/2fa/recovery-codes": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all tenders with pagination support",
+                "description": "Invalidates all existing recovery codes and issues a new set. Requires a valid TOTP code.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tender"
+                    "auth"
                 ],
-                "summary": "Get all tenders with pagination",
+                "summary": "Regenerate recovery codes",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
+                        "description": "TOTP code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Recovery codes",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Tenders"
-                            }
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code or 2FA not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/forgot-password": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates a new tender with the provided details",
+                "description": "Initiates password reset process by sending a verification code to the user's email.",
                 "consumes": [
                     "application/json"
                 ],
@@ -889,35 +966,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tender"
+                    "auth"
                 ],
-                "summary": "Create a new tender",
+                "summary": "Forgot Password",
                 "parameters": [
                     {
-                        "description": "Tender Request Body",
+                        "description": "User phone number for password reset",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TenderRequest"
+                            "$ref": "#/definitions/models.ForgotPassword"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "Verification code sent successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.Tenders"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Failed to parse request or Invalid phone number format",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to send email or Database error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -925,14 +1009,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/restore/{id}": {
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Restores a tender by removing the ` + "`" + `DeletedAt` + "`" + ` timestamp, making it active again.",
+        "/auth/login": {
+            "post": {
+                "description": "Allows a user to log in using email and password. If valid, returns a JWT token.\nUsers with 2FA enabled get an mfa_token instead, to be exchanged at /auth/login/2fa.\nUsers whose role requires 2FA but who have not enrolled get a token limited to /auth/2fa.",
                 "consumes": [
                     "application/json"
                 ],
@@ -940,27 +1019,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tender"
+                    "auth"
                 ],
-                "summary": "Restore a soft deleted tender by ID",
+                "summary": "Login a user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tender ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Login Credentials",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tender restored successfully",
+                        "description": "Successfully restored the category",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized: Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "404": {
-                        "description": "Tender not found or already active",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -974,39 +1067,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/{client_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Exchanges the mfa_token returned by /auth/login and a TOTP or recovery code for a JWT token.\nAfter 5 wrong codes the mfa_token is invalidated and the user has to log in again.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieve all tenders for a specific client",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tender"
+                    "auth"
                 ],
-                "summary": "Get tenders by client ID",
+                "summary": "Complete login with a second factor",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Client ID",
-                        "name": "client_id",
-                        "in": "path",
-                        "required": true
+                        "description": "MFA token and code",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TwoFactorLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "JWT token",
                         "schema": {
-                            "$ref": "#/definitions/models.Tenders"
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired mfa token or code",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -1014,14 +1119,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/{id}": {
-            "put": {
-                "security": [
+        "/auth/new-password": {
+            "post": {
+                "description": "Allows user to reset their password after successful OTP verification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Set New Password",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "New password to set",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewPassword"
+                        }
                     }
                 ],
-                "description": "Updates the details of an existing tender",
+                "responses": {
+                    "200": {
+                        "description": "Forgotten password updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "New password validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reset password user",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Registers a new user by providing phone number, email, and password.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1029,61 +1181,151 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tender"
+                    "auth"
                 ],
-                "summary": "Update an existing tender",
+                "summary": "Create a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tender ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User Registration Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRegister"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created the user",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "This endpoint allows the user to reset their password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Reset user password",
+                "parameters": [
                     {
-                        "description": "Updated Tender Body",
-                        "name": "body",
+                        "description": "User Reset Password Request",
+                        "name": "requestBody",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TenderRequest"
+                            "$ref": "#/definitions/models.ResetPassword"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Password reset successfully",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Failed to parse request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid password",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "404": {
-                        "description": "Tender not found",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
+            }
+        },
+        "/auth/verify": {
+            "post": {
+                "description": "Verifies the user's phone number using the code sent earlier. If valid, activates the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify phone number with the code",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "Phone number and code verification data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VerifyRequest"
+                        }
                     }
                 ],
-                "description": "Marks a tender as deleted by setting the ` + "`" + `DeletedAt` + "`" + ` timestamp.",
+                "responses": {
+                    "200": {
+                        "description": "User verified and activated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-forgot-password": {
+            "post": {
+                "description": "Verifies the OTP code sent to the user for password reset.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1091,33 +1333,83 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tender"
+                    "auth"
                 ],
-                "summary": "Soft delete a tender by ID",
+                "summary": "Verify Forgot Password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tender ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Verification code to verify user",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VerifyRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tender deleted successfully",
+                        "description": "User verified successfully",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Verification code not found or expired or Wrong OTP code",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Redis server error",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the children of a category (or the top level when parent is omitted), or searches codes and names with q.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "List categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Parent category code",
+                        "name": "parent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search code or name",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Category"
+                            }
+                        }
                     },
                     "404": {
-                        "description": "Tender not found or already deleted",
+                        "description": "Parent category not found",
                         "schema": {
                             "$ref": "#/definitions/controllers.Response"
                         }
@@ -1130,290 +1422,5018 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.Response": {
-            "type": "object",
-            "properties": {
-                "message": {}
-            }
         },
-        "models.ForgotPassword": {
-            "type": "object",
-            "properties": {
-                "phone_number": {
+        "/contractors/documents": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records a licence or certificate held by the authenticated contractor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contractors"
+                ],
+                "summary": "Add a qualification document",
+                "parameters": [
+                    {
+                        "description": "Document",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.QualificationDocumentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.QualificationDocument"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/contractors/documents/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes one of the authenticated contractor's documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contractors"
+                ],
+                "summary": "Delete a qualification document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Document ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Document deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Document not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/contractors/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated contractor's profile and qualification documents.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contractors"
+                ],
+                "summary": "Get my contractor profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile and documents",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saves the authenticated contractor's company profile. The tax ID must be a 9 digit Uzbek INN.\ncategories lists the category codes the contractor serves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contractors"
+                ],
+                "summary": "Create or update my contractor profile",
+                "parameters": [
+                    {
+                        "description": "Profile",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ContractorProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContractorProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/contractors/{id}/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a contractor's company profile and qualification documents, so clients can assess bidders.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contractors"
+                ],
+                "summary": "Get a contractor's profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contractor user ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile and documents",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Profile not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the latest rate of each currency published on or before date, today when omitted. A rate is\nthe value of one unit of the currency in the base currency, the configured default (UZS).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exchange-rates"
+                ],
+                "summary": "List exchange rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date, YYYY-MM-DD",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Base currency, date and rates",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Token egasining xabarlari, sahifalab. type va unread bo‘yicha filtrlash mumkin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Notification inbox",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Show archived notifications instead of the inbox",
+                        "name": "archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Yangi xabar yaratish (Client yoki Contractor uchun). Faqat admin uchun:\nodatiy xabarlar tender va taklif hodisalaridan avtomatik yaratiladi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Create Notification",
+                "parameters": [
+                    {
+                        "description": "Notification Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NotifRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Notif"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Har bir xabar turi uchun yetkazish kanallari (in-app, email, SMS, webhook) va rejimi.\nSozlanmagan turlar uchun standart qiymat: faqat in-app, darhol.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NotifPreference"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bitta xabar turi uchun kanallar va rejimni saqlash. digest rejimi faqat emailga tegishli:\nxabarlar kuniga bir marta bitta emailga yig‘iladi.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Set notification preference",
+                "parameters": [
+                    {
+                        "description": "Preference",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NotifPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotifPreference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/read-all": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "O‘qilmagan xabarlar soni (badge uchun).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Unread notification count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Delete notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification deleted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Notif not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/{id}/archive": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Xabarni arxivlash: u inboxdan yo‘qoladi, lekin archived=true bilan ko‘rinadi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Archive notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification archived",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Notif not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/{id}/read": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Notif not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifs/{user_id}/{relation_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "User uchun o‘ziga tegishli xabarlarni olish. Faqat token egasining xabarlari qaytariladi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get User Notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Relation ID",
+                        "name": "relation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Notif"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Notif not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ocds/records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams an OCDS 1.1 record package for the same tenders as the release package, one record per\ntender with its release embedded and compiled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ocds"
+                ],
+                "summary": "OCDS record package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Releases dated on or after this date, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Releases dated before this date, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ocds.RecordPackage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ocds/releases": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams an Open Contracting Data Standard 1.1 release package with one release per tender whose\nrelease date falls in the range: tenders updated in it and tenders whose bids were unsealed in it.\nBids, tenderers and awards only appear after the tender deadline.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ocds"
+                ],
+                "summary": "OCDS release package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Releases dated on or after this date, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Releases dated before this date, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ocds.ReleasePackage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve offers with filtering, sorting, field selection and pagination, e.g.\n?filter[tender_id]=5\u0026filter[price][lte]=1000\u0026sort=price,-delivery_time\u0026fields=id,price.\nFilterable: id, tender_id, contractor_id, price, delivery_time, comments, status, organization_id,\ncreated_at, updated_at. Sortable: id, tender_id, contractor_id, price, delivery_time, created_at, updated_at.\nOnly the caller's offers, their own or their organizations', are listed, and the offers on tenders the\ncaller manages once their deadline has passed; offers stay sealed until then.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Get all offers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[price][lte]=1000; op is eq (default), ne, gt, gte, lt, lte, in or contains",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, - for descending, e.g. price,-delivery_time",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,price",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With cursor, also count totalRecords",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Offers"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or fields",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to fetch offers",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint creates a new offer with the provided details. contractor_id must be the caller, or a\nmember of the organization_id the caller edits.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Create a new offer",
+                "parameters": [
+                    {
+                        "description": "Offer Request Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OffersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Offers"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to parse request body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create offer",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/filter": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns, for every tender with matching offers, the offer count, min/max/mean/median price, the spread\nagainst the budget, the delivery distribution and the offers at each extreme. Only tenders the caller\nmanages, as client or organization editor, whose deadline has passed are included, as offers stay\nsealed until then. Prices are normalized to the tender currency at the rates of its deadline.\nStatistics never mix tenders; tenders are not divided into lots, so there is no per-lot breakdown.\nThe filter parameters of GET /offers narrow the offers, e.g. filter[tender_id][in]=4,5 or\nfilter[created_at][gte]=2025-01-01.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Get offer statistics per tender",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[tender_id]=5",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderStats"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Error message",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/restore/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore an offer that was previously soft deleted.",
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Restore a soft-deleted offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Offer restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/sorted": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint retrieves a list of offers with pagination, sorted by normalized price and delivery time\nunless sort is given. The normalized price is the offer price in its tender's currency, at the exchange\nrate stored with the offer: the latest rate when it was submitted, revalued at the deadline rate shortly\nafter the tender deadline passes. It takes the same filter, sort and fields parameters as GET /offers and\nalso provides the total number of offers matching the filters (excluding deleted offers). Like GET /offers\nit only lists the caller's offers and the unsealed offers on tenders the caller manages.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Get filtered and sorted offers with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[tender_id]=5",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, - for descending; normalized_price,delivery_time when omitted",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of offers per page (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With cursor, also count totalRecords",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response with offers and total count",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or fields",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{contractor_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve an offer of a contractor, who must be the caller.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Get a specific offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Contractor ID",
+                        "name": "contractor_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Offers"
+                        }
+                    },
+                    "403": {
+                        "description": "You can only fetch your own offers",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the details of an offer by its ID. Its tender_id and contractor_id cannot change.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Update an existing offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated offer details",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OffersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OffersRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Failed to parse request body",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark an offer as deleted by setting the DeletedAt field.",
+                "tags": [
+                    "offers"
+                ],
+                "summary": "Soft delete an offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Offer deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the organizations the authenticated user is a member of.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "List my organizations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Organization"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a company the authenticated user acts on behalf of. The creator becomes its owner.\nThe organization kind follows the creator's role (client or contractor).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Create an organization",
+                "parameters": [
+                    {
+                        "description": "Organization",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/invitations/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Joins the organization using the emailed token. The token must belong to the authenticated user's email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired invitation",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Invitation addressed to another user",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns an organization with its members. Only visible to members.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Get an organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a member",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Organization not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/invitations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Emails an invitation token to join the organization with the given role. Owners only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Invite a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.InvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Owners only",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the in-organization role of a member. Owners only. The last owner cannot be demoted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Change a member's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MemberRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Owners only",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a member from the organization. Owners can remove anyone; members can remove themselves.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Remove a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Member removed successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Last owner cannot leave",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Member not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/feed.atom": {
+            "get": {
+                "description": "The newest open tenders as an Atom 1.0 feed. No authentication.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Atom feed of new tenders",
+                "responses": {
+                    "200": {
+                        "description": "Atom document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/feed.rss": {
+            "get": {
+                "description": "The newest open tenders as an RSS 2.0 feed. No authentication.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "RSS feed of new tenders",
+                "responses": {
+                    "200": {
+                        "description": "RSS document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/tenders": {
+            "get": {
+                "description": "Published tenders still accepting offers, newest first. No authentication; responses may be cached\nfor a minute and requests are rate limited per IP.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "List open tenders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated category codes; tenders in any of them or their subcategories",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PublicTender"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/tenders/{id}": {
+            "get": {
+                "description": "One published tender still accepting offers. No authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Get an open tender",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PublicTender"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/tenders/{id}/attachments/{attachment_id}": {
+            "get": {
+                "description": "Streams an attachment of an open tender. No authentication.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "public"
+                ],
+                "summary": "Download a tender attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "attachment_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tenders, awards, savings against budget, average bids and average days to award, grouped by month,\ncategory, client or organization. Clients see their own and their organizations' tenders, admins\neverything. Figures come from a snapshot refreshed every few minutes; see refreshed_at. Amounts are\nin the tender currency and never summed across currencies: each group has a row per currency.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Procurement summary report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "month (default), category, client or organization",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenders published on or after this date, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenders published before this date, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/top-contractors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Contractors ranked by tenders won, with awarded value and savings against budget. Scoped and\nrefreshed like the summary report. Contractors have a row per tender currency they won in.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "report"
+                ],
+                "summary": "Top contractors report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of contractors, default 10, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenders published on or after this date, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenders published before this date, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/link": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Shows whether the authenticated user has linked a Telegram chat.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Get my Telegram link",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TelegramLink"
+                        }
+                    },
+                    "404": {
+                        "description": "Not linked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops all bot messages to the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Unlink Telegram",
+                "responses": {
+                    "200": {
+                        "description": "Telegram unlinked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not linked",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/link-code": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a one-time code, valid for 10 minutes, to send to the bot as \"/link \u003ccode\u003e\".\nWhen the bot name is configured a t.me deep link that sends it automatically is included.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Get a Telegram link code",
+                "responses": {
+                    "200": {
+                        "description": "Code, deep link and expiry",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Telegram bot is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve tenders with filtering, sorting, field selection and pagination, e.g.\n?filter[budget][gte]=1000\u0026filter[state]=open\u0026sort=-deadline,budget\u0026fields=id,title.\nFilterable: id, title, description, deadline, budget, status, state, client_id, organization_id,\nawarded_offer_id, created_at, updated_at. Sortable: id, title, deadline, budget, state, client_id,\ncreated_at, updated_at.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Get all tenders with pagination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[budget][gte]=1000; op is eq (default), ne, gt, gte, lt, lte, in or contains",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, - for descending, e.g. -deadline,budget",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,title",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With cursor, also count totalRecords",
+                        "name": "total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated category codes; tenders in any of them or their subcategories",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tenders"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or fields, or unknown category",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new tender with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Create a new tender",
+                "parameters": [
+                    {
+                        "description": "Tender Request Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tenders"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/restore/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a tender by removing the ` + "`" + `DeletedAt` + "`" + ` timestamp, making it active again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Restore a soft deleted tender by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tender restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found or already active",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over tender titles, descriptions and plain text attachments, ranked by relevance.\nq accepts web search syntax: \"quoted phrases\", or, and -excluded words. The headline and snippet\nare HTML: the text is escaped and matches are wrapped in \u003cb\u003e. Filters compose with the text query and take the same\nfilter[field][op] parameters as GET /tenders; sort replaces the relevance order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Search tenders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Query language: uz, ru or en; all three when omitted",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[budget][gte]=1000 or filter[deadline][lte]=2025-01-01",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated category codes; tenders in any of them or their subcategories",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, - for descending; relevance when omitted",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,headline",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With cursor, also count totalRecords",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderSearchHit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query or filter",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{client_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all tenders for a specific client. Accepts the same filter, sort and fields parameters as GET /tenders.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Get tenders by client ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[budget][gte]=1000; op is eq (default), ne, gt, gte, lt, lte, in or contains",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, - for descending, e.g. -deadline,budget",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return, e.g. id,title",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With cursor, also count totalRecords",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tenders"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or fields",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the details of an existing tender while it is open and before its deadline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Update an existing tender",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Tender Body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request, or the tender is closed or past its deadline",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks a tender as deleted by setting the ` + "`" + `DeletedAt` + "`" + ` timestamp.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Soft delete a tender by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tender deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found or already deleted",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}/award": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Closes an open tender after its deadline and awards it to one of its offers.\nThe winning bidder and the other bidders are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Award a tender to an offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Winning offer",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AwardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tenders"
+                        }
+                    },
+                    "400": {
+                        "description": "Tender cannot be awarded",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender or offer not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels an open tender. Every bidder is notified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Cancel a tender",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tenders"
+                        }
+                    },
+                    "400": {
+                        "description": "Tender is not open",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}/comparison": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lays the bids on a tender side by side for the client managing it: price, deviation from the budget,\ndelivery, a 0-100 score per criterion, the weighted total and the rank. Bids in other accepted\ncurrencies are ranked on their price in the tender currency at the exchange rates of the deadline;\neach row shows the rate and rate date used, which the award fixes. Bids are sealed until the\ndeadline. format=csv or format=xlsx downloads the matrix.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Compare the offers on a tender",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), csv or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderComparison"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown format",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the tender's client, or bids still sealed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}/ocds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The current release of one tender, wrapped in a release package.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ocds"
+                ],
+                "summary": "OCDS release of a tender",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ocds.ReleasePackage"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}/offers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the bids on a tender for the client managing it. Bids are sealed until the deadline.\nTakes the filter, sort, fields and paging parameters of GET /offers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "List the offers on a tender",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter, e.g. filter[price][lte]=1000",
+                        "name": "filter[field][op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, - for descending; price,delivery_time when omitted",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields to return",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page by cursor instead of page: empty for the first page, then nextCursor or prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With cursor, also count totalRecords",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Offers"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, sort or fields",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the tender's client, or bids still sealed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Offer count, min/max/mean/median price, spread against the budget, delivery distribution and the\noffers at each extreme, for the client managing the tender. Prices are in the tender currency at the\nexchange rates of the deadline. Bids are sealed until the deadline.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tender"
+                ],
+                "summary": "Offer statistics of a tender",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderStats"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the tender's client, or bids still sealed",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Tender not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the caller's endpoints and those of organizations they edit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List my webhook endpoints",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookEndpoint"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes a URL to tender and offer events. Pass organization_id to register it for an organization you edit.\nThe signing secret is returned only once. Each request carries X-Tender-Timestamp and\nX-Tender-Signature: \"sha256=\" + hex HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" keyed with the secret.\nThe URL must be http(s) on a public host; loopback, private and link-local addresses are rejected.\ntender.published reaches every subscribed endpoint; other events only reach the tender's client and\nits bidders, and offer events only the offer's own bidder.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook endpoint",
+                "parameters": [
+                    {
+                        "description": "Endpoint",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Endpoint and its secret",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an editor of the organization",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deliveries to any of the caller's endpoints that failed every retry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Dead-letter list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a delivery again, typically one from the dead-letter list, with a fresh retry budget.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Redeliver a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery queued",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Delivery already sent or endpoint deleted",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops deliveries to the endpoint. Deliveries still pending are dropped.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook endpoint deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deliveries to one endpoint, newest first, optionally filtered by status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List deliveries of an endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, sent or dead",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/ping": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Immediately sends a signed \"ping\" event to the endpoint and reports the response status.\nUseful to check signature verification against a local receiver.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send a test event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Endpoint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Response status",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "Endpoint did not accept the request",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.Response": {
+            "type": "object",
+            "properties": {
+                "message": {}
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.APIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.AcceptInvitationRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "owner_type": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploaded_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AwardRequest": {
+            "type": "object",
+            "required": [
+                "offer_id"
+            ],
+            "properties": {
+                "offer_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContractorProfile": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "legal_name": {
+                    "type": "string"
+                },
+                "specialisations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tax_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "years_in_business": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ContractorProfileRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "legal_name",
+                "tax_id"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "legal_name": {
+                    "type": "string"
+                },
+                "specialisations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tax_id": {
+                    "type": "string"
+                },
+                "years_in_business": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.ForgotPassword": {
+            "type": "object",
+            "properties": {
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvitationRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MemberRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "editor",
+                        "viewer"
+                    ]
+                }
+            }
+        },
+        "models.NewPassword": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "user_id"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Notif": {
+            "type": "object",
+            "properties": {
+                "archived_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "relation_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.NotifType"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NotifPreference": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "boolean"
+                },
+                "in_app": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "sms": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.NotifType"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook": {
+                    "type": "boolean"
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NotifPreferenceRequest": {
+            "type": "object",
+            "required": [
+                "mode",
+                "type"
+            ],
+            "properties": {
+                "email": {
+                    "type": "boolean"
+                },
+                "in_app": {
+                    "type": "boolean"
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "immediate",
+                        "digest"
+                    ]
+                },
+                "sms": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "enum": [
+                        "offer.submitted",
+                        "offer.updated",
+                        "tender.amended",
+                        "tender.deadline_soon",
+                        "tender.awarded",
+                        "tender.cancelled",
+                        "announcement"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotifType"
+                        }
+                    ]
+                },
+                "webhook": {
+                    "type": "boolean"
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NotifRequest": {
+            "type": "object",
+            "required": [
+                "message",
+                "relation_id",
+                "type",
+                "user_id"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "relation_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "enum": [
+                        "offer.submitted",
+                        "offer.updated",
+                        "tender.amended",
+                        "tender.deadline_soon",
+                        "tender.awarded",
+                        "tender.cancelled",
+                        "announcement"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotifType"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.NotifType": {
+            "type": "string",
+            "enum": [
+                "offer.submitted",
+                "offer.updated",
+                "tender.amended",
+                "tender.deadline_soon",
+                "tender.awarded",
+                "tender.cancelled",
+                "announcement"
+            ],
+            "x-enum-varnames": [
+                "NotifOfferSubmitted",
+                "NotifOfferUpdated",
+                "NotifTenderAmended",
+                "NotifTenderDeadlineSoon",
+                "NotifTenderAwarded",
+                "NotifTenderCancelled",
+                "NotifAnnouncement"
+            ]
+        },
+        "models.OfferComparison": {
+            "type": "object",
+            "properties": {
+                "contractor_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "delivery_days": {
+                    "type": "integer"
+                },
+                "delivery_time": {
+                    "type": "string"
+                },
+                "deviation": {
+                    "type": "string"
+                },
+                "deviation_pct": {
+                    "type": "number"
+                },
+                "exchange_rate": {
+                    "type": "string"
+                },
+                "normalized_price": {
+                    "type": "string"
+                },
+                "offer_id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "rate_date": {
+                    "type": "string"
+                },
+                "scores": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.OfferExtremes": {
+            "type": "object",
+            "properties": {
+                "cheapest": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Offers"
+                    }
+                },
+                "dearest": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Offers"
+                    }
+                },
+                "fastest": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Offers"
+                    }
+                },
+                "slowest": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Offers"
+                    }
+                }
+            }
+        },
+        "models.Offers": {
+            "type": "object",
+            "required": [
+                "contractor_id",
+                "tender_id"
+            ],
+            "properties": {
+                "comments": {
+                    "type": "string"
+                },
+                "contractor_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "delivery_time": {
+                    "type": "string"
+                },
+                "exchange_rate": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "normalized_price": {
+                    "description": "NormalizedPrice is the price in the tender currency at ExchangeRate,\nderived from the rates published on RateDate. Offers in the tender\ncurrency have a rate of 1 and no rate date.",
+                    "type": "string",
+                    "example": "118500000.00"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "118500000.00"
+                },
+                "rate_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "tender_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OffersRequest": {
+            "type": "object",
+            "required": [
+                "comments",
+                "contractor_id",
+                "delivery_time",
+                "price",
+                "tender_id"
+            ],
+            "properties": {
+                "comments": {
+                    "type": "string"
+                },
+                "contractor_id": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "Currency must be one the tender accepts; it defaults to the\ntender currency when empty.",
+                    "type": "string"
+                },
+                "delivery_time": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "118500000.00"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "tender_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Organization": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrganizationMember"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationInvitation": {
+            "type": "object",
+            "properties": {
+                "accepted_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "invited_by": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OutboxMessage": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "idempotency_key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "processed_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PublicAttachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PublicCategory": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PublicTender": {
+            "type": "object",
+            "properties": {
+                "accepted_currencies": {
+                    "description": "AcceptedCurrencies are the currencies offers may be priced in.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PublicAttachment"
+                    }
+                },
+                "budget": {
+                    "type": "string"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PublicCategory"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QualificationDocument": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "qualification": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QualificationDocumentRequest": {
+            "type": "object",
+            "required": [
+                "expires_at",
+                "number",
+                "qualification",
+                "type"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "qualification": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "licence",
+                        "certificate"
+                    ]
+                }
+            }
+        },
+        "models.Report": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "refreshed_at": {
+                    "type": "string"
+                },
+                "rows": {},
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ResetPassword": {
+            "type": "object",
+            "properties": {
+                "confirm_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TelegramLink": {
+            "type": "object",
+            "properties": {
+                "linked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderComparison": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TenderCriterion"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OfferComparison"
+                    }
+                },
+                "rates_as_of": {
+                    "type": "string"
+                },
+                "tender_id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderCriterion": {
+            "type": "object",
+            "properties": {
+                "criterion": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.TenderQualification": {
+            "type": "object",
+            "properties": {
+                "qualification": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderRequest": {
+            "type": "object",
+            "required": [
+                "budget",
+                "deadline",
+                "description",
+                "title"
+            ],
+            "properties": {
+                "accepted_currencies": {
+                    "description": "AcceptedCurrencies are other currencies offers may be priced in,\ne.g. [\"USD\", \"EUR\"]. Offers are compared in Currency.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "budget": {
+                    "type": "string",
+                    "example": "125000000.00"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "criteria": {
+                    "description": "Criteria weighs price, delivery and experience when ranking\noffers, e.g. {\"price\": 70, \"delivery\": 30}.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "currency": {
+                    "description": "Currency is an ISO 4217 code, the configured default when empty.",
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "hide_budget": {
+                    "description": "HideBudget keeps the budget out of the public portal and exports.",
+                    "type": "boolean"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "qualifications": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderSearchHit": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "headline": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TenderStats": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "delivery_by_deadline": {
+                    "type": "integer"
+                },
+                "delivery_later": {
+                    "type": "integer"
+                },
+                "delivery_within_month": {
+                    "type": "integer"
+                },
+                "delivery_within_quarter": {
+                    "type": "integer"
+                },
+                "delivery_within_week": {
+                    "type": "integer"
+                },
+                "extremes": {
+                    "$ref": "#/definitions/models.OfferExtremes"
+                },
+                "max_delivery": {
+                    "type": "string"
+                },
+                "max_deviation": {
+                    "type": "string"
+                },
+                "max_price": {
+                    "type": "string"
+                },
+                "mean_deviation_pct": {
+                    "type": "number"
+                },
+                "mean_price": {
+                    "type": "string"
+                },
+                "median_delivery": {
+                    "type": "string"
+                },
+                "median_price": {
+                    "type": "string"
+                },
+                "min_delivery": {
+                    "type": "string"
+                },
+                "min_deviation": {
+                    "type": "string"
+                },
+                "min_price": {
+                    "type": "string"
+                },
+                "offer_count": {
+                    "type": "integer"
+                },
+                "over_budget": {
+                    "type": "integer"
+                },
+                "tender_id": {
+                    "type": "integer"
+                },
+                "under_budget": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Tenders": {
+            "type": "object",
+            "required": [
+                "client_id"
+            ],
+            "properties": {
+                "accepted_currencies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "awarded_at": {
+                    "type": "string"
+                },
+                "awarded_offer_id": {
+                    "type": "integer"
+                },
+                "budget": {
+                    "type": "string",
+                    "example": "125000000.00"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "client_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TenderCriterion"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "deadline": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "hide_budget": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "qualifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TenderQualification"
+                    }
+                },
+                "state": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorDisableRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorLoginRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "mfa_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorPolicy": {
+            "type": "object",
+            "properties": {
+                "required": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TwoFactorPolicyRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "required": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "client",
+                        "contractor",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "models.UserRegister": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "phone_number",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "client",
+                        "contractor"
+                    ]
+                }
+            }
+        },
+        "models.VerifyRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookEndpoint": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "integer"
+                },
+                "url": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.WebhookEndpointRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "event_types",
+                "url"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "password": {
+                "organization_id": {
+                    "type": "integer"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.NewPassword": {
+        "ocds.Award": {
             "type": "object",
-            "required": [
-                "new_password",
-                "user_id"
-            ],
             "properties": {
-                "new_password": {
+                "date": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
+                },
+                "relatedBids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.OrganizationRef"
+                    }
+                },
+                "value": {
+                    "$ref": "#/definitions/ocds.Value"
                 }
             }
         },
-        "models.Notif": {
+        "ocds.Bid": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "date": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "integer"
-                },
-                "message": {
                     "type": "string"
                 },
-                "relation_id": {
-                    "type": "integer"
-                },
-                "type": {
+                "status": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "tenderers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.OrganizationRef"
+                    }
+                },
+                "value": {
+                    "$ref": "#/definitions/ocds.Value"
                 }
             }
         },
-        "models.NotifRequest": {
+        "ocds.Bids": {
             "type": "object",
-            "required": [
-                "message",
-                "relation_id",
-                "type",
-                "user_id"
-            ],
             "properties": {
-                "message": {
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Bid"
+                    }
+                }
+            }
+        },
+        "ocds.Classification": {
+            "type": "object",
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "relation_id": {
-                    "type": "integer"
-                },
-                "type": {
+                "id": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "scheme": {
+                    "type": "string"
                 }
             }
         },
-        "models.Offers": {
+        "ocds.Document": {
             "type": "object",
-            "required": [
-                "contractor_id",
-                "tender_id"
-            ],
             "properties": {
-                "comments": {
+                "datePublished": {
                     "type": "string"
                 },
-                "contractor_id": {
-                    "type": "integer"
-                },
-                "created_at": {
+                "documentType": {
                     "type": "string"
                 },
-                "delivery_time": {
+                "format": {
                     "type": "string"
                 },
                 "id": {
-                    "type": "integer"
-                },
-                "price": {
-                    "type": "number"
+                    "type": "string"
                 },
-                "status": {
-                    "type": "boolean"
+                "title": {
+                    "type": "string"
                 },
-                "tender_id": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ocds.Identifier": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
                 },
-                "updated_at": {
+                "legalName": {
                     "type": "string"
                 }
             }
         },
-        "models.OffersRequest": {
+        "ocds.Item": {
             "type": "object",
-            "required": [
-                "comments",
-                "contractor_id",
-                "delivery_time",
-                "price",
-                "tender_id"
-            ],
             "properties": {
-                "comments": {
+                "classification": {
+                    "$ref": "#/definitions/ocds.Classification"
+                },
+                "description": {
                     "type": "string"
                 },
-                "contractor_id": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "ocds.OrganizationRef": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
                 },
-                "delivery_time": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "ocds.Party": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "identifier": {
+                    "$ref": "#/definitions/ocds.Identifier"
                 },
-                "status": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "tender_id": {
-                    "type": "integer"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "models.ResetPassword": {
+        "ocds.Period": {
             "type": "object",
             "properties": {
-                "confirm_password": {
+                "endDate": {
                     "type": "string"
                 },
-                "new_password": {
+                "startDate": {
                     "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
                 }
             }
         },
-        "models.TenderRequest": {
+        "ocds.Publisher": {
             "type": "object",
-            "required": [
-                "budget",
-                "client_id",
-                "deadline",
-                "description",
-                "title"
-            ],
             "properties": {
-                "budget": {
-                    "type": "number"
+                "name": {
+                    "type": "string"
                 },
-                "client_id": {
-                    "type": "integer"
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
+        "ocds.Record": {
+            "type": "object",
+            "properties": {
+                "compiledRelease": {
+                    "$ref": "#/definitions/ocds.Release"
                 },
-                "deadline": {
+                "ocid": {
                     "type": "string"
                 },
-                "description": {
+                "releases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Release"
+                    }
+                }
+            }
+        },
+        "ocds.RecordPackage": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publishedDate": {
                     "type": "string"
                 },
-                "file_url": {
+                "publisher": {
+                    "$ref": "#/definitions/ocds.Publisher"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Record"
+                    }
+                },
+                "uri": {
                     "type": "string"
                 },
-                "title": {
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "models.Tenders": {
+        "ocds.Release": {
             "type": "object",
-            "required": [
-                "client_id"
-            ],
             "properties": {
-                "budget": {
-                    "type": "number"
+                "awards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Award"
+                    }
                 },
-                "client_id": {
-                    "type": "integer"
+                "bids": {
+                    "$ref": "#/definitions/ocds.Bids"
                 },
-                "created_at": {
+                "buyer": {
+                    "$ref": "#/definitions/ocds.OrganizationRef"
+                },
+                "date": {
                     "type": "string"
                 },
-                "deadline": {
+                "id": {
                     "type": "string"
                 },
-                "description": {
+                "initiationType": {
                     "type": "string"
                 },
-                "file_url": {
+                "ocid": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "parties": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Party"
+                    }
                 },
-                "status": {
-                    "type": "boolean"
+                "tag": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "title": {
+                "tender": {
+                    "$ref": "#/definitions/ocds.Tender"
+                }
+            }
+        },
+        "ocds.ReleasePackage": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publishedDate": {
                     "type": "string"
                 },
-                "updated_at": {
+                "publisher": {
+                    "$ref": "#/definitions/ocds.Publisher"
+                },
+                "releases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Release"
+                    }
+                },
+                "uri": {
+                    "type": "string"
+                },
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "models.UserRegister": {
+        "ocds.Tender": {
             "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "phone_number",
-                "role"
-            ],
             "properties": {
-                "email": {
+                "awardCriteria": {
                     "type": "string"
                 },
-                "first_name": {
+                "awardCriteriaDetails": {
                     "type": "string"
                 },
-                "password": {
+                "description": {
                     "type": "string"
                 },
-                "phone_number": {
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Document"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "client",
-                        "contractor"
-                    ]
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.Item"
+                    }
+                },
+                "numberOfTenderers": {
+                    "type": "integer"
+                },
+                "procurementMethod": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenderPeriod": {
+                    "$ref": "#/definitions/ocds.Period"
+                },
+                "tenderers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ocds.OrganizationRef"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "value": {
+                    "$ref": "#/definitions/ocds.Value"
                 }
             }
         },
-        "models.VerifyRequest": {
+        "ocds.Value": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "amount": {
+                    "type": "number"
                 },
-                "phone_number": {
+                "currency": {
                     "type": "string"
                 }
             }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key for machine-to-machine integrations",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Bearer token",
             "type": "apiKey",
@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
 	"log"
 	"tender_management/config"
 	"tender_management/controllers"
+	"tender_management/models"
 	"tender_management/pkg/db"
+	"tender_management/pkg/events"
+	"tender_management/pkg/filestore"
 	"tender_management/pkg/middleware"
+	"tender_management/pkg/notifier"
+	"tender_management/pkg/outbox"
 	"tender_management/pkg/redise"
+	"tender_management/pkg/scheduler"
+	"tender_management/pkg/telegram"
+	"tender_management/pkg/webhooks"
+	"tender_management/service"
+	"tender_management/storage"
+	"time"
 
 	_ "tender_management/docs"
 
@@ -28,6 +40,11 @@ import (
 // @name Authorization
 // @description Bearer token
 // @type apiKey
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key for machine-to-machine integrations
 func main() {
 
 	enforcer, err := casbin.NewEnforcer("auth_model.conf", "policy.csv")
@@ -38,6 +55,12 @@ func main() {
 	r := gin.Default()
 
 	cfg := config.LoadConfig()
+	if len(cfg.SecretKey) == 0 {
+		log.Fatal("SEKRET_KEY must be set to sign tokens")
+	}
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	gin.SetMode(gin.ReleaseMode)
 
 	conn, err := db.ConnectDB(cfg)
@@ -51,10 +74,60 @@ func main() {
 		log.Fatalf("Redis ulanish xatosi: %v", err)
 	}
 	
-	authSt := controllers.NewAuthController(conn, redisDb, &cfg)
-	tenderSt := controllers.NewTenderController(conn)
-	offerSt := controllers.NewOfferController(conn)
-	notifSt := controllers.NewNotifController(conn)
+	dispatcher := notifier.NewDispatcher(conn, &cfg, notifier.LogSMS{})
+	bus := events.NewBus()
+	notifier.Register(bus, conn)
+	webhooks.Register(bus, conn)
+
+	httpClient := outbox.NewWebhookClient(10*time.Second, cfg.WebhookAllowPrivate)
+	webhookSender := webhooks.NewSender(conn, httpClient)
+
+	relay := outbox.NewRelay(conn)
+	relay.Handle(models.OutboxEvent, outbox.EventHandler(bus, rd))
+	relay.Handle(models.OutboxEmail, outbox.EmailHandler(&cfg))
+	relay.Handle(models.OutboxWebhook, outbox.WebhookHandler(httpClient))
+	relay.Handle(models.OutboxNotifDelivery, dispatcher.HandleDelivery)
+	relay.Handle(models.OutboxWebhookDelivery, webhookSender.HandleDelivery)
+
+	if cfg.TelegramBotToken != "" {
+		tg := telegram.NewHTTPClient(cfg.TelegramAPIURL, cfg.TelegramBotToken)
+		telegram.Register(bus, conn)
+		relay.Handle(models.OutboxTelegram, telegram.Handler(tg))
+		go telegram.NewBot(conn, redisDb, tg).Run(context.Background())
+	}
+
+	go scheduler.OutboxRelay(context.Background(), relay, 5*time.Second)
+	go scheduler.DeadlineReminders(context.Background(), conn, 10*time.Minute)
+	go scheduler.Digests(context.Background(), dispatcher, time.Hour)
+	go scheduler.ReportRefresh(context.Background(), conn, 15*time.Minute)
+
+	store := storage.New(conn)
+	access := service.NewAccessService(store)
+	tenders := service.NewTenderService(store, cfg.Currency)
+
+	go scheduler.Revaluations(context.Background(), tenders, 10*time.Minute)
+
+	authSt := controllers.NewAuthController(service.NewUserService(store, redisDb), redisDb, &cfg)
+	tenderSt := controllers.NewTenderController(tenders)
+	offerSt := controllers.NewOfferController(service.NewOfferService(store, cfg.Currency))
+	notifSt := controllers.NewNotifController(service.NewNotifService(store, cfg.WebhookAllowPrivate))
+	apiKeySt := controllers.NewAPIKeyController(conn, enforcer)
+	orgSt := controllers.NewOrganizationController(conn, &cfg)
+	contractorSt := controllers.NewContractorController(conn)
+	outboxSt := controllers.NewOutboxController(conn)
+	webhookSt := controllers.NewWebhookController(conn, webhookSender, access, &cfg)
+	telegramSt := controllers.NewTelegramController(conn, redisDb, &cfg)
+	categorySt := controllers.NewCategoryController(conn)
+	rateSt := controllers.NewRateController(conn, &cfg)
+	reportSt := controllers.NewReportController(conn)
+	ocdsSt := controllers.NewOCDSController(conn, &cfg)
+
+	files, err := filestore.New(&cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure file storage: %v", err)
+	}
+	attachmentSt := controllers.NewAttachmentController(conn, files, access, &cfg)
+	publicSt := controllers.NewPublicController(conn, files, &cfg)
 
 	public := r.Group("")
 
@@ -65,14 +138,82 @@ func main() {
 	public.POST("/auth/forgot-password", authSt.ForGotPassword)
 	public.POST("/auth/verify-forgot-password", authSt.VerifyForgotPassword)
 	public.POST("/auth/new-password", authSt.NewPassword)
+	public.POST("/auth/login/2fa", authSt.LoginTwoFactor)
 
-	r.Use(middleware.AutoMiddleware(enforcer))
+	r.Use(middleware.AutoMiddleware(enforcer, conn, cfg.SecretKey))
+
+	r.POST("/auth/2fa/enroll", authSt.EnrollTwoFactor)
+	r.POST("/auth/2fa/confirm", authSt.ConfirmTwoFactor)
+	r.POST("/auth/2fa/recovery-codes", authSt.RegenerateRecoveryCodes)
+	r.POST("/auth/2fa/disable", authSt.DisableTwoFactor)
+
+	r.POST("/api-keys", apiKeySt.CreateAPIKey)
+	r.GET("/api-keys", apiKeySt.GetAPIKeys)
+	r.DELETE("/api-keys/:id", apiKeySt.RevokeAPIKey)
+
+	r.POST("/organizations", orgSt.CreateOrganization)
+	r.GET("/organizations", orgSt.GetOrganizations)
+	r.GET("/organizations/:id", orgSt.GetOrganization)
+	r.POST("/organizations/:id/invitations", orgSt.InviteMember)
+	r.POST("/organizations/invitations/accept", orgSt.AcceptInvitation)
+	r.PUT("/organizations/:id/members/:user_id", orgSt.UpdateMemberRole)
+	r.DELETE("/organizations/:id/members/:user_id", orgSt.RemoveMember)
+
+	r.PUT("/contractors/profile", contractorSt.SaveProfile)
+	r.GET("/contractors/profile", contractorSt.GetMyProfile)
+	r.GET("/contractors/:id/profile", contractorSt.GetProfile)
+	r.POST("/contractors/documents", contractorSt.AddDocument)
+	r.DELETE("/contractors/documents/:id", contractorSt.DeleteDocument)
+
+	r.POST("/attachments", attachmentSt.UploadAttachment)
+	r.GET("/attachments", attachmentSt.GetAttachments)
+	r.GET("/attachments/:id/download", attachmentSt.DownloadAttachment)
+	r.DELETE("/attachments/:id", attachmentSt.DeleteAttachment)
+
+	r.GET("/admin/2fa-policy", authSt.GetTwoFactorPolicies)
+	r.PUT("/admin/2fa-policy", authSt.SetTwoFactorPolicy)
+
+	r.POST("/webhooks", webhookSt.CreateEndpoint)
+	r.GET("/webhooks", webhookSt.ListEndpoints)
+	r.GET("/webhooks/dead-letters", webhookSt.ListDeadLetters)
+	r.DELETE("/webhooks/:id", webhookSt.DeleteEndpoint)
+	r.POST("/webhooks/:id/ping", webhookSt.PingEndpoint)
+	r.GET("/webhooks/:id/deliveries", webhookSt.ListDeliveries)
+	r.POST("/webhooks/deliveries/:id/redeliver", webhookSt.Redeliver)
+
+	r.GET("/categories", categorySt.ListCategories)
+	r.POST("/admin/categories/import", categorySt.ImportCategories)
+
+	r.GET("/exchange-rates", rateSt.ListRates)
+	r.POST("/admin/exchange-rates/import", rateSt.ImportRates)
+
+	r.POST("/telegram/link-code", telegramSt.CreateLinkCode)
+	r.GET("/telegram/link", telegramSt.GetLink)
+	r.DELETE("/telegram/link", telegramSt.Unlink)
+
+	r.GET("/admin/outbox", outboxSt.ListMessages)
+	r.GET("/admin/outbox/:id", outboxSt.GetMessage)
+	r.POST("/admin/outbox/:id/retry", outboxSt.RetryMessage)
+
+	r.GET("/reports/summary", reportSt.GetSummary)
+	r.GET("/reports/top-contractors", reportSt.GetTopContractors)
+	r.POST("/admin/reports/refresh", reportSt.RefreshReports)
+
+	r.GET("/ocds/releases", ocdsSt.GetReleasePackage)
+	r.GET("/ocds/records", ocdsSt.GetRecordPackage)
 
 	r.POST("/tenders", tenderSt.CreateTender)
 	r.GET("/tenders", tenderSt.GetAllTenders)
-	r.GET("/tenders/:client_id", tenderSt.GetTenders)
+	r.GET("/tenders/search", tenderSt.SearchTenders)
+	r.GET("/tenders/:id", tenderSt.GetTenders)
+	r.GET("/tenders/:id/offers", tenderSt.GetTenderOffers)
+	r.GET("/tenders/:id/comparison", tenderSt.CompareOffers)
+	r.GET("/tenders/:id/stats", tenderSt.GetTenderStats)
+	r.GET("/tenders/:id/ocds", ocdsSt.GetTenderRelease)
 	r.PUT("/tenders/:id", tenderSt.UpdateTender)
 	r.DELETE("/tenders/:id", tenderSt.DeleteTender)
+	r.POST("/tenders/:id/award", tenderSt.AwardTender)
+	r.POST("/tenders/:id/cancel", tenderSt.CancelTender)
 	r.PATCH("/tenders/restore/:id", tenderSt.RestoreTender)
 
 	r.POST("/offers", offerSt.CreateOffer)
@@ -85,7 +226,22 @@ func main() {
 	r.PATCH("/offers/restore/:id", offerSt.RestoreOffer)
 
 	r.POST("/notifs", notifSt.CreateNotif)
+	r.GET("/notifs", notifSt.GetInbox)
+	r.GET("/notifs/unread-count", notifSt.GetUnreadCount)
+	r.GET("/notifs/preferences", notifSt.GetPreferences)
+	r.PUT("/notifs/preferences", notifSt.SetPreference)
 	r.GET("/notifs/:user_id/:relation_id", notifSt.GetNotifsUser)
+	r.PATCH("/notifs/read-all", notifSt.MarkAllRead)
+	r.PATCH("/notifs/:id/read", notifSt.MarkRead)
+	r.PATCH("/notifs/:id/archive", notifSt.ArchiveNotif)
+	r.DELETE("/notifs/:id", notifSt.DeleteNotif)
+
+	portal := public.Group("/public", middleware.RateLimit(rd, "public", int(cfg.PublicRateLimit), time.Minute))
+	portal.GET("/tenders", publicSt.ListTenders)
+	portal.GET("/tenders/:id", publicSt.GetTender)
+	portal.GET("/tenders/:id/attachments/:attachment_id", publicSt.DownloadAttachment)
+	portal.GET("/feed.rss", publicSt.GetRSSFeed)
+	portal.GET("/feed.atom", publicSt.GetAtomFeed)
 
 	public.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
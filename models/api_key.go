@@ -0,0 +1,54 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// APIKey is a long-lived credential for machine-to-machine integrations.
+// Scopes is a comma separated list of "METHOD /path" permissions, each of
+// which must also be allowed for the owner's role.
+type APIKey struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	Name       string     `gorm:"type:varchar(255);not null" json:"name"`
+	Lookup     string     `gorm:"type:varchar(32);unique;not null" json:"prefix"`
+	KeyHash    string     `gorm:"type:varchar(64);not null" json:"-"`
+	Scopes     string     `gorm:"type:text;not null" json:"-"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Users      *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	ScopeList []string `gorm:"-" json:"scopes"`
+}
+
+type APIKeyRequest struct {
+	Name      string   `json:"name" binding:"required"`
+	Scopes    []string `json:"scopes" binding:"required,min=1"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+}
+
+func (k *APIKey) SplitScopes() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// ParseScope splits a "METHOD /path" scope into its path and method.
+func ParseScope(scope string) (path, method string, ok bool) {
+	fields := strings.Fields(scope)
+	if len(fields) != 2 || !strings.HasPrefix(fields[1], "/") {
+		return "", "", false
+	}
+	return fields[1], strings.ToUpper(fields[0]), true
+}
+
+// CredentialPath reports whether path manages the caller's credentials:
+// API keys and two-factor authentication. API keys may not call these
+// paths, so that a leaked key cannot mint wider keys or turn off 2FA.
+func CredentialPath(path string) bool {
+	return path == "/api-keys" || strings.HasPrefix(path, "/api-keys/") || strings.HasPrefix(path, "/auth/2fa/")
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	AttachmentOwnerTender = "tender"
+	AttachmentOwnerOffer  = "offer"
+)
+
+// Attachment is a file uploaded to a tender or an offer. The contents live
+// in the configured file store under StorageKey. TextContent keeps the text
+// of plain text tender attachments for full-text search.
+type Attachment struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	OwnerType   string     `gorm:"type:varchar(20);not null;index:idx_attachment_owner" json:"owner_type"`
+	OwnerID     uint       `gorm:"not null;index:idx_attachment_owner" json:"owner_id"`
+	FileName    string     `gorm:"type:varchar(255);not null" json:"file_name"`
+	ContentType string     `gorm:"type:varchar(100);not null" json:"content_type"`
+	Size        int64      `gorm:"not null" json:"size"`
+	SHA256      string     `gorm:"type:varchar(64);not null" json:"sha256"`
+	StorageKey  string     `gorm:"type:varchar(255);not null;unique" json:"-"`
+	UploadedBy  uint       `gorm:"not null" json:"uploaded_by"`
+	TextContent string     `gorm:"type:text" json:"-"`
+	DeletedAt   *time.Time `gorm:"index" json:"-"`
+	CreatedAt   *time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
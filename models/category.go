@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Category is a node of the classification tree, e.g. a CPV code. Path
+// lists the codes from the root down to the category itself, like
+// "/45000000/45200000/", so descendants are found with a prefix match.
+type Category struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string     `gorm:"type:varchar(20);not null;unique" json:"code"`
+	Name      string     `gorm:"type:varchar(500);not null" json:"name"`
+	ParentID  *uint      `gorm:"index" json:"parent_id"`
+	Path      string     `gorm:"type:varchar(500);not null;index" json:"-"`
+	CreatedAt *time.Time `gorm:"autoCreateTime" json:"-"`
+	Parent    *Category  `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL;" json:"-"`
+}
+
+// CategoryRow is one entry of an imported code list. ParentCode may be
+// empty, in which case the parent is derived from the CPV code structure.
+type CategoryRow struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	ParentCode string `json:"parent_code,omitempty"`
+}
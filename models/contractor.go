@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ContractorProfile is the company information a contractor shows to
+// clients. Specialisations is a comma separated list.
+type ContractorProfile struct {
+	ID              uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          uint       `gorm:"not null;unique" json:"user_id"`
+	LegalName       string     `gorm:"type:varchar(255);not null" json:"legal_name"`
+	TaxID           string     `gorm:"type:varchar(9);not null;unique" json:"tax_id"`
+	Address         string     `gorm:"type:text;not null" json:"address"`
+	Specialisations string     `gorm:"type:text" json:"-"`
+	YearsInBusiness int        `gorm:"not null;default:0" json:"years_in_business"`
+	CreatedAt       *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	Users           *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	SpecialisationList []string   `gorm:"-" json:"specialisations"`
+	Categories         []Category `gorm:"many2many:contractor_categories;joinForeignKey:ProfileID;joinReferences:CategoryID" json:"categories"`
+}
+
+func (p *ContractorProfile) SplitSpecialisations() []string {
+	if p.Specialisations == "" {
+		return nil
+	}
+	return strings.Split(p.Specialisations, ",")
+}
+
+// QualificationDocument is a licence or certificate proving that a
+// contractor holds a qualification, identified by a short code such as
+// "construction-licence".
+type QualificationDocument struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uint       `gorm:"not null;index" json:"user_id"`
+	Type          string     `gorm:"type:varchar(50);not null" json:"type"`
+	Qualification string     `gorm:"type:varchar(100);not null;index" json:"qualification"`
+	Number        string     `gorm:"type:varchar(100);not null" json:"number"`
+	FileURL       string     `gorm:"type:varchar(255)" json:"file_url,omitempty"`
+	IssuedAt      *time.Time `json:"issued_at"`
+	ExpiresAt     *time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt     *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Users         *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type TenderQualification struct {
+	ID            uint     `gorm:"primaryKey;autoIncrement" json:"-"`
+	TenderID      uint     `gorm:"not null;index" json:"-"`
+	Qualification string   `gorm:"type:varchar(100);not null" json:"qualification"`
+	Tenders       *Tenders `gorm:"foreignKey:TenderID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type ContractorProfileRequest struct {
+	LegalName       string   `json:"legal_name" binding:"required"`
+	TaxID           string   `json:"tax_id" binding:"required"`
+	Address         string   `json:"address" binding:"required"`
+	Specialisations []string `json:"specialisations"`
+	Categories      []string `json:"categories"`
+	YearsInBusiness int      `json:"years_in_business" binding:"gte=0"`
+}
+
+type QualificationDocumentRequest struct {
+	Type          string `json:"type" binding:"required,oneof=licence certificate"`
+	Qualification string `json:"qualification" binding:"required"`
+	Number        string `json:"number" binding:"required"`
+	FileURL       string `json:"file_url,omitempty"`
+	IssuedAt      string `json:"issued_at,omitempty"`
+	ExpiresAt     string `json:"expires_at" binding:"required"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Criteria offers are scored on. Every criterion scores 0 to 100, the best
+// offer getting 100.
+const (
+	CriterionPrice      = "price"
+	CriterionDelivery   = "delivery"
+	CriterionExperience = "experience"
+)
+
+// TenderCriterion is the weight a tender gives to one criterion when
+// ranking its offers. A tender without criteria ranks on price alone.
+type TenderCriterion struct {
+	ID        uint     `gorm:"primaryKey;autoIncrement" json:"-"`
+	TenderID  uint     `gorm:"not null;index" json:"-"`
+	Criterion string   `gorm:"type:varchar(30);not null" json:"criterion"`
+	Weight    float64  `gorm:"type:decimal(5,2);not null" json:"weight"`
+	Tenders   *Tenders `gorm:"foreignKey:TenderID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// OfferComparison is one row of a tender's comparison matrix. Price is as
+// offered; NormalizedPrice is in the tender currency at ExchangeRate, from
+// the rates of RateDate, and is what offers are ranked on. Deviation is
+// the normalized price minus the budget, DeliveryDays the days between the
+// tender deadline and the promised delivery.
+type OfferComparison struct {
+	Rank            int                `json:"rank"`
+	OfferID         uint               `json:"offer_id"`
+	ContractorID    uint               `json:"contractor_id"`
+	OrganizationID  *uint              `json:"organization_id,omitempty"`
+	Price           decimal.Decimal    `json:"price" swaggertype:"string"`
+	Currency        string             `json:"currency"`
+	NormalizedPrice decimal.Decimal    `json:"normalized_price" swaggertype:"string"`
+	ExchangeRate    decimal.Decimal    `json:"exchange_rate" swaggertype:"string"`
+	RateDate        *time.Time         `json:"rate_date,omitempty"`
+	Deviation       decimal.Decimal    `json:"deviation" swaggertype:"string"`
+	DeviationPct    float64            `json:"deviation_pct"`
+	DeliveryTime    *time.Time         `json:"delivery_time"`
+	DeliveryDays    int                `json:"delivery_days"`
+	Scores          map[string]float64 `json:"scores"`
+	Total           float64            `json:"total"`
+}
+
+// TenderComparison lays a tender's offers side by side, best first.
+// RatesAsOf is the date offers in other currencies were valued at.
+type TenderComparison struct {
+	TenderID  uint              `json:"tender_id"`
+	Title     string            `json:"title"`
+	Budget    decimal.Decimal   `json:"budget" swaggertype:"string"`
+	Currency  string            `json:"currency"`
+	Deadline  *time.Time        `json:"deadline"`
+	RatesAsOf *time.Time        `json:"rates_as_of"`
+	Criteria  []TenderCriterion `json:"criteria"`
+	Offers    []OfferComparison `json:"offers"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRate is the value of one unit of Currency in the base currency
+// on Date, as published by the Central Bank. Rates published per nominal,
+// such as 100 JPY, are stored divided down to one unit.
+type ExchangeRate struct {
+	ID        uint            `gorm:"primaryKey;autoIncrement" json:"-"`
+	Currency  string          `gorm:"type:varchar(3);not null;uniqueIndex:idx_exchange_rates_currency_date" json:"currency"`
+	Date      time.Time       `gorm:"type:date;not null;uniqueIndex:idx_exchange_rates_currency_date" json:"date"`
+	Rate      decimal.Decimal `gorm:"type:numeric(24,10);not null" json:"rate" swaggertype:"string" example:"12739.22"`
+	Source    string          `gorm:"type:varchar(100)" json:"source,omitempty"`
+	CreatedAt *time.Time      `gorm:"autoCreateTime" json:"-"`
+}
+
+// ExchangeRateRow is one entry of an imported rate file, still as text.
+// Nominal is the number of units Rate is quoted for, 1 when empty; Date is
+// 2006-01-02 or the Central Bank's 02.01.2006.
+type ExchangeRateRow struct {
+	Currency string `json:"currency"`
+	Rate     string `json:"rate"`
+	Nominal  string `json:"nominal,omitempty"`
+	Date     string `json:"date"`
+}
@@ -2,21 +2,50 @@ package models
 
 import "time"
 
+type NotifType string
+
+const (
+	NotifOfferSubmitted     NotifType = "offer.submitted"
+	NotifOfferUpdated       NotifType = "offer.updated"
+	NotifTenderAmended      NotifType = "tender.amended"
+	NotifTenderDeadlineSoon NotifType = "tender.deadline_soon"
+	NotifTenderAwarded      NotifType = "tender.awarded"
+	NotifTenderCancelled    NotifType = "tender.cancelled"
+	NotifAnnouncement       NotifType = "announcement"
+)
+
+var NotifTypes = []NotifType{
+	NotifOfferSubmitted,
+	NotifOfferUpdated,
+	NotifTenderAmended,
+	NotifTenderDeadlineSoon,
+	NotifTenderAwarded,
+	NotifTenderCancelled,
+	NotifAnnouncement,
+}
+
+// Notif is a notification for one user. RelationID refers to the offer for
+// offer.* types and to the tender for every other type. InboxHidden is set
+// when the user turned in-app delivery off for the type; the row is still
+// kept for the other channels. EventID is the events.Event that created the
+// row, so that a redelivered event does not notify twice.
 type Notif struct {
-	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID     uint       `gorm:"not null;index" json:"user_id"`
-	Message    string     `gorm:"type:text;not null" json:"message"`
-	RelationID uint       `gorm:"not null" json:"relation_id"`
-	Type       string     `gorm:"type:varchar(50);not null" json:"type"`
-	CreatedAt  *time.Time `gorm:"autoCreateTime" json:"created_at"`
-	Users      *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
-	Tenders    *Tenders   `gorm:"foreignKey:RelationID;constraint:OnDelete:CASCADE;" json:"-"`
-	Offers     *Offers    `gorm:"foreignKey:RelationID;constraint:OnDelete:CASCADE;" json:"-"`
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Message     string     `gorm:"type:text;not null" json:"message"`
+	RelationID  uint       `gorm:"not null" json:"relation_id"`
+	Type        NotifType  `gorm:"type:varchar(50);not null" json:"type"`
+	ReadAt      *time.Time `json:"read_at"`
+	ArchivedAt  *time.Time `gorm:"index" json:"archived_at,omitempty"`
+	InboxHidden bool       `gorm:"not null;default:false" json:"-"`
+	EventID     string     `gorm:"type:varchar(100);index" json:"-"`
+	CreatedAt   *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Users       *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
 }
 
 type NotifRequest struct {
-	UserID     uint   `json:"user_id" binding:"required"`
-	Message    string `json:"message" binding:"required"`
-	RelationID uint   `json:"relation_id" binding:"required"`
-	Type       string `json:"type" binding:"required"`
+	UserID     uint      `json:"user_id" binding:"required"`
+	Message    string    `json:"message" binding:"required"`
+	RelationID uint      `json:"relation_id" binding:"required"`
+	Type       NotifType `json:"type" binding:"required,oneof=offer.submitted offer.updated tender.amended tender.deadline_soon tender.awarded tender.cancelled announcement"`
 }
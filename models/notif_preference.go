@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelSMS     = "sms"
+	ChannelWebhook = "webhook"
+
+	DeliveryImmediate = "immediate"
+	DeliveryDigest    = "digest"
+
+	DeliveryPending = "pending"
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"
+)
+
+// NotifPreference sets how a user wants to receive one notification type.
+// Mode only affects email: in digest mode emails are collected into one
+// daily message, while SMS and webhooks are always sent immediately.
+type NotifPreference struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID     uint       `gorm:"not null;uniqueIndex:idx_notif_pref" json:"-"`
+	Type       NotifType  `gorm:"type:varchar(50);not null;uniqueIndex:idx_notif_pref" json:"type"`
+	InApp      bool       `gorm:"not null" json:"in_app"`
+	Email      bool       `gorm:"not null" json:"email"`
+	SMS        bool       `gorm:"not null" json:"sms"`
+	Webhook    bool       `gorm:"not null" json:"webhook"`
+	WebhookURL string     `gorm:"type:varchar(255)" json:"webhook_url,omitempty"`
+	Mode       string     `gorm:"type:varchar(20);not null" json:"mode"`
+	UpdatedAt  *time.Time `gorm:"autoUpdateTime" json:"updated_at,omitempty"`
+	Users      *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// DefaultNotifPreference is used for types a user has not configured:
+// in-app only, delivered immediately.
+func DefaultNotifPreference(userID uint, t NotifType) NotifPreference {
+	return NotifPreference{
+		UserID: userID,
+		Type:   t,
+		InApp:  true,
+		Mode:   DeliveryImmediate,
+	}
+}
+
+// NotifDelivery tracks sending one notification over one external channel.
+type NotifDelivery struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	NotifID   uint       `gorm:"not null;index" json:"notif_id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Channel   string     `gorm:"type:varchar(20);not null" json:"channel"`
+	Digest    bool       `gorm:"not null;default:false" json:"digest"`
+	Status    string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempts  int        `gorm:"not null;default:0" json:"attempts"`
+	LastError string     `gorm:"type:text" json:"last_error,omitempty"`
+	SentAt    *time.Time `json:"sent_at"`
+	CreatedAt *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Notif     *Notif     `gorm:"foreignKey:NotifID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type NotifPreferenceRequest struct {
+	Type       NotifType `json:"type" binding:"required,oneof=offer.submitted offer.updated tender.amended tender.deadline_soon tender.awarded tender.cancelled announcement"`
+	InApp      bool      `json:"in_app"`
+	Email      bool      `json:"email"`
+	SMS        bool      `json:"sms"`
+	Webhook    bool      `json:"webhook"`
+	WebhookURL string    `json:"webhook_url" binding:"omitempty,url"`
+	Mode       string    `json:"mode" binding:"required,oneof=immediate digest"`
+}
@@ -1,36 +1,88 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type Offers struct {
-	ID           uint       `gorm:"primaryKey;autoIncrement" json:"id"`
-	TenderID     uint       `gorm:"not null" json:"tender_id" binding:"required"`
-	ContractorID uint       `gorm:"not null" json:"contractor_id" binding:"required"`
-	Price        float64    `gorm:"type:decimal(10,2);not null" json:"price"`
-	DeliveryTime *time.Time `gorm:"not null" json:"delivery_time"`
-	Comments     string     `gorm:"type:text;not null" json:"comments"`
-	Status       bool       `gorm:"default:true" json:"status"`
-	DeletedAt    *time.Time `gorm:"index" json:"-"`
-	CreatedAt    *time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Users        *Users     `gorm:"foreignKey:ContractorID" json:"-"`
-	Tenders      *Tenders   `gorm:"foreignKey:TenderID" json:"-"`
+	ID           uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenderID     uint            `gorm:"not null" json:"tender_id" binding:"required"`
+	ContractorID uint            `gorm:"not null" json:"contractor_id" binding:"required"`
+	Price        decimal.Decimal `gorm:"type:numeric(20,2);not null" json:"price" swaggertype:"string" example:"118500000.00"`
+	Currency     string          `gorm:"type:varchar(3);not null" json:"currency"`
+	// NormalizedPrice is the price in the tender currency at ExchangeRate,
+	// derived from the rates published on RateDate. Offers in the tender
+	// currency have a rate of 1 and no rate date.
+	NormalizedPrice decimal.Decimal `gorm:"type:numeric(20,2);not null" json:"normalized_price" swaggertype:"string" example:"118500000.00"`
+	ExchangeRate    decimal.Decimal `gorm:"type:numeric(24,10);not null" json:"exchange_rate" swaggertype:"string" example:"1"`
+	RateDate        *time.Time      `gorm:"type:date" json:"rate_date,omitempty"`
+	DeliveryTime    *time.Time      `gorm:"not null" json:"delivery_time"`
+	Comments        string          `gorm:"type:text;not null" json:"comments"`
+	Status          bool            `gorm:"default:true" json:"status"`
+	OrganizationID  *uint           `gorm:"index" json:"organization_id,omitempty"`
+	DeletedAt       *time.Time      `gorm:"index" json:"-"`
+	CreatedAt       *time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       *time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	Users           *Users          `gorm:"foreignKey:ContractorID" json:"-"`
+	Tenders         *Tenders        `gorm:"foreignKey:TenderID" json:"-"`
+	Organization    *Organization   `gorm:"foreignKey:OrganizationID" json:"-"`
 }
 
 type OffersRequest struct {
-	TenderID     uint    `json:"tender_id" binding:"required"`
-	ContractorID uint    `json:"contractor_id" binding:"required"`
-	Price        float64 `json:"price" binding:"required,gt=0"`
-	DeliveryTime string  `json:"delivery_time" binding:"required"`
-	Comments     string  `json:"comments" binding:"required"`
-	Status       bool    `json:"status"`
+	TenderID     uint            `json:"tender_id" binding:"required"`
+	ContractorID uint            `json:"contractor_id" binding:"required"`
+	Price        decimal.Decimal `json:"price" binding:"required" swaggertype:"string" example:"118500000.00"`
+	// Currency must be one the tender accepts; it defaults to the
+	// tender currency when empty.
+	Currency       string `json:"currency,omitempty"`
+	DeliveryTime   string `json:"delivery_time" binding:"required"`
+	Comments       string `json:"comments" binding:"required"`
+	Status         bool   `json:"status"`
+	OrganizationID *uint  `json:"organization_id,omitempty"`
 }
 
-type Stats struct {
-	MinPrice     float64   `json:"min_price"`
-	MaxPrice     float64   `json:"max_price"`
-	MinDelivery  time.Time `json:"min_delivery"`
-	MaxDelivery  time.Time `json:"max_delivery"`
-	TotalRecords int64     `json:"total_records"`
+// TenderStats summarises the live offers on one tender. Prices are the
+// normalized prices, in the tender currency. Deviations are price minus
+// budget; delivery buckets count offers by days between the
+// tender deadline and the promised delivery.
+type TenderStats struct {
+	TenderID           uint            `json:"tender_id"`
+	Budget             decimal.Decimal `json:"budget" swaggertype:"string"`
+	Currency           string          `json:"currency"`
+	OfferCount         int64           `json:"offer_count"`
+	MinPrice           decimal.Decimal `json:"min_price" swaggertype:"string"`
+	MaxPrice           decimal.Decimal `json:"max_price" swaggertype:"string"`
+	MeanPrice          decimal.Decimal `json:"mean_price" swaggertype:"string"`
+	MedianPrice        decimal.Decimal `json:"median_price" swaggertype:"string"`
+	MinDeviation       decimal.Decimal `json:"min_deviation" swaggertype:"string"`
+	MaxDeviation       decimal.Decimal `json:"max_deviation" swaggertype:"string"`
+	MeanDeviationPct   float64         `json:"mean_deviation_pct"`
+	UnderBudget        int64           `json:"under_budget"`
+	OverBudget         int64           `json:"over_budget"`
+	MinDelivery        *time.Time      `json:"min_delivery"`
+	MaxDelivery        *time.Time      `json:"max_delivery"`
+	MedianDelivery     *time.Time      `json:"median_delivery"`
+	DeliveryByDeadline int64           `json:"delivery_by_deadline"`
+	DeliveryWeek       int64           `json:"delivery_within_week"`
+	DeliveryMonth      int64           `json:"delivery_within_month"`
+	DeliveryQuarter    int64           `json:"delivery_within_quarter"`
+	DeliveryLater      int64           `json:"delivery_later"`
+
+	// The offers at each extreme as JSON arrays, decoded into Extremes.
+	CheapestOffers string        `json:"-"`
+	DearestOffers  string        `json:"-"`
+	FastestOffers  string        `json:"-"`
+	SlowestOffers  string        `json:"-"`
+	Extremes       OfferExtremes `gorm:"-" json:"extremes"`
 }
 
+// OfferExtremes are the offers at each end of the price and delivery range;
+// ties are all included.
+type OfferExtremes struct {
+	Cheapest []Offers `json:"cheapest"`
+	Dearest  []Offers `json:"dearest"`
+	Fastest  []Offers `json:"fastest"`
+	Slowest  []Offers `json:"slowest"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleEditor = "editor"
+	OrgRoleViewer = "viewer"
+)
+
+// Organization is a client or contractor company. Its Kind matches the user
+// role of its members: client organizations own tenders, contractor
+// organizations own offers.
+type Organization struct {
+	ID        uint                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string               `gorm:"type:varchar(255);not null" json:"name"`
+	Kind      string               `gorm:"type:varchar(50);not null" json:"kind"`
+	DeletedAt *time.Time           `gorm:"index" json:"-"`
+	CreatedAt *time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt *time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+	Members   []OrganizationMember `gorm:"foreignKey:OrganizationID" json:"members,omitempty"`
+}
+
+type OrganizationMember struct {
+	ID             uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrganizationID uint          `gorm:"not null;uniqueIndex:idx_org_member" json:"organization_id"`
+	UserID         uint          `gorm:"not null;uniqueIndex:idx_org_member;index" json:"user_id"`
+	Role           string        `gorm:"type:varchar(50);not null" json:"role"`
+	CreatedAt      *time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+	Users          *Users        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type OrganizationInvitation struct {
+	ID             uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrganizationID uint          `gorm:"not null;index" json:"organization_id"`
+	Email          string        `gorm:"type:varchar(255);not null" json:"email"`
+	Role           string        `gorm:"type:varchar(50);not null" json:"role"`
+	TokenHash      string        `gorm:"type:varchar(64);unique;not null" json:"-"`
+	InvitedBy      uint          `gorm:"not null" json:"invited_by"`
+	ExpiresAt      *time.Time    `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time    `json:"accepted_at"`
+	CreatedAt      *time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type OrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type InvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type MemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner editor viewer"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Outbox message kinds. Each kind has one handler in the relay.
+const (
+	OutboxEvent           = "event"
+	OutboxEmail           = "email"
+	OutboxWebhook         = "webhook"
+	OutboxNotifDelivery   = "notif.delivery"
+	OutboxWebhookDelivery = "webhook.delivery"
+	OutboxTelegram        = "telegram"
+)
+
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxFailed  = "failed"
+)
+
+// OutboxMessage is a side effect recorded in the same transaction as the
+// change that caused it, and delivered afterwards by the relay at least
+// once. Receivers use IdempotencyKey to drop duplicates.
+type OutboxMessage struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Kind           string     `gorm:"type:varchar(30);not null;index" json:"kind"`
+	IdempotencyKey string     `gorm:"type:varchar(100);not null;uniqueIndex" json:"idempotency_key"`
+	Payload        string     `gorm:"type:text;not null" json:"payload"`
+	Status         string     `gorm:"type:varchar(20);not null;index:idx_outbox_due" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_outbox_due" json:"next_attempt_at"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	ProcessedAt    *time.Time `json:"processed_at"`
+	CreatedAt      *time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// EmailPayload is the payload of an OutboxEmail message.
+type EmailPayload struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// WebhookPayload is the payload of an OutboxWebhook message.
+type WebhookPayload struct {
+	URL  string `json:"url"`
+	Body string `json:"body"`
+}
+
+// NotifDeliveryPayload is the payload of an OutboxNotifDelivery message.
+type NotifDeliveryPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// WebhookDeliveryPayload is the payload of an OutboxWebhookDelivery message.
+type WebhookDeliveryPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PublicTender is what the public portal shows of an open tender. Budget
+// is left out when the client hid it.
+type PublicTender struct {
+	ID          uint             `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Budget      *decimal.Decimal `json:"budget,omitempty" swaggertype:"string"`
+	Currency    string           `json:"currency"`
+	// AcceptedCurrencies are the currencies offers may be priced in.
+	AcceptedCurrencies []string           `json:"accepted_currencies"`
+	Deadline           *time.Time         `json:"deadline"`
+	PublishedAt        *time.Time         `json:"published_at"`
+	UpdatedAt          *time.Time         `json:"updated_at"`
+	Categories         []PublicCategory   `json:"categories"`
+	Attachments        []PublicAttachment `json:"attachments"`
+}
+
+type PublicCategory struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type PublicAttachment struct {
+	ID          uint   `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+}
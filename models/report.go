@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Report groupings.
+const (
+	ReportByMonth        = "month"
+	ReportByCategory     = "category"
+	ReportByClient       = "client"
+	ReportByOrganization = "organization"
+)
+
+// ReportRow aggregates the tenders of one group in one currency. Savings are
+// budget minus the winning price over awarded tenders; DaysToAward runs from
+// publication to award.
+type ReportRow struct {
+	Group          string          `json:"group"`
+	Label          string          `json:"label,omitempty"`
+	Currency       string          `json:"currency"`
+	Tenders        int64           `json:"tenders"`
+	Awarded        int64           `json:"awarded"`
+	AwardedBudget  decimal.Decimal `json:"awarded_budget" swaggertype:"string"`
+	AwardedValue   decimal.Decimal `json:"awarded_value" swaggertype:"string"`
+	Savings        decimal.Decimal `json:"savings" swaggertype:"string"`
+	SavingsPct     float64         `json:"savings_pct"`
+	AvgBids        float64         `json:"avg_bids"`
+	AvgDaysToAward float64         `json:"avg_days_to_award"`
+}
+
+// ContractorReportRow is one contractor ranked by tenders won in one
+// currency.
+type ContractorReportRow struct {
+	ContractorID uint            `json:"contractor_id"`
+	LegalName    string          `json:"legal_name"`
+	Currency     string          `json:"currency"`
+	Wins         int64           `json:"wins"`
+	AwardedValue decimal.Decimal `json:"awarded_value" swaggertype:"string"`
+	Savings      decimal.Decimal `json:"savings" swaggertype:"string"`
+}
+
+// Report is an analytics response. RefreshedAt is when the underlying
+// snapshot was last rebuilt.
+type Report struct {
+	GroupBy     string      `json:"group_by,omitempty"`
+	From        *time.Time  `json:"from,omitempty"`
+	To          *time.Time  `json:"to,omitempty"`
+	RefreshedAt *time.Time  `json:"refreshed_at"`
+	Rows        interface{} `json:"rows"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TenderSearchHit is one GET /tenders/search result. Headline is the title
+// and Snippet an excerpt of the description as HTML: the text is escaped
+// and matches are wrapped in <b>.
+type TenderSearchHit struct {
+	ID             uint            `json:"id"`
+	Title          string          `json:"title"`
+	Deadline       *time.Time      `json:"deadline"`
+	Budget         decimal.Decimal `json:"budget" swaggertype:"string"`
+	Currency       string          `json:"currency"`
+	State          string          `json:"state"`
+	ClientID       uint            `json:"client_id"`
+	OrganizationID *uint           `json:"organization_id,omitempty"`
+	Rank           float64         `json:"rank"`
+	Headline       string          `json:"headline"`
+	Snippet        string          `json:"snippet"`
+	CreatedAt      *time.Time      `json:"created_at"`
+	UpdatedAt      *time.Time      `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// TelegramLink connects a user to the Telegram chat the bot talks to them
+// in. Each user and each chat can be linked only once.
+type TelegramLink struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"-"`
+	UserID    uint       `gorm:"not null;uniqueIndex" json:"user_id"`
+	ChatID    int64      `gorm:"not null;uniqueIndex" json:"-"`
+	Username  string     `gorm:"type:varchar(100)" json:"username,omitempty"`
+	CreatedAt *time.Time `gorm:"autoCreateTime" json:"linked_at"`
+	Users     *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// TelegramPayload is the payload of an OutboxTelegram message.
+type TelegramPayload struct {
+	ChatID int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
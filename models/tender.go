@@ -1,29 +1,79 @@
 package models
 
 import (
+	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TenderStateOpen      = "open"
+	TenderStateAwarded   = "awarded"
+	TenderStateCancelled = "cancelled"
 )
 
 type Tenders struct {
-	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
-	Title       string     `gorm:"type:varchar(255);not null" json:"title"`
-	Description string     `gorm:"type:text;not null" json:"description"`
-	Deadline    *time.Time `gorm:"not null" json:"deadline"`
-	Budget      float64    `gorm:"type:decimal(10,2);not null" json:"budget"`
-	FileURL     string     `gorm:"type:varchar(255)" json:"file_url,omitempty"`
-	Status      bool       `gorm:"default:true" json:"status"`
-	ClientID    uint       `gorm:"not null" json:"client_id" binding:"required"`
-	DeletedAt   *time.Time `gorm:"index" json:"-"`
-	CreatedAt   *time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
-	Users       *Users     `gorm:"foreignKey:ClientID" json:"-"`
+	ID                 uint            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title              string          `gorm:"type:varchar(255);not null" json:"title"`
+	Description        string          `gorm:"type:text;not null" json:"description"`
+	Deadline           *time.Time      `gorm:"not null" json:"deadline"`
+	Budget             decimal.Decimal `gorm:"type:numeric(20,2);not null" json:"budget" swaggertype:"string" example:"125000000.00"`
+	Currency           string          `gorm:"type:varchar(3);not null" json:"currency"`
+	AcceptedCurrencies string          `gorm:"type:varchar(100);not null" json:"-"`
+	HideBudget         bool            `gorm:"not null;default:false" json:"hide_budget"`
+	FileURL            string          `gorm:"type:varchar(255)" json:"file_url,omitempty"`
+	Status             bool            `gorm:"default:true" json:"status"`
+	ClientID           uint            `gorm:"not null" json:"client_id" binding:"required"`
+	OrganizationID     *uint           `gorm:"index" json:"organization_id,omitempty"`
+	State              string          `gorm:"type:varchar(20);not null;default:open" json:"state"`
+	AwardedOfferID     *uint           `json:"awarded_offer_id,omitempty"`
+	AwardedAt          *time.Time      `json:"awarded_at,omitempty"`
+	ReminderSentAt     *time.Time      `json:"-"`
+	SearchVector       string          `gorm:"->:false;<-:false;type:tsvector;index:idx_tenders_search,type:gin" json:"-"`
+	DeletedAt          *time.Time      `gorm:"index" json:"-"`
+	CreatedAt          *time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          *time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	Users              *Users          `gorm:"foreignKey:ClientID" json:"-"`
+	Organization       *Organization   `gorm:"foreignKey:OrganizationID" json:"-"`
+
+	Qualifications []TenderQualification `gorm:"foreignKey:TenderID" json:"qualifications,omitempty"`
+	Categories     []Category            `gorm:"many2many:tender_categories;joinForeignKey:TenderID;joinReferences:CategoryID" json:"categories,omitempty"`
+	Criteria       []TenderCriterion     `gorm:"foreignKey:TenderID" json:"criteria,omitempty"`
+
+	AcceptedCurrencyList []string `gorm:"-" json:"accepted_currencies,omitempty"`
+}
+
+// SplitAcceptedCurrencies lists the currencies offers may be priced in,
+// the tender's own currency first.
+func (t *Tenders) SplitAcceptedCurrencies() []string {
+	if t.AcceptedCurrencies == "" {
+		return nil
+	}
+	return strings.Split(t.AcceptedCurrencies, ",")
 }
 
 type TenderRequest struct {
-	Title       string  `json:"title" binding:"required"`
-	Description string  `json:"description" binding:"required"`
-	Deadline    string  `json:"deadline" binding:"required"`
-	Budget      float64 `json:"budget" binding:"required,gt=0"`
-	FileURL     string  `json:"file_url,omitempty"`
-	ClientID    uint    `json:"client_id" binding:"required"`
+	Title       string          `json:"title" binding:"required"`
+	Description string          `json:"description" binding:"required"`
+	Deadline    string          `json:"deadline" binding:"required"`
+	Budget      decimal.Decimal `json:"budget" binding:"required" swaggertype:"string" example:"125000000.00"`
+	// Currency is an ISO 4217 code, the configured default when empty.
+	Currency string `json:"currency,omitempty"`
+	// AcceptedCurrencies are other currencies offers may be priced in,
+	// e.g. ["USD", "EUR"]. Offers are compared in Currency.
+	AcceptedCurrencies []string `json:"accepted_currencies,omitempty"`
+	FileURL            string   `json:"file_url,omitempty"`
+	OrganizationID     *uint    `json:"organization_id,omitempty"`
+	Qualifications     []string `json:"qualifications,omitempty"`
+	Categories         []string `json:"categories,omitempty"`
+	// Criteria weighs price, delivery and experience when ranking
+	// offers, e.g. {"price": 70, "delivery": 30}.
+	Criteria map[string]float64 `json:"criteria,omitempty"`
+	// HideBudget keeps the budget out of the public portal and exports.
+	HideBudget bool `json:"hide_budget,omitempty"`
+}
+
+type AwardRequest struct {
+	OfferID uint `json:"offer_id" binding:"required"`
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Users     *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+// TwoFactorPolicy lets an admin make 2FA mandatory for every user of a role.
+type TwoFactorPolicy struct {
+	Role      string     `gorm:"type:varchar(255);primaryKey" json:"role"`
+	Required  bool       `gorm:"default:false" json:"required"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type TwoFactorPolicyRequest struct {
+	Role     string `json:"role" binding:"required,oneof=client contractor admin"`
+	Required bool   `json:"required"`
+}
@@ -10,6 +10,9 @@ type Users struct {
 	Password    string `gorm:"type:varchar(255);not null" json:"password"`
 	Role        string `gorm:"type:varchar(255);not null" json:"user_role"`
 	IsActive    bool   `gorm:"default:false" json:"is_active"`
+
+	TwoFactorSecret  string `gorm:"type:varchar(64)" json:"-"`
+	TwoFactorEnabled bool   `gorm:"default:false" json:"two_factor_enabled"`
 }
 
 type UserRegister struct {
@@ -31,8 +34,10 @@ type VerifyRequest struct {
 }
 
 type Claims struct {
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	UserID        uint   `json:"user_id"`
+	Email         string `json:"email"`
+	Role          string `json:"role"`
+	MFAEnrollOnly bool   `json:"mfa_enroll_only,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -50,4 +55,3 @@ type NewPassword struct {
 	UserID      uint   `json:"user_id" binding:"required"`
 	NewPassword string `json:"new_password" binding:"required"`
 }
-
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySent    = "sent"
+	WebhookDeliveryDead    = "dead"
+)
+
+// WebhookEndpoint receives signed tender and offer events. It belongs to a
+// user, or to an organization when OrganizationID is set. EventTypes is a
+// comma separated list of events.Type values.
+type WebhookEndpoint struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID         uint       `gorm:"not null;index" json:"user_id"`
+	OrganizationID *uint      `gorm:"index" json:"organization_id,omitempty"`
+	URL            string     `gorm:"type:varchar(500);not null" json:"url"`
+	Secret         string     `gorm:"type:varchar(64);not null" json:"-"`
+	EventTypes     string     `gorm:"type:text;not null" json:"-"`
+	CreatedAt      *time.Time `gorm:"autoCreateTime" json:"created_at"`
+	DeletedAt      *time.Time `gorm:"index" json:"-"`
+	Users          *Users     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+
+	EventTypeList []string `gorm:"-" json:"event_types"`
+}
+
+func (w *WebhookEndpoint) SplitEventTypes() []string {
+	if w.EventTypes == "" {
+		return nil
+	}
+	return strings.Split(w.EventTypes, ",")
+}
+
+// WebhookDelivery is one event sent to one endpoint. Deliveries that ran
+// out of retries are dead and can be redelivered by hand.
+type WebhookDelivery struct {
+	ID             uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	EndpointID     uint             `gorm:"not null;index" json:"endpoint_id"`
+	EventID        string           `gorm:"type:varchar(100);not null" json:"event_id"`
+	EventType      string           `gorm:"type:varchar(50);not null" json:"event_type"`
+	Payload        string           `gorm:"type:text;not null" json:"payload"`
+	Status         string           `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempts       int              `gorm:"not null;default:0" json:"attempts"`
+	ResponseStatus int              `json:"response_status,omitempty"`
+	LastError      string           `gorm:"type:text" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time       `json:"delivered_at"`
+	CreatedAt      *time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	Endpoint       *WebhookEndpoint `gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type WebhookEndpointRequest struct {
+	URL            string   `json:"url" binding:"required,url"`
+	EventTypes     []string `json:"event_types" binding:"required,min=1"`
+	OrganizationID *uint    `json:"organization_id,omitempty"`
+}
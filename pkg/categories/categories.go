@@ -0,0 +1,264 @@
+// Package categories imports the category tree and answers "which tenders
+// fall under these categories" including every descendant category.
+package categories
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"tender_management/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NormalizeCode strips whitespace and the CPV check digit ("45210000-2"
+// becomes "45210000").
+func NormalizeCode(code string) string {
+	code = strings.TrimSpace(code)
+	if i := strings.IndexByte(code, '-'); i > 0 {
+		code = code[:i]
+	}
+	return code
+}
+
+// ParseCSV reads rows with a "code,name[,parent_code]" header.
+func ParseCSV(r io.Reader) ([]models.CategoryRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty file")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	codeCol, ok := columns["code"]
+	if !ok {
+		return nil, errors.New(`missing "code" column`)
+	}
+	nameCol, ok := columns["name"]
+	if !ok {
+		return nil, errors.New(`missing "name" column`)
+	}
+	parentCol, hasParent := columns["parent_code"]
+
+	rows := make([]models.CategoryRow, 0, len(records)-1)
+	for n, record := range records[1:] {
+		if len(record) <= codeCol || len(record) <= nameCol {
+			return nil, fmt.Errorf("line %d: too few columns", n+2)
+		}
+		row := models.CategoryRow{Code: record[codeCol], Name: record[nameCol]}
+		if hasParent && len(record) > parentCol {
+			row.ParentCode = record[parentCol]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ParseJSON reads an array of models.CategoryRow.
+func ParseJSON(r io.Reader) ([]models.CategoryRow, error) {
+	var rows []models.CategoryRow
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Import creates or renames the categories in rows, links them to their
+// parents and rebuilds every path. It returns the number of rows imported.
+func Import(db *gorm.DB, rows []models.CategoryRow) (int, error) {
+	parents := make(map[string]string, len(rows))
+	cats := make([]models.Category, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+
+	for i, row := range rows {
+		code := NormalizeCode(row.Code)
+		name := strings.TrimSpace(row.Name)
+		if code == "" || name == "" {
+			return 0, fmt.Errorf("row %d: code and name are required", i+1)
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		cats = append(cats, models.Category{Code: code, Name: name, Path: "/" + code + "/"})
+		parents[code] = NormalizeCode(row.ParentCode)
+	}
+	if len(cats) == 0 {
+		return 0, errors.New("no categories to import")
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "code"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		}).CreateInBatches(&cats, 500).Error; err != nil {
+			return err
+		}
+
+		var all []models.Category
+		if err := tx.Find(&all).Error; err != nil {
+			return err
+		}
+
+		byCode := make(map[string]*models.Category, len(all))
+		stored := make(map[uint]models.Category, len(all))
+		for i := range all {
+			byCode[all[i].Code] = &all[i]
+			stored[all[i].ID] = all[i]
+		}
+
+		for code, parentCode := range parents {
+			cat := byCode[code]
+			if parentCode == "" {
+				parentCode = cpvParent(code, byCode)
+			}
+			cat.ParentID = nil
+			if parent, ok := byCode[parentCode]; ok && parentCode != code {
+				cat.ParentID = &parent.ID
+			}
+		}
+
+		return rebuildPaths(tx, all, stored)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(cats), nil
+}
+
+// cpvParent returns the closest existing ancestor of an 8 digit CPV code:
+// each level zeroes the last significant digit, down to the two digit
+// division.
+func cpvParent(code string, byCode map[string]*models.Category) string {
+	if len(code) != 8 || strings.Trim(code, "0123456789") != "" {
+		return ""
+	}
+
+	digits := []byte(code)
+	for {
+		last := strings.LastIndexFunc(string(digits), func(r rune) bool { return r != '0' })
+		if last < 2 {
+			return ""
+		}
+		digits[last] = '0'
+		if _, ok := byCode[string(digits)]; ok {
+			return string(digits)
+		}
+	}
+}
+
+// rebuildPaths recomputes the path of every category and saves those whose
+// parent or path differ from stored.
+func rebuildPaths(tx *gorm.DB, all []models.Category, stored map[uint]models.Category) error {
+	byID := make(map[uint]*models.Category, len(all))
+	for i := range all {
+		byID[all[i].ID] = &all[i]
+	}
+
+	paths := make(map[uint]string, len(all))
+	var pathOf func(cat *models.Category, depth int) (string, error)
+	pathOf = func(cat *models.Category, depth int) (string, error) {
+		if p, ok := paths[cat.ID]; ok {
+			return p, nil
+		}
+		if depth > len(all) {
+			return "", fmt.Errorf("category %s is its own ancestor", cat.Code)
+		}
+
+		prefix := "/"
+		if cat.ParentID != nil {
+			if parent, ok := byID[*cat.ParentID]; ok {
+				p, err := pathOf(parent, depth+1)
+				if err != nil {
+					return "", err
+				}
+				prefix = p
+			}
+		}
+		paths[cat.ID] = prefix + cat.Code + "/"
+		return paths[cat.ID], nil
+	}
+
+	for i := range all {
+		cat := &all[i]
+		path, err := pathOf(cat, 0)
+		if err != nil {
+			return err
+		}
+
+		old := stored[cat.ID]
+		if old.Path == path && sameParent(old.ParentID, cat.ParentID) {
+			continue
+		}
+		if err := tx.Model(&models.Category{}).Where("id = ?", cat.ID).
+			Updates(map[string]interface{}{"parent_id": cat.ParentID, "path": path}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Resolve loads the categories with the given codes and reports any code
+// that does not exist.
+func Resolve(db *gorm.DB, codes []string) ([]models.Category, error) {
+	normalized := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if code = NormalizeCode(code); code != "" {
+			normalized = append(normalized, code)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	var cats []models.Category
+	if err := db.Where("code IN ?", normalized).Find(&cats).Error; err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(cats))
+	for _, cat := range cats {
+		found[cat.Code] = true
+	}
+	for _, code := range normalized {
+		if !found[code] {
+			return nil, fmt.Errorf("unknown category %q", code)
+		}
+	}
+	return cats, nil
+}
+
+// TenderIDs returns a subquery selecting the tenders tagged with any of the
+// categories or their descendants.
+func TenderIDs(db *gorm.DB, cats []models.Category) *gorm.DB {
+	match := db.Where("1 = 0")
+	for _, cat := range cats {
+		match = match.Or("categories.path LIKE ?", cat.Path+"%")
+	}
+
+	return db.Table("tender_categories").
+		Select("tender_categories.tender_id").
+		Joins("JOIN categories ON categories.id = tender_categories.category_id").
+		Where(match)
+}
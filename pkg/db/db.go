@@ -1,30 +1,67 @@
 package db
 
 import (
+	"errors"
 	"fmt"
-	"tender_management/config"
-	"tender_management/models"
 	"log"
+	"tender_management/config"
+	"tender_management/pkg/migrate"
+	"tender_management/pkg/search"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func ConnectDB(cfg config.Config) (*gorm.DB, error) {
+// Open connects to the database without touching its schema.
+func Open(cfg config.Config) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+}
+
+// ConnectDB connects to the database and makes sure its schema is the one
+// this binary expects, applying pending migrations if MIGRATE_ON_START
+// allows it. It refuses to run against a newer schema: the error then
+// wraps migrate.ErrNewerSchema. The connection is closed on any error.
+func ConnectDB(cfg config.Config) (*gorm.DB, error) {
+	db, err := Open(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := db.AutoMigrate(&models.Users{}, &models.Tenders{}, &models.Offers{}); err != nil {
-		log.Fatal("Error Migratilon")
+	if err := prepare(db, cfg); err != nil {
+		if sqlDB, dbErr := db.DB(); dbErr == nil {
+			sqlDB.Close()
+		}
+		return nil, err
 	}
 
-
 	log.Println("Connected database... ")
 	return db, nil
 }
+
+func prepare(db *gorm.DB, cfg config.Config) error {
+	if cfg.MigrateOnStart {
+		applied, err := migrate.Up(db)
+		if err != nil {
+			return fmt.Errorf("migrating database: %w", err)
+		}
+		for _, m := range applied {
+			log.Printf("Applied migration %04d_%s\n", m.Version, m.Name)
+		}
+	}
+
+	if err := migrate.Check(db); err != nil {
+		if errors.Is(err, migrate.ErrNewerSchema) {
+			return fmt.Errorf("refusing to start: %w", err)
+		}
+		return fmt.Errorf("checking database schema: %w", err)
+	}
+
+	if err := search.RefreshMissing(db); err != nil {
+		return fmt.Errorf("indexing tenders: %w", err)
+	}
+	return nil
+}
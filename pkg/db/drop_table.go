@@ -1,6 +1,13 @@
 package db
 
+import (
+	"tender_management/pkg/migrate"
 
-func DropTables() {
+	"gorm.io/gorm"
+)
 
-} 
\ No newline at end of file
+// DropTables reverts every applied migration, leaving an empty schema.
+func DropTables(db *gorm.DB) error {
+	_, err := migrate.Down(db, 0)
+	return err
+}
@@ -0,0 +1,213 @@
+// Package evaluation scores and ranks the offers of a tender against its
+// weighted criteria and lays them out as a comparison matrix.
+package evaluation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"tender_management/models"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Known lists the supported criteria in the order they are shown.
+var Known = []string{models.CriterionPrice, models.CriterionDelivery, models.CriterionExperience}
+
+// DefaultCriteria apply to tenders that did not set any.
+var DefaultCriteria = []models.TenderCriterion{{Criterion: models.CriterionPrice, Weight: 100}}
+
+// Criteria validates the weights of a tender request. Zero weights are
+// dropped; an empty map means the defaults.
+func Criteria(weights map[string]float64) ([]models.TenderCriterion, error) {
+	var criteria []models.TenderCriterion
+	for name, weight := range weights {
+		if !isKnown(name) {
+			return nil, fmt.Errorf("unknown criterion %q, use price, delivery or experience", name)
+		}
+		if weight < 0 || weight > 100 {
+			return nil, fmt.Errorf("weight of %q must be between 0 and 100", name)
+		}
+		if weight > 0 {
+			criteria = append(criteria, models.TenderCriterion{Criterion: name, Weight: weight})
+		}
+	}
+	if len(weights) > 0 && len(criteria) == 0 {
+		return nil, fmt.Errorf("at least one criterion needs a positive weight")
+	}
+
+	sort.Slice(criteria, func(i, j int) bool {
+		return indexOf(criteria[i].Criterion) < indexOf(criteria[j].Criterion)
+	})
+	return criteria, nil
+}
+
+// Compare scores every live offer on tender and ranks them, best first, on
+// their normalized prices. tender.Criteria must be loaded.
+func Compare(db *gorm.DB, tender models.Tenders) (models.TenderComparison, error) {
+	criteria := tender.Criteria
+	if len(criteria) == 0 {
+		criteria = DefaultCriteria
+	}
+
+	var offers []models.Offers
+	if err := db.Where("tender_id = ? AND deleted_at IS NULL", tender.ID).Find(&offers).Error; err != nil {
+		return models.TenderComparison{}, err
+	}
+
+	experience, err := yearsInBusiness(db, offers)
+	if err != nil {
+		return models.TenderComparison{}, err
+	}
+
+	rows := make([]models.OfferComparison, len(offers))
+	for i, offer := range offers {
+		rows[i] = models.OfferComparison{
+			OfferID:         offer.ID,
+			ContractorID:    offer.ContractorID,
+			OrganizationID:  offer.OrganizationID,
+			Price:           offer.Price,
+			Currency:        offer.Currency,
+			NormalizedPrice: offer.NormalizedPrice,
+			ExchangeRate:    offer.ExchangeRate,
+			RateDate:        offer.RateDate,
+			Deviation:       offer.NormalizedPrice.Sub(tender.Budget),
+			DeliveryTime:    offer.DeliveryTime,
+			DeliveryDays:    deliveryDays(tender, offer),
+			Scores:          make(map[string]float64, len(criteria)),
+		}
+		if tender.Budget.IsPositive() {
+			rows[i].DeviationPct = offer.NormalizedPrice.Sub(tender.Budget).Div(tender.Budget).Mul(hundred).Round(2).InexactFloat64()
+		}
+	}
+
+	score(rows, criteria, experience)
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		switch {
+		case a.Total != b.Total:
+			return a.Total > b.Total
+		case !a.NormalizedPrice.Equal(b.NormalizedPrice):
+			return a.NormalizedPrice.LessThan(b.NormalizedPrice)
+		case a.DeliveryDays != b.DeliveryDays:
+			return a.DeliveryDays < b.DeliveryDays
+		}
+		return a.OfferID < b.OfferID
+	})
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+
+	return models.TenderComparison{
+		TenderID: tender.ID,
+		Title:    tender.Title,
+		Budget:   tender.Budget,
+		Currency: tender.Currency,
+		Deadline: tender.Deadline,
+		Criteria: criteria,
+		Offers:   rows,
+	}, nil
+}
+
+// score fills in the criterion scores and weighted totals. Each criterion
+// gives the best value 100 and the others a share of it: lowest normalized
+// price over normalized price, fewest delivery days over delivery days, years in business over
+// the most years.
+func score(rows []models.OfferComparison, criteria []models.TenderCriterion, experience map[uint]int) {
+	if len(rows) == 0 {
+		return
+	}
+
+	minPrice, minDays, maxYears := rows[0].NormalizedPrice, math.MaxInt, 0
+	for _, row := range rows {
+		minPrice = decimal.Min(minPrice, row.NormalizedPrice)
+		minDays = min(minDays, max(row.DeliveryDays, 1))
+		maxYears = max(maxYears, experience[row.ContractorID])
+	}
+
+	var totalWeight float64
+	for _, c := range criteria {
+		totalWeight += c.Weight
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		var total float64
+		for _, c := range criteria {
+			var s float64
+			switch c.Criterion {
+			case models.CriterionPrice:
+				s = 100
+				if row.NormalizedPrice.IsPositive() {
+					s = minPrice.Div(row.NormalizedPrice).Mul(hundred).InexactFloat64()
+				}
+			case models.CriterionDelivery:
+				s = float64(minDays) / float64(max(row.DeliveryDays, 1)) * 100
+			case models.CriterionExperience:
+				s = 100
+				if maxYears > 0 {
+					s = float64(experience[row.ContractorID]) / float64(maxYears) * 100
+				}
+			}
+			row.Scores[c.Criterion] = round(s)
+			total += s * c.Weight
+		}
+		if totalWeight > 0 {
+			row.Total = round(total / totalWeight)
+		}
+	}
+}
+
+func yearsInBusiness(db *gorm.DB, offers []models.Offers) (map[uint]int, error) {
+	ids := make([]uint, 0, len(offers))
+	for _, offer := range offers {
+		ids = append(ids, offer.ContractorID)
+	}
+
+	var profiles []models.ContractorProfile
+	if len(ids) > 0 {
+		if err := db.Select("user_id, years_in_business").Where("user_id IN ?", ids).Find(&profiles).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	years := make(map[uint]int, len(profiles))
+	for _, p := range profiles {
+		years[p.UserID] = p.YearsInBusiness
+	}
+	return years, nil
+}
+
+// deliveryDays counts whole days, rounded up, from the tender deadline to
+// the promised delivery. Deliveries before the deadline count as zero.
+func deliveryDays(tender models.Tenders, offer models.Offers) int {
+	if tender.Deadline == nil || offer.DeliveryTime == nil {
+		return 0
+	}
+	hours := offer.DeliveryTime.Sub(*tender.Deadline).Hours()
+	if hours <= 0 {
+		return 0
+	}
+	return int(math.Ceil(hours / 24))
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func isKnown(name string) bool {
+	return indexOf(name) >= 0
+}
+
+func indexOf(name string) int {
+	for i, known := range Known {
+		if known == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,143 @@
+package evaluation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"tender_management/constants"
+	"tender_management/models"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/xuri/excelize/v2"
+)
+
+const sheetName = "Comparison"
+
+// Header is the first row of an exported comparison: the fixed columns,
+// then one score column per criterion, then the total.
+func Header(cmp models.TenderComparison) []string {
+	header := []string{"Rank", "Offer ID", "Contractor ID", "Price", "Currency", "Exchange rate", "Rate date",
+		"Normalized price", "Deviation from budget", "Deviation %", "Delivery time", "Delivery days"}
+	for _, c := range cmp.Criteria {
+		header = append(header, fmt.Sprintf("%s score (weight %g)", c.Criterion, c.Weight))
+	}
+	return append(header, "Total score")
+}
+
+// Rows are the exported offers, numbers kept as numbers for spreadsheets.
+// Amounts are decimals; WriteXLSX stores them as the nearest number. The
+// exchange rate stays text so that it keeps every stored digit.
+func Rows(cmp models.TenderComparison) [][]interface{} {
+	rows := make([][]interface{}, 0, len(cmp.Offers))
+	for _, o := range cmp.Offers {
+		delivery := ""
+		if o.DeliveryTime != nil {
+			delivery = o.DeliveryTime.Format(constants.Layout)
+		}
+
+		rateDate := ""
+		if o.RateDate != nil {
+			rateDate = o.RateDate.Format(time.DateOnly)
+		}
+
+		row := []interface{}{o.Rank, o.OfferID, o.ContractorID, o.Price, o.Currency, o.ExchangeRate.String(), rateDate,
+			o.NormalizedPrice, o.Deviation, o.DeviationPct, delivery, o.DeliveryDays}
+		for _, c := range cmp.Criteria {
+			row = append(row, o.Scores[c.Criterion])
+		}
+		rows = append(rows, append(row, o.Total))
+	}
+	return rows
+}
+
+// WriteCSV writes the comparison matrix as CSV.
+func WriteCSV(w io.Writer, cmp models.TenderComparison) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(Header(cmp)); err != nil {
+		return err
+	}
+
+	for _, row := range Rows(cmp) {
+		record := make([]string, len(row))
+		for i, v := range row {
+			switch v := v.(type) {
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', 2, 64)
+			case decimal.Decimal:
+				record[i] = v.StringFixed(2)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// WriteXLSX writes the comparison matrix as a one sheet workbook with a
+// bold, frozen header row.
+func WriteXLSX(w io.Writer, cmp models.TenderComparison) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		return err
+	}
+
+	header := Header(cmp)
+	headerRow := make([]interface{}, len(header))
+	for i, h := range header {
+		headerRow[i] = h
+	}
+	if err := f.SetSheetRow(sheetName, "A1", &headerRow); err != nil {
+		return err
+	}
+
+	for i, row := range Rows(cmp) {
+		for j, v := range row {
+			if amount, ok := v.(decimal.Decimal); ok {
+				row[j] = amount.InexactFloat64()
+			}
+		}
+
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(sheetName, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	last, err := excelize.CoordinatesToCellName(len(header), 1)
+	if err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheetName, "A1", last, bold); err != nil {
+		return err
+	}
+
+	lastCol, err := excelize.ColumnNumberToName(len(header))
+	if err != nil {
+		return err
+	}
+	if err := f.SetColWidth(sheetName, "A", lastCol, 18); err != nil {
+		return err
+	}
+	if err := f.SetPanes(sheetName, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+
+	_, err = f.WriteTo(w)
+	return err
+}
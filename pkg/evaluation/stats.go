@@ -0,0 +1,104 @@
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"tender_management/models"
+
+	"gorm.io/gorm"
+)
+
+// statsOffers is the per-offer input of the statistics: window functions
+// attach each tender's extremes to its offers so the outer aggregate can
+// pick the offers at them in the same pass, each offer carried whole as
+// JSON. Its rate date is a date column, written as a timestamp so that it
+// decodes into time.Time. Prices are the normalized ones, so offers in
+// other currencies compare in the tender currency.
+const statsOffers = `offers.id, offers.tender_id, offers.normalized_price AS price, offers.delivery_time,
+	to_jsonb(offers) || jsonb_build_object('rate_date', to_char(offers.rate_date, 'YYYY-MM-DD"T00:00:00Z"')) AS offer,
+	tenders.budget, tenders.currency,
+	EXTRACT(EPOCH FROM offers.delivery_time - tenders.deadline) / 86400 AS delivery_days,
+	MIN(offers.normalized_price) OVER (PARTITION BY offers.tender_id) AS min_price,
+	MAX(offers.normalized_price) OVER (PARTITION BY offers.tender_id) AS max_price,
+	MIN(offers.delivery_time) OVER (PARTITION BY offers.tender_id) AS min_delivery,
+	MAX(offers.delivery_time) OVER (PARTITION BY offers.tender_id) AS max_delivery`
+
+// statsColumns aggregates per tender. The median price averages the middle
+// prices from both ends, which keeps it exact where percentile_cont would
+// go through double precision.
+const statsColumns = `o.tender_id,
+	MIN(o.budget) AS budget,
+	MIN(o.currency) AS currency,
+	COUNT(*) AS offer_count,
+	MIN(o.price) AS min_price,
+	MAX(o.price) AS max_price,
+	ROUND(AVG(o.price), 2) AS mean_price,
+	ROUND((percentile_disc(0.5) WITHIN GROUP (ORDER BY o.price) + percentile_disc(0.5) WITHIN GROUP (ORDER BY o.price DESC)) / 2, 2) AS median_price,
+	MIN(o.price - o.budget) AS min_deviation,
+	MAX(o.price - o.budget) AS max_deviation,
+	ROUND(AVG((o.price - o.budget) / NULLIF(o.budget, 0) * 100), 2) AS mean_deviation_pct,
+	COUNT(*) FILTER (WHERE o.price < o.budget) AS under_budget,
+	COUNT(*) FILTER (WHERE o.price > o.budget) AS over_budget,
+	MIN(o.delivery_time) AS min_delivery,
+	MAX(o.delivery_time) AS max_delivery,
+	percentile_disc(0.5) WITHIN GROUP (ORDER BY o.delivery_time) AS median_delivery,
+	COUNT(*) FILTER (WHERE o.delivery_days <= 0) AS delivery_by_deadline,
+	COUNT(*) FILTER (WHERE o.delivery_days > 0 AND o.delivery_days <= 7) AS delivery_week,
+	COUNT(*) FILTER (WHERE o.delivery_days > 7 AND o.delivery_days <= 30) AS delivery_month,
+	COUNT(*) FILTER (WHERE o.delivery_days > 30 AND o.delivery_days <= 90) AS delivery_quarter,
+	COUNT(*) FILTER (WHERE o.delivery_days > 90) AS delivery_later,
+	jsonb_agg(o.offer ORDER BY o.id) FILTER (WHERE o.price = o.min_price) AS cheapest_offers,
+	jsonb_agg(o.offer ORDER BY o.id) FILTER (WHERE o.price = o.max_price) AS dearest_offers,
+	jsonb_agg(o.offer ORDER BY o.id) FILTER (WHERE o.delivery_time = o.min_delivery) AS fastest_offers,
+	jsonb_agg(o.offer ORDER BY o.id) FILTER (WHERE o.delivery_time = o.max_delivery) AS slowest_offers`
+
+// Stats computes the statistics of every tender with offers in the offers
+// query, which must select from offers, e.g. a filtered
+// db.Model(&models.Offers{}), in a single query. Statistics and extremes
+// never mix tenders. Tenders without matching offers are left out.
+func Stats(db *gorm.DB, offers *gorm.DB) ([]models.TenderStats, error) {
+	scoped := offers.
+		Select(statsOffers).
+		Joins("JOIN tenders ON tenders.id = offers.tender_id").
+		Where("offers.deleted_at IS NULL AND tenders.deleted_at IS NULL")
+
+	var stats []models.TenderStats
+	if err := db.Table("(?) AS o", scoped).
+		Select(statsColumns).
+		Group("o.tender_id").
+		Order("o.tender_id").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range stats {
+		if err := decodeExtremes(&stats[i]); err != nil {
+			return nil, err
+		}
+	}
+	return stats, nil
+}
+
+// decodeExtremes reads the offers the statistics name at each extreme.
+func decodeExtremes(s *models.TenderStats) error {
+	lists := []struct {
+		raw    string
+		offers *[]models.Offers
+	}{
+		{s.CheapestOffers, &s.Extremes.Cheapest},
+		{s.DearestOffers, &s.Extremes.Dearest},
+		{s.FastestOffers, &s.Extremes.Fastest},
+		{s.SlowestOffers, &s.Extremes.Slowest},
+	}
+
+	for _, list := range lists {
+		*list.offers = []models.Offers{}
+		if list.raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(list.raw), list.offers); err != nil {
+			return fmt.Errorf("decode extreme offers of tender %d: %w", s.TenderID, err)
+		}
+	}
+	return nil
+}
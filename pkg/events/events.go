@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type Type string
+
+const (
+	TenderPublished    Type = "tender.published"
+	OfferSubmitted     Type = "offer.submitted"
+	OfferUpdated       Type = "offer.updated"
+	TenderAmended      Type = "tender.amended"
+	TenderDeadlineSoon Type = "tender.deadline_soon"
+	TenderAwarded      Type = "tender.awarded"
+	TenderCancelled    Type = "tender.cancelled"
+)
+
+// Types lists every event type, in the order they are documented.
+var Types = []Type{
+	TenderPublished,
+	OfferSubmitted,
+	OfferUpdated,
+	TenderAmended,
+	TenderDeadlineSoon,
+	TenderAwarded,
+	TenderCancelled,
+}
+
+// Event describes something that happened to a tender or an offer.
+// Subscribers load whatever else they need from the database. ID is unique
+// per event and stays the same when the outbox redelivers it.
+type Event struct {
+	ID         string    `json:"id,omitempty"`
+	Type       Type      `json:"type"`
+	TenderID   uint      `json:"tender_id"`
+	OfferID    uint      `json:"offer_id,omitempty"`
+	ActorID    uint      `json:"actor_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type Handler func(ctx context.Context, e Event) error
+
+// Bus calls the subscribers of an event synchronously. Events reach it only
+// through the outbox relay; publish them with storage.Store.Publish inside
+// the transaction of the change instead.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[Type][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[Type][]Handler),
+	}
+}
+
+func (b *Bus) Subscribe(t Type, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[t] = append(b.handlers[t], h)
+}
+
+// Dispatch calls every subscriber of the event and returns their errors
+// joined, so that the outbox relay can retry the event.
+func (b *Bus) Dispatch(ctx context.Context, e Event) error {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[e.Type]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
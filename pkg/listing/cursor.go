@@ -0,0 +1,219 @@
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// cursor is the position a keyset page starts after (or, going backward,
+// before). Values are the sort keys of that row in key order, nil where the
+// key is NULL; Sort ties the cursor to the order it was issued for.
+type cursor struct {
+	Sort     string    `json:"s"`
+	Backward bool      `json:"b,omitempty"`
+	Values   []*string `json:"v"`
+
+	args []interface{}
+}
+
+// key is one ORDER BY term. Keyset paging needs a total order, so the ID is
+// always the last key. NULLs sort last whatever the direction.
+type key struct {
+	field  string
+	column string
+	kind   Kind
+	desc   bool
+}
+
+func (p Params) keys() []key {
+	keys := make([]key, 0, len(p.Sort)+1)
+	hasID := false
+	for _, s := range p.Sort {
+		field := p.resource.Fields[s.Field]
+		keys = append(keys, key{field: s.Field, column: field.Column, kind: field.Kind, desc: s.Desc})
+		hasID = hasID || field.Column == p.resource.IDColumn
+	}
+
+	if !hasID && p.resource.IDColumn != "" {
+		for name, field := range p.resource.Fields {
+			if field.Column == p.resource.IDColumn {
+				keys = append(keys, key{field: name, column: field.Column, kind: field.Kind})
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// sortKey is the sort in parameter syntax, e.g. "-deadline,budget".
+func (p Params) sortKey() string {
+	names := make([]string, len(p.Sort))
+	for i, s := range p.Sort {
+		names[i] = s.Field
+		if s.Desc {
+			names[i] = "-" + s.Field
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// order adds ORDER BY for every key, with NULLs last, all reversed when
+// paging backward.
+func (p Params) order(db *gorm.DB, reverse bool) *gorm.DB {
+	for _, k := range p.keys() {
+		direction := " ASC"
+		if k.desc != reverse {
+			direction = " DESC"
+		}
+		nulls := " NULLS LAST"
+		if reverse {
+			nulls = " NULLS FIRST"
+		}
+		db = db.Order(k.column + direction + nulls)
+	}
+	return db
+}
+
+// seek is the condition selecting the rows after values in key order, or
+// before them when backward:
+//
+//	(k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+//
+// with < instead of > for descending keys. As NULLs sort last, the rows
+// after a value include those where the key is NULL, none follow a NULL
+// key, and every non-NULL key precedes it.
+func (p Params) seek(values []interface{}, backward bool) (string, []interface{}) {
+	keys := p.keys()
+	var terms []string
+	var args []interface{}
+
+	for i, k := range keys {
+		var parts []string
+		var partArgs []interface{}
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				parts = append(parts, keys[j].column+" IS NULL")
+				continue
+			}
+			parts = append(parts, keys[j].column+" = ?")
+			partArgs = append(partArgs, values[j])
+		}
+
+		switch {
+		case values[i] == nil && backward:
+			parts = append(parts, k.column+" IS NOT NULL")
+		case values[i] == nil:
+			// Nothing sorts after NULL.
+			continue
+		default:
+			op := " > ?"
+			if k.desc != backward {
+				op = " < ?"
+			}
+			if backward || k.column == p.resource.IDColumn {
+				parts = append(parts, k.column+op)
+			} else {
+				parts = append(parts, "("+k.column+op+" OR "+k.column+" IS NULL)")
+			}
+			partArgs = append(partArgs, values[i])
+		}
+
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+		args = append(args, partArgs...)
+	}
+	if len(terms) == 0 {
+		return "1 = 0", nil
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+// cursorAt encodes the position of item, one element of the fetched slice.
+func (p Params) cursorAt(item interface{}, backward bool) (string, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+	var row map[string]json.RawMessage
+	if err := json.Unmarshal(data, &row); err != nil {
+		return "", err
+	}
+
+	c := cursor{Sort: p.sortKey(), Backward: backward}
+	for _, k := range p.keys() {
+		raw, ok := row[k.field]
+		if !ok || string(raw) == "null" {
+			c.Values = append(c.Values, nil)
+			continue
+		}
+
+		value := string(raw)
+		if strings.HasPrefix(value, `"`) {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return "", err
+			}
+		}
+		c.Values = append(c.Values, &value)
+	}
+
+	data, err = json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor reads a cursor issued for the same sort and converts its
+// values to the key types.
+func (p Params) decodeCursor(token string) (*cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errInvalidCursor
+	}
+	if c.Sort != p.sortKey() {
+		return nil, errors.New("cursor was issued for a different sort, start again without one")
+	}
+
+	keys := p.keys()
+	if len(c.Values) != len(keys) {
+		return nil, errInvalidCursor
+	}
+
+	for i, k := range keys {
+		if c.Values[i] == nil {
+			c.args = append(c.args, nil)
+			continue
+		}
+
+		raw := *c.Values[i]
+		var value interface{} = raw
+		switch k.kind {
+		case Number:
+			value, err = strconv.ParseFloat(raw, 64)
+		case Decimal:
+			value, err = decimal.NewFromString(raw)
+		case Time:
+			value, err = time.Parse(time.RFC3339Nano, raw)
+		case Bool:
+			value, err = strconv.ParseBool(raw)
+		}
+		if err != nil {
+			return nil, errInvalidCursor
+		}
+		c.args = append(c.args, value)
+	}
+	return &c, nil
+}
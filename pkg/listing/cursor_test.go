@@ -0,0 +1,156 @@
+package listing
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type item struct {
+	ID    uint `json:"id"`
+	Score *int `json:"score"`
+}
+
+var itemListing = Resource{
+	IDColumn:    "items.id",
+	DefaultSort: "id",
+	Fields: map[string]Field{
+		"id":    {Column: "items.id", Kind: Number, Sortable: true},
+		"score": {Column: "items.score", Kind: Number, Sortable: true},
+	},
+}
+
+// openItems stores items 1 to 7, three of them without a score.
+func openItems(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "listing.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	if err := db.AutoMigrate(&item{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	score := func(n int) *int { return &n }
+	items := []item{
+		{ID: 1, Score: score(20)},
+		{ID: 2},
+		{ID: 3, Score: score(10)},
+		{ID: 4},
+		{ID: 5, Score: score(20)},
+		{ID: 6, Score: score(30)},
+		{ID: 7},
+	}
+	if err := db.Create(&items).Error; err != nil {
+		t.Fatalf("create items: %v", err)
+	}
+	return db
+}
+
+// page fetches one keyset page the way the list endpoints do.
+func page(t *testing.T, db *gorm.DB, sort, cursor string) ([]uint, Meta) {
+	t.Helper()
+
+	p, err := Parse(url.Values{"sort": {sort}, "cursor": {cursor}, "pageSize": {"2"}}, itemListing)
+	if err != nil {
+		t.Fatalf("Parse(sort=%s): %v", sort, err)
+	}
+
+	var items []item
+	if err := p.Paginate(p.Filter(db.Model(&item{}))).Find(&items).Error; err != nil {
+		t.Fatalf("sort=%s: %v", sort, err)
+	}
+	meta, err := p.Finish(&items, nil)
+	if err != nil {
+		t.Fatalf("sort=%s: Finish: %v", sort, err)
+	}
+
+	ids := make([]uint, len(items))
+	for i, it := range items {
+		ids[i] = it.ID
+	}
+	return ids, meta
+}
+
+func TestKeysetPagingWithNullSortKeys(t *testing.T) {
+	db := openItems(t)
+
+	tests := []struct {
+		sort string
+		want []uint
+	}{
+		{"score", []uint{3, 1, 5, 6, 2, 4, 7}},
+		{"-score", []uint{6, 1, 5, 3, 2, 4, 7}},
+		{"score,-id", []uint{3, 5, 1, 6, 7, 4, 2}},
+		{"-score,-id", []uint{6, 5, 1, 3, 7, 4, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			var forward []string
+			var got []uint
+			cursor := ""
+			for i := 0; ; i++ {
+				if i > len(tt.want) {
+					t.Fatal("paging did not end")
+				}
+				forward = append(forward, cursor)
+
+				ids, meta := page(t, db, tt.sort, cursor)
+				got = append(got, ids...)
+				if meta.NextCursor == "" {
+					break
+				}
+				cursor = meta.NextCursor
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("forward: got %v, want %v", got, tt.want)
+			}
+
+			// Going back from the last page visits the same pages in
+			// reverse.
+			var back []uint
+			ids, meta := page(t, db, tt.sort, forward[len(forward)-1])
+			back = append(ids, back...)
+			for meta.PrevCursor != "" {
+				ids, meta = page(t, db, tt.sort, meta.PrevCursor)
+				back = append(ids, back...)
+			}
+			if !reflect.DeepEqual(back, tt.want) {
+				t.Fatalf("backward: got %v, want %v", back, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeekAfterNullKey(t *testing.T) {
+	p, err := Parse(url.Values{"sort": {"score"}}, itemListing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	where, args := p.seek([]interface{}{nil, 4.0}, false)
+	if want := "((items.score IS NULL AND items.id > ?))"; where != want {
+		t.Errorf("forward: %s, want %s", where, want)
+	}
+	if fmt.Sprint(args) != "[4]" {
+		t.Errorf("forward args: %v", args)
+	}
+
+	where, args = p.seek([]interface{}{nil, 4.0}, true)
+	if want := "((items.score IS NOT NULL) OR (items.score IS NULL AND items.id < ?))"; where != want {
+		t.Errorf("backward: %s, want %s", where, want)
+	}
+	if fmt.Sprint(args) != "[4]" {
+		t.Errorf("backward args: %v", args)
+	}
+}
@@ -0,0 +1,432 @@
+// Package listing parses the query parameters shared by list endpoints:
+//
+//	?filter[budget][gte]=1000&filter[state]=open&sort=-deadline,price&fields=id,title&page=2&pageSize=20
+//
+// Every resource declares which fields may be filtered, sorted and selected.
+// Field names are the JSON names clients see; they are mapped to columns
+// here, so user input never reaches SQL except as bound values.
+//
+// Lists are paged by offset (page, pageSize) or, when a cursor parameter is
+// present, by keyset: cursor= starts at the first page and each response
+// carries opaque next and previous cursors. Keyset pages stay fast and do
+// not skip or repeat rows when rows are inserted between requests. Totals
+// are then only counted with total=true. Rows whose sort key is NULL come
+// last, in either direction.
+package listing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"tender_management/constants"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Kind is the type of a field's values.
+type Kind int
+
+const (
+	String Kind = iota
+	Number
+	Time
+	Bool
+	// Decimal is an exact number such as an amount of money; it is
+	// compared without going through float64.
+	Decimal
+)
+
+// Operators and the kinds they apply to.
+var operators = map[string]struct {
+	sql   string
+	kinds []Kind
+}{
+	"eq":       {"= ?", []Kind{String, Number, Time, Bool, Decimal}},
+	"ne":       {"<> ?", []Kind{String, Number, Time, Bool, Decimal}},
+	"gt":       {"> ?", []Kind{Number, Time, Decimal}},
+	"gte":      {">= ?", []Kind{Number, Time, Decimal}},
+	"lt":       {"< ?", []Kind{Number, Time, Decimal}},
+	"lte":      {"<= ?", []Kind{Number, Time, Decimal}},
+	"in":       {"IN ?", []Kind{String, Number, Decimal}},
+	"contains": {"ILIKE ?", []Kind{String}},
+}
+
+// Field describes one field of a resource. A field without a Column is a
+// relation: it can be selected with fields= but not filtered or sorted.
+type Field struct {
+	Column     string
+	Kind       Kind
+	Filterable bool
+	Sortable   bool
+}
+
+// Resource is the whitelist of a list endpoint. DefaultSort uses the sort
+// parameter syntax and applies when the request has none.
+type Resource struct {
+	Fields      map[string]Field
+	DefaultSort string
+	// IDColumn breaks ties so pages are stable, and is always selected.
+	IDColumn string
+}
+
+// Filter is one validated condition.
+type Filter struct {
+	Field string
+	Op    string
+	Value interface{}
+}
+
+// Sort is one validated ordering.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Params is a parsed and validated list request.
+type Params struct {
+	Filters  []Filter
+	Sort     []Sort
+	Fields   []string
+	Page     int
+	PageSize int
+
+	resource  Resource
+	keyset    bool
+	after     *cursor
+	withTotal bool
+}
+
+// Meta is the pagination block every list response carries. Offset pages
+// have CurrentPage and TotalPages, keyset pages the cursors; TotalRecords is
+// nil when it was not counted.
+type Meta struct {
+	TotalRecords *int64 `json:"totalRecords,omitempty"`
+	CurrentPage  int    `json:"currentPage,omitempty"`
+	PageSize     int    `json:"pageSize"`
+	TotalPages   int    `json:"totalPages,omitempty"`
+	NextCursor   string `json:"nextCursor,omitempty"`
+	PrevCursor   string `json:"prevCursor,omitempty"`
+}
+
+var filterParam = regexp.MustCompile(`^filter\[(\w+)\](?:\[(\w+)\])?$`)
+
+// Parse validates query against the resource whitelist. The error message
+// is safe to show to the client.
+func Parse(query url.Values, r Resource) (Params, error) {
+	p := Params{resource: r}
+
+	for key, values := range query {
+		m := filterParam.FindStringSubmatch(key)
+		if m == nil {
+			if strings.HasPrefix(key, "filter") {
+				return Params{}, fmt.Errorf("invalid filter parameter %q, use filter[field][op]=value", key)
+			}
+			continue
+		}
+
+		name, op := m[1], m[2]
+		if op == "" {
+			op = "eq"
+		}
+		field, ok := r.Fields[name]
+		if !ok || !field.Filterable {
+			return Params{}, fmt.Errorf("cannot filter by %q", name)
+		}
+		operator, ok := operators[op]
+		if !ok || !allows(operator.kinds, field.Kind) {
+			return Params{}, fmt.Errorf("operator %q is not supported for %q", op, name)
+		}
+
+		for _, raw := range values {
+			value, err := parseValue(field.Kind, op, raw)
+			if err != nil {
+				return Params{}, fmt.Errorf("invalid value for filter[%s][%s]: %v", name, op, err)
+			}
+			p.Filters = append(p.Filters, Filter{Field: name, Op: op, Value: value})
+		}
+	}
+
+	sort := query.Get("sort")
+	if sort == "" {
+		sort = r.DefaultSort
+	}
+	for _, name := range splitList(sort) {
+		s := Sort{Field: strings.TrimPrefix(name, "-"), Desc: strings.HasPrefix(name, "-")}
+		if field, ok := r.Fields[s.Field]; !ok || !field.Sortable {
+			return Params{}, fmt.Errorf("cannot sort by %q", s.Field)
+		}
+		p.Sort = append(p.Sort, s)
+	}
+
+	for _, name := range splitList(query.Get("fields")) {
+		if _, ok := r.Fields[name]; !ok {
+			return Params{}, fmt.Errorf("unknown field %q", name)
+		}
+		p.Fields = append(p.Fields, name)
+	}
+
+	var err error
+	if tokens, ok := query["cursor"]; ok {
+		if query.Get("page") != "" {
+			return Params{}, fmt.Errorf("page cannot be combined with cursor")
+		}
+		p.keyset = true
+		if tokens[0] != "" {
+			if p.after, err = p.decodeCursor(tokens[0]); err != nil {
+				return Params{}, err
+			}
+		}
+		if total := query.Get("total"); total != "" {
+			if p.withTotal, err = strconv.ParseBool(total); err != nil {
+				return Params{}, fmt.Errorf("invalid total: must be true or false")
+			}
+		}
+	}
+
+	if p.Page, err = positiveInt(query.Get("page"), 1); err != nil {
+		return Params{}, fmt.Errorf("invalid page: %v", err)
+	}
+	if p.PageSize, err = positiveInt(query.Get("pageSize"), DefaultPageSize); err != nil {
+		return Params{}, fmt.Errorf("invalid pageSize: %v", err)
+	}
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+
+	return p, nil
+}
+
+// Filter adds the WHERE conditions. Use it for both the count and the page
+// query.
+func (p Params) Filter(db *gorm.DB) *gorm.DB {
+	for _, f := range p.Filters {
+		db = db.Where(p.resource.Fields[f.Field].Column+" "+operators[f.Op].sql, f.Value)
+	}
+	return db
+}
+
+// Keyset reports whether the request pages by cursor.
+func (p Params) Keyset() bool {
+	return p.keyset
+}
+
+// WantTotal reports whether the total number of matching rows should be
+// counted: always for offset pages, on request for keyset pages.
+func (p Params) WantTotal() bool {
+	return !p.keyset || p.withTotal
+}
+
+// Select restricts the query to the columns of the requested fields, plus
+// the ID and sort columns paging needs. It does nothing without fields.
+func (p Params) Select(db *gorm.DB) *gorm.DB {
+	if columns := p.columns(); len(columns) > 0 {
+		db = db.Select(columns)
+	}
+	return db
+}
+
+// Paginate orders the query and limits it to the requested page. Keyset
+// pages fetch one extra row to tell whether another page follows; Finish
+// removes it.
+func (p Params) Paginate(db *gorm.DB) *gorm.DB {
+	if !p.keyset {
+		return p.order(db, false).Limit(p.PageSize).Offset((p.Page - 1) * p.PageSize)
+	}
+
+	backward := p.after != nil && p.after.Backward
+	if p.after != nil {
+		where, args := p.seek(p.after.args, backward)
+		db = db.Where(where, args...)
+	}
+	return p.order(db, backward).Limit(p.PageSize + 1)
+}
+
+// Finish completes a page fetched with Paginate. items must point to the
+// slice the page was scanned into; totalRecords is nil when not counted.
+func (p Params) Finish(items interface{}, totalRecords *int64) (Meta, error) {
+	meta := Meta{TotalRecords: totalRecords, PageSize: p.PageSize}
+
+	if !p.keyset {
+		meta.CurrentPage = p.Page
+		if totalRecords != nil {
+			meta.TotalPages = int((*totalRecords + int64(p.PageSize) - 1) / int64(p.PageSize))
+		}
+		return meta, nil
+	}
+
+	slice := reflect.ValueOf(items).Elem()
+	more := slice.Len() > p.PageSize
+	if more {
+		slice.Set(slice.Slice(0, p.PageSize))
+	}
+
+	backward := p.after != nil && p.after.Backward
+	if backward {
+		swap := reflect.Swapper(slice.Interface())
+		for i, j := 0, slice.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+	if slice.Len() == 0 {
+		return meta, nil
+	}
+
+	// Going forward there is a next page if the extra row came back, and a
+	// previous one unless this is the first page; backward it is the
+	// other way round.
+	hasNext, hasPrev := more, p.after != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+
+	var err error
+	if hasNext {
+		if meta.NextCursor, err = p.cursorAt(slice.Index(slice.Len()-1).Interface(), false); err != nil {
+			return Meta{}, err
+		}
+	}
+	if hasPrev {
+		if meta.PrevCursor, err = p.cursorAt(slice.Index(0).Interface(), true); err != nil {
+			return Meta{}, err
+		}
+	}
+	return meta, nil
+}
+
+// Project reduces items, a slice of structs, to the requested fields. It
+// returns items unchanged when no fields were requested.
+func (p Params) Project(items interface{}) (interface{}, error) {
+	if len(p.Fields) == 0 {
+		return items, nil
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+
+	projected := make([]map[string]json.RawMessage, len(rows))
+	for i, row := range rows {
+		projected[i] = make(map[string]json.RawMessage, len(p.Fields))
+		for _, name := range p.Fields {
+			if value, ok := row[name]; ok {
+				projected[i][name] = value
+			}
+		}
+	}
+	return projected, nil
+}
+
+// columns are the columns behind the requested fields plus the ID, which
+// preloading relations needs, and the sort keys cursors are built from.
+func (p Params) columns() []string {
+	if len(p.Fields) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	columns := []string{}
+	add := func(column string) {
+		if column != "" && !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+
+	add(p.resource.IDColumn)
+	for _, name := range p.Fields {
+		add(p.resource.Fields[name].Column)
+	}
+	for _, s := range p.Sort {
+		add(p.resource.Fields[s.Field].Column)
+	}
+	return columns
+}
+
+func parseValue(kind Kind, op, raw string) (interface{}, error) {
+	if op == "in" {
+		parts := splitList(raw)
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("empty list")
+		}
+		values := make([]interface{}, 0, len(parts))
+		for _, part := range parts {
+			value, err := parseValue(kind, "eq", part)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	switch kind {
+	case Number:
+		return strconv.ParseFloat(raw, 64)
+	case Decimal:
+		return decimal.NewFromString(raw)
+	case Bool:
+		return strconv.ParseBool(raw)
+	case Time:
+		for _, layout := range []string{constants.Layout, time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, raw); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("use %q, RFC 3339 or YYYY-MM-DD", constants.Layout)
+	}
+
+	if op == "contains" {
+		return "%" + escapeLike(raw) + "%", nil
+	}
+	return raw, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func allows(kinds []Kind, kind Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func positiveInt(raw string, fallback int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("must be a positive integer")
+	}
+	return n, nil
+}
@@ -0,0 +1,253 @@
+package listing
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// widgetListing has a field of every kind, a field that cannot be filtered
+// or sorted and a relation.
+var widgetListing = Resource{
+	IDColumn:    "widgets.id",
+	DefaultSort: "-created_at",
+	Fields: map[string]Field{
+		"id":         {Column: "widgets.id", Kind: Number, Filterable: true, Sortable: true},
+		"title":      {Column: "widgets.title", Kind: String, Filterable: true, Sortable: true},
+		"price":      {Column: "widgets.price", Kind: Decimal, Filterable: true, Sortable: true},
+		"created_at": {Column: "widgets.created_at", Kind: Time, Filterable: true, Sortable: true},
+		"active":     {Column: "widgets.active", Kind: Bool, Filterable: true},
+		"notes":      {Column: "widgets.notes", Kind: String},
+		"parts":      {},
+	},
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"malformed filter", "filter=title", `invalid filter parameter "filter"`},
+		{"filter without field", "filter[]=x", `invalid filter parameter "filter[]"`},
+		{"unknown filter field", "filter[owner]=1", `cannot filter by "owner"`},
+		{"unfilterable field", "filter[notes]=x", `cannot filter by "notes"`},
+		{"relation filter", "filter[parts]=x", `cannot filter by "parts"`},
+		{"unknown operator", "filter[id][like]=1", `operator "like" is not supported for "id"`},
+		{"contains on a number", "filter[id][contains]=1", `operator "contains" is not supported for "id"`},
+		{"gt on a string", "filter[title][gt]=a", `operator "gt" is not supported for "title"`},
+		{"in on a time", "filter[created_at][in]=2024-01-01", `operator "in" is not supported for "created_at"`},
+		{"in on a bool", "filter[active][in]=true", `operator "in" is not supported for "active"`},
+		{"unknown sort field", "sort=owner", `cannot sort by "owner"`},
+		{"unsortable field", "sort=-active", `cannot sort by "active"`},
+		{"unknown selected field", "fields=id,owner", `unknown field "owner"`},
+		{"page with cursor", "cursor=&page=2", "page cannot be combined with cursor"},
+		{"zero page", "page=0", "invalid page"},
+		{"negative page size", "pageSize=-1", "invalid pageSize"},
+		{"bad total", "cursor=&total=maybe", "invalid total"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = Parse(query, widgetListing)
+			if err == nil {
+				t.Fatalf("Parse(%s) got no error, want %q", tt.query, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Parse(%s) error %q, want %q", tt.query, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	moment := time.Date(2024, 3, 1, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query string
+		want  Filter
+	}{
+		{"string", "filter[title]=Desk", Filter{"title", "eq", "Desk"}},
+		{"number", "filter[id][gte]=12", Filter{"id", "gte", float64(12)}},
+		{"decimal", "filter[price][lt]=1000.10", Filter{"price", "lt", decimal.RequireFromString("1000.10")}},
+		{"bool", "filter[active]=false", Filter{"active", "eq", false}},
+		{"time in the repo layout", "filter[created_at][gt]=2024-03-01 14:30:00", Filter{"created_at", "gt", moment}},
+		{"time in RFC 3339", "filter[created_at][gt]=2024-03-01T14:30:00Z", Filter{"created_at", "gt", moment}},
+		{"date", "filter[created_at][lte]=2024-03-01", Filter{"created_at", "lte", day}},
+		{"string list", "filter[title][in]=Desk, Chair,,", Filter{"title", "in", []interface{}{"Desk", "Chair"}}},
+		{"number list", "filter[id][in]=1,2", Filter{"id", "in", []interface{}{float64(1), float64(2)}}},
+		{"contains", "filter[title][contains]=desk", Filter{"title", "contains", "%desk%"}},
+		{"contains with wildcards", `filter[title][contains]=50%25_off%5C`, Filter{"title", "contains", `%50\%\_off\\%`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := Parse(query, widgetListing)
+			if err != nil {
+				t.Fatalf("Parse(%s): %v", tt.query, err)
+			}
+			if len(p.Filters) != 1 {
+				t.Fatalf("got filters %+v, want one", p.Filters)
+			}
+			got := p.Filters[0]
+			if d, ok := tt.want.Value.(decimal.Decimal); ok {
+				if v, ok := got.Value.(decimal.Decimal); !ok || !v.Equal(d) {
+					t.Errorf("got value %#v, want decimal %s", got.Value, d)
+				}
+				got.Value, tt.want.Value = nil, nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsValues(t *testing.T) {
+	for _, query := range []string{
+		"filter[id]=twelve",
+		"filter[price]=1,000",
+		"filter[active]=yes",
+		"filter[created_at]=01/03/2024",
+		"filter[id][in]=1,x",
+		"filter[title][in]=,",
+	} {
+		q, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Parse(q, widgetListing); err == nil || !strings.Contains(err.Error(), "invalid value for filter[") {
+			t.Errorf("Parse(%s) error %v, want an invalid value", query, err)
+		}
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	p, err := Parse(url.Values{"pageSize": {"500"}}, widgetListing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Page != 1 || p.PageSize != MaxPageSize {
+		t.Errorf("got page %d of %d, want page 1 of %d", p.Page, p.PageSize, MaxPageSize)
+	}
+	if want := []Sort{{Field: "created_at", Desc: true}}; !reflect.DeepEqual(p.Sort, want) {
+		t.Errorf("got sort %+v, want the default %+v", p.Sort, want)
+	}
+	if p.Keyset() || !p.WantTotal() {
+		t.Errorf("offset paging: keyset %t, total %t", p.Keyset(), p.WantTotal())
+	}
+}
+
+// dryRun builds SQL without running it.
+func dryRun(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{
+		DryRun: true,
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	return db
+}
+
+func TestFilter(t *testing.T) {
+	db := dryRun(t)
+
+	tests := []struct {
+		query string
+		where string
+		vars  []interface{}
+	}{
+		{"filter[title]=Desk", "widgets.title = ?", []interface{}{"Desk"}},
+		{"filter[id][ne]=3", "widgets.id <> ?", []interface{}{float64(3)}},
+		{"filter[title][in]=a,b", "widgets.title IN (?,?)", []interface{}{"a", "b"}},
+		{"filter[title][contains]=10%25", "widgets.title ILIKE ?", []interface{}{`%10\%%`}},
+		{"filter[title][contains]=%27 OR 1=1--", "widgets.title ILIKE ?", []interface{}{"%' OR 1=1--%"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := Parse(query, widgetListing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stmt := p.Filter(db.Table("widgets")).Find(&[]map[string]interface{}{}).Statement
+			sql := stmt.SQL.String()
+			if !strings.HasSuffix(sql, "WHERE "+tt.where) {
+				t.Errorf("got %q, want it to end in WHERE %s", sql, tt.where)
+			}
+			if !reflect.DeepEqual(stmt.Vars, tt.vars) {
+				t.Errorf("got vars %#v, want %#v", stmt.Vars, tt.vars)
+			}
+		})
+	}
+}
+
+func TestFilterRepeatedField(t *testing.T) {
+	p, err := Parse(url.Values{"filter[id][gte]": {"1"}, "filter[id][lt]": {"9"}}, widgetListing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stmt := p.Filter(dryRun(t).Table("widgets")).Find(&[]map[string]interface{}{}).Statement
+	sql := stmt.SQL.String()
+	if !strings.Contains(sql, "widgets.id >= ?") || !strings.Contains(sql, "widgets.id < ?") || !strings.Contains(sql, " AND ") {
+		t.Errorf("got %q, want both conditions", sql)
+	}
+	if len(stmt.Vars) != 2 {
+		t.Errorf("got vars %v, want 2", stmt.Vars)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+		want  []string
+	}{
+		{"no fields", url.Values{}, nil},
+		{"with ID and sort column", url.Values{"fields": {"title"}}, []string{"widgets.id", "widgets.title", "widgets.created_at"}},
+		{"without duplicates", url.Values{"fields": {"id,created_at,title"}, "sort": {"title"}}, []string{"widgets.id", "widgets.created_at", "widgets.title"}},
+		{"relation only", url.Values{"fields": {"parts"}, "sort": {"id"}}, []string{"widgets.id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Parse(tt.query, widgetListing)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stmt := p.Select(dryRun(t).Table("widgets")).Find(&[]map[string]interface{}{}).Statement
+			if tt.want == nil {
+				if len(stmt.Selects) != 0 {
+					t.Errorf("selected %v, want every column", stmt.Selects)
+				}
+				return
+			}
+			if !reflect.DeepEqual(stmt.Selects, tt.want) {
+				t.Errorf("selected %v, want %v", stmt.Selects, tt.want)
+			}
+		})
+	}
+}
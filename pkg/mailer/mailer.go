@@ -0,0 +1,19 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"tender_management/config"
+)
+
+const (
+	smtpHost = "smtp.gmail.com"
+	smtpPort = "587"
+)
+
+func Send(cfg *config.Config, to, subject, body string) error {
+	message := fmt.Sprintf("Subject: %s\n\n%s", subject, body)
+
+	auth := smtp.PlainAuth("", cfg.AppEmail, cfg.AppPassword, smtpHost)
+	return smtp.SendMail(smtpHost+":"+smtpPort, auth, cfg.AppEmail, []string{to}, []byte(message))
+}
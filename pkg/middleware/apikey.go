@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"tender_management/models"
+	"tender_management/pkg/utils"
+	"time"
+
+	"github.com/casbin/casbin/v2/util"
+	"gorm.io/gorm"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+var errInvalidAPIKey = errors.New("invalid API key")
+
+// authenticateAPIKey resolves an API key to the claims of its owner and the
+// scopes the key is restricted to.
+func authenticateAPIKey(db *gorm.DB, key string) (*models.Claims, []string, error) {
+	lookup, ok := utils.ParseAPIKey(key)
+	if !ok {
+		return nil, nil, errInvalidAPIKey
+	}
+
+	var apiKey models.APIKey
+	if err := db.Where("lookup = ?", lookup).First(&apiKey).Error; err != nil {
+		return nil, nil, errInvalidAPIKey
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(utils.HashToken(key))) != 1 {
+		return nil, nil, errInvalidAPIKey
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, nil, errors.New("API key revoked")
+	}
+
+	now := time.Now()
+	if apiKey.ExpiresAt != nil && !apiKey.ExpiresAt.After(now) {
+		return nil, nil, errors.New("API key expired")
+	}
+
+	var user models.Users
+	if err := db.Where("id = ? AND is_active = ?", apiKey.UserID, true).First(&user).Error; err != nil {
+		return nil, nil, errors.New("API key owner not found or inactive")
+	}
+
+	if err := db.Model(&apiKey).UpdateColumn("last_used_at", now).Error; err != nil {
+		log.Printf("[ERROR] Failed to update API key last_used_at: %v\n", err)
+	}
+
+	claims := &models.Claims{
+		UserID: user.ID,
+		Email:  user.Email,
+		Role:   user.Role,
+	}
+	return claims, apiKey.SplitScopes(), nil
+}
+
+func scopeAllows(scopes []string, path, method string) bool {
+	for _, scope := range scopes {
+		scopePath, scopeMethod, ok := models.ParseScope(scope)
+		if ok && scopeMethod == method && util.KeyMatch2(path, scopePath) {
+			return true
+		}
+	}
+	return false
+}
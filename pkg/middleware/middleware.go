@@ -3,31 +3,62 @@ package middleware
 import (
 	"log"
 	"net/http"
+	"strings"
+	"tender_management/constants"
 	"tender_management/controllers"
+	"tender_management/models"
 	"tender_management/pkg/utils"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
-func AutoMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
+// AutoMiddleware authenticates a request by API key or by a token signed
+// with secret, then enforces the casbin policy and any API key scopes.
+func AutoMiddleware(e *casbin.Enforcer, db *gorm.DB, secret []byte) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tokenString := c.GetHeader("Authorization")
-		if tokenString == "" {
-			log.Println("[ERROR] Authorization header missing")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing"})
-			return
-		}
+		var claims *models.Claims
+		var scopes []string
+		var err error
 
-		claims, err := utils.ValidateToken(tokenString)
-		if err != nil {
-			if err.Error() == "token is expired" {
-				log.Printf("[ERROR] Token expired: %v\n", err)
-				controllers.HandleResponse(c, http.StatusUnauthorized, "Token expired")
-			} else {
-				log.Printf("[ERROR] Invalid token: %v\n", err)
-				controllers.HandleResponse(c, http.StatusUnauthorized, "Invalid token")
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			claims, scopes, err = authenticateAPIKey(db, key)
+			if err != nil {
+				log.Printf("[ERROR] API key rejected: %v\n", err)
+				controllers.HandleResponse(c, http.StatusUnauthorized, "Invalid API key")
+				c.Abort()
+				return
+			}
+			if models.CredentialPath(c.Request.URL.Path) {
+				controllers.HandleResponse(c, http.StatusForbidden, "API keys cannot manage API keys or two-factor authentication")
+				c.Abort()
+				return
+			}
+		} else {
+			tokenString := c.GetHeader("Authorization")
+			if tokenString == "" {
+				log.Println("[ERROR] Authorization header missing")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing"})
+				return
+			}
+
+			claims, err = utils.ValidateToken(secret, tokenString)
+			if err != nil {
+				if err.Error() == "token is expired" {
+					log.Printf("[ERROR] Token expired: %v\n", err)
+					controllers.HandleResponse(c, http.StatusUnauthorized, "Token expired")
+				} else {
+					log.Printf("[ERROR] Invalid token: %v\n", err)
+					controllers.HandleResponse(c, http.StatusUnauthorized, "Invalid token")
+				}
+				c.Abort()
+				return
 			}
+		}
+
+		if claims.MFAEnrollOnly && !strings.HasPrefix(c.Request.URL.Path, "/auth/2fa/") {
+			controllers.HandleResponse(c, http.StatusForbidden, "Two-factor authentication enrolment required")
 			c.Abort()
 			return
 		}
@@ -46,6 +77,17 @@ func AutoMiddleware(e *casbin.Enforcer) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+
+		if scopes != nil && !scopeAllows(scopes, c.Request.URL.Path, c.Request.Method) {
+			controllers.HandleResponse(c, http.StatusForbidden, "API key scope does not allow this request")
+			log.Printf("[INFO] API key scope denied for user: %v, Path: %s, Method: %s\n", claims.UserID, c.Request.URL.Path, c.Request.Method)
+			c.Abort()
+			return
+		}
+
+		c.Set(constants.CtxUserID, claims.UserID)
+		c.Set(constants.CtxEmail, claims.Email)
+		c.Set(constants.CtxRole, claims.Role)
 		c.Next()
 	}
 }
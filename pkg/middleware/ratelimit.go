@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"tender_management/controllers"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimit allows each client IP limit requests per window, counted in
+// fixed windows in Redis so the limit holds across instances. Requests go
+// through when Redis is unavailable. The client IP only comes from
+// X-Forwarded-For behind the engine's trusted proxies, so clients cannot
+// pick the key they are counted under.
+func RateLimit(rdb *redis.Client, name string, limit int, window time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+		start := now.Truncate(window)
+		key := fmt.Sprintf("ratelimit:%s:%s:%d", name, c.ClientIP(), start.Unix())
+
+		pipe := rdb.TxPipeline()
+		count := pipe.Incr(c, key)
+		pipe.Expire(c, key, window)
+		if _, err := pipe.Exec(c); err != nil {
+			log.Printf("[ERROR] Rate limit: %v\n", err)
+			c.Next()
+			return
+		}
+
+		remaining := limit - int(count.Val())
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(max(remaining, 0)))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(start.Add(window).Unix(), 10))
+
+		if remaining < 0 {
+			retry := start.Add(window).Sub(now)
+			c.Header("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+			controllers.HandleResponse(c, http.StatusTooManyRequests, "Too many requests")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
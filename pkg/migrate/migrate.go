@@ -0,0 +1,271 @@
+// Package migrate applies the versioned SQL migrations embedded in the
+// binary and records them in the schema_migrations table.
+//
+// Migrations live in migrations/ as NNNN_name.up.sql and NNNN_name.down.sql
+// pairs. Each one runs in its own transaction together with its
+// schema_migrations row, so a failed migration leaves no trace.
+package migrate
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var files embed.FS
+
+// lockKey serialises migrations of concurrently starting processes.
+const lockKey = 7432001
+
+const tableSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name varchar(255) NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now()
+)`
+
+// ErrNewerSchema is returned when the database has a migration applied
+// that this binary does not know, typically because a newer release ran
+// against it.
+var ErrNewerSchema = errors.New("database schema is newer than this binary")
+
+// ErrPending is returned by Check when migrations remain to be applied.
+var ErrPending = errors.New("database has pending migrations")
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, nil while pending.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type record struct {
+	Version   uint
+	Name      string
+	AppliedAt time.Time
+}
+
+func (record) TableName() string {
+	return "schema_migrations"
+}
+
+// Load returns the embedded migrations in version order.
+func Load() ([]Migration, error) {
+	entries, err := files.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		file := entry.Name()
+
+		base, direction := strings.TrimSuffix(file, ".sql"), ""
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			base, direction = strings.TrimSuffix(base, ".up"), "up"
+		case strings.HasSuffix(base, ".down"):
+			base, direction = strings.TrimSuffix(base, ".down"), "down"
+		default:
+			return nil, fmt.Errorf("migration %s is neither .up.sql nor .down.sql", file)
+		}
+
+		number, name, ok := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(number, 10, 32)
+		if !ok || err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s must be named NNNN_name", file)
+		}
+
+		body, err := files.ReadFile(path.Join("migrations", file))
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[uint(version)]
+		if m == nil {
+			m = &Migration{Version: uint(version), Name: name}
+			byVersion[uint(version)] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Statuses lists every embedded migration with when it was applied. It
+// fails with ErrNewerSchema if the database has unknown migrations.
+func Statuses(db *gorm.DB) ([]Status, error) {
+	migrations, applied, err := state(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		status := Status{Migration: m}
+		if r, ok := applied[m.Version]; ok {
+			appliedAt := r.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Check verifies that the database schema is exactly the one this binary
+// expects: ErrNewerSchema if it has unknown migrations, ErrPending if some
+// are not applied yet.
+func Check(db *gorm.DB) error {
+	migrations, applied, err := state(db)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; !ok {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d not applied, run migrate up", ErrPending, pending)
+	}
+	return nil
+}
+
+// Up applies the pending migrations in order and returns them.
+func Up(db *gorm.DB) ([]Migration, error) {
+	migrations, applied, err := state(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+				return err
+			}
+
+			// Another process may have applied it while we waited.
+			var count int64
+			if err := tx.Model(&record{}).Where("version = ?", m.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+
+			if err := tx.Exec(m.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&record{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// Down reverts the given number of applied migrations, newest first, and
+// returns them. Zero or less reverts all of them.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, applied, err := state(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if steps > 0 && len(done) == steps {
+			break
+		}
+
+		m := migrations[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(m.Down).Error; err != nil {
+				return err
+			}
+			return tx.Where("version = ?", m.Version).Delete(&record{}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// state loads the embedded migrations and the applied ones, creating the
+// version table if needed.
+func state(db *gorm.DB) ([]Migration, map[uint]record, error) {
+	migrations, err := Load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := db.Exec(tableSQL).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var records []record
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, nil, err
+	}
+
+	known := make(map[uint]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+	}
+
+	applied := make(map[uint]record, len(records))
+	for _, r := range records {
+		if !known[r.Version] {
+			return nil, nil, fmt.Errorf("%w: migration %04d_%s is unknown", ErrNewerSchema, r.Version, r.Name)
+		}
+		applied[r.Version] = r
+	}
+	return migrations, applied, nil
+}
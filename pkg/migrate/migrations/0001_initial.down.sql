@@ -0,0 +1,25 @@
+DROP MATERIALIZED VIEW IF EXISTS report_tender_facts;
+DROP TABLE IF EXISTS contractor_categories;
+DROP TABLE IF EXISTS tender_categories;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS telegram_links;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
+DROP TABLE IF EXISTS outbox_messages;
+DROP TABLE IF EXISTS notif_deliveries;
+DROP TABLE IF EXISTS notif_preferences;
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS tender_criterions;
+DROP TABLE IF EXISTS tender_qualifications;
+DROP TABLE IF EXISTS qualification_documents;
+DROP TABLE IF EXISTS contractor_profiles;
+DROP TABLE IF EXISTS organization_invitations;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS two_factor_policies;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS notifs;
+DROP TABLE IF EXISTS offers;
+DROP TABLE IF EXISTS tenders;
+DROP TABLE IF EXISTS organizations;
+DROP TABLE IF EXISTS users;
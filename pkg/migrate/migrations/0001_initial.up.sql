@@ -0,0 +1,381 @@
+-- Initial schema. Every statement is guarded with IF NOT EXISTS so that
+-- databases created by the former AutoMigrate at startup are adopted as is.
+
+CREATE TABLE IF NOT EXISTS users (
+	id bigserial,
+	first_name varchar(255) NOT NULL,
+	email varchar(255) NOT NULL,
+	phone_number varchar(255) NOT NULL,
+	password varchar(255) NOT NULL,
+	role varchar(255) NOT NULL,
+	is_active boolean DEFAULT false,
+	two_factor_secret varchar(64),
+	two_factor_enabled boolean DEFAULT false,
+	PRIMARY KEY (id),
+	CONSTRAINT uni_users_email UNIQUE (email),
+	CONSTRAINT uni_users_phone_number UNIQUE (phone_number)
+);
+
+CREATE TABLE IF NOT EXISTS organizations (
+	id bigserial,
+	name varchar(255) NOT NULL,
+	kind varchar(50) NOT NULL,
+	deleted_at timestamptz,
+	created_at timestamptz,
+	updated_at timestamptz,
+	PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tenders (
+	id bigserial,
+	title varchar(255) NOT NULL,
+	description text NOT NULL,
+	deadline timestamptz NOT NULL,
+	budget decimal(10,2) NOT NULL,
+	hide_budget boolean NOT NULL DEFAULT false,
+	file_url varchar(255),
+	status boolean DEFAULT true,
+	client_id bigint NOT NULL,
+	organization_id bigint,
+	state varchar(20) NOT NULL DEFAULT 'open',
+	awarded_offer_id bigint,
+	awarded_at timestamptz,
+	reminder_sent_at timestamptz,
+	search_vector tsvector,
+	deleted_at timestamptz,
+	created_at timestamptz,
+	updated_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_tenders_users FOREIGN KEY (client_id) REFERENCES users(id),
+	CONSTRAINT fk_tenders_organization FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+CREATE INDEX IF NOT EXISTS idx_tenders_deleted_at ON tenders (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_tenders_search ON tenders USING gin(search_vector);
+CREATE INDEX IF NOT EXISTS idx_tenders_organization_id ON tenders (organization_id);
+CREATE INDEX IF NOT EXISTS idx_tenders_client_id ON tenders (client_id);
+CREATE INDEX IF NOT EXISTS idx_tenders_deadline ON tenders (deadline);
+
+CREATE TABLE IF NOT EXISTS offers (
+	id bigserial,
+	tender_id bigint NOT NULL,
+	contractor_id bigint NOT NULL,
+	price decimal(10,2) NOT NULL,
+	delivery_time timestamptz NOT NULL,
+	comments text NOT NULL,
+	status boolean DEFAULT true,
+	organization_id bigint,
+	deleted_at timestamptz,
+	created_at timestamptz,
+	updated_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_offers_users FOREIGN KEY (contractor_id) REFERENCES users(id),
+	CONSTRAINT fk_offers_tenders FOREIGN KEY (tender_id) REFERENCES tenders(id),
+	CONSTRAINT fk_offers_organization FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+CREATE INDEX IF NOT EXISTS idx_offers_deleted_at ON offers (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_offers_organization_id ON offers (organization_id);
+CREATE INDEX IF NOT EXISTS idx_offers_tender_id ON offers (tender_id);
+CREATE INDEX IF NOT EXISTS idx_offers_contractor_id ON offers (contractor_id);
+
+CREATE TABLE IF NOT EXISTS notifs (
+	id bigserial,
+	user_id bigint NOT NULL,
+	message text NOT NULL,
+	relation_id bigint NOT NULL,
+	type varchar(50) NOT NULL,
+	read_at timestamptz,
+	archived_at timestamptz,
+	inbox_hidden boolean NOT NULL DEFAULT false,
+	event_id varchar(100),
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_notifs_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_notifs_event_id ON notifs (event_id);
+CREATE INDEX IF NOT EXISTS idx_notifs_archived_at ON notifs (archived_at);
+CREATE INDEX IF NOT EXISTS idx_notifs_user_id ON notifs (user_id);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+	id bigserial,
+	user_id bigint NOT NULL,
+	code_hash varchar(64) NOT NULL,
+	used_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_recovery_codes_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS two_factor_policies (
+	role varchar(255),
+	required boolean DEFAULT false,
+	updated_at timestamptz,
+	PRIMARY KEY (role)
+);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+	id bigserial,
+	user_id bigint NOT NULL,
+	name varchar(255) NOT NULL,
+	lookup varchar(32) NOT NULL,
+	key_hash varchar(64) NOT NULL,
+	scopes text NOT NULL,
+	expires_at timestamptz,
+	last_used_at timestamptz,
+	revoked_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_api_keys_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT uni_api_keys_lookup UNIQUE (lookup)
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
+
+CREATE TABLE IF NOT EXISTS organization_members (
+	id bigserial,
+	organization_id bigint NOT NULL,
+	user_id bigint NOT NULL,
+	role varchar(50) NOT NULL,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_organization_members_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT fk_organizations_members FOREIGN KEY (organization_id) REFERENCES organizations(id)
+);
+CREATE INDEX IF NOT EXISTS idx_organization_members_user_id ON organization_members (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_org_member ON organization_members (organization_id,user_id);
+
+CREATE TABLE IF NOT EXISTS organization_invitations (
+	id bigserial,
+	organization_id bigint NOT NULL,
+	email varchar(255) NOT NULL,
+	role varchar(50) NOT NULL,
+	token_hash varchar(64) NOT NULL,
+	invited_by bigint NOT NULL,
+	expires_at timestamptz NOT NULL,
+	accepted_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_organization_invitations_organization FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+	CONSTRAINT uni_organization_invitations_token_hash UNIQUE (token_hash)
+);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_organization_id ON organization_invitations (organization_id);
+
+CREATE TABLE IF NOT EXISTS contractor_profiles (
+	id bigserial,
+	user_id bigint NOT NULL,
+	legal_name varchar(255) NOT NULL,
+	tax_id varchar(9) NOT NULL,
+	address text NOT NULL,
+	specialisations text,
+	years_in_business bigint NOT NULL DEFAULT 0,
+	created_at timestamptz,
+	updated_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_contractor_profiles_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT uni_contractor_profiles_tax_id UNIQUE (tax_id),
+	CONSTRAINT uni_contractor_profiles_user_id UNIQUE (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS qualification_documents (
+	id bigserial,
+	user_id bigint NOT NULL,
+	type varchar(50) NOT NULL,
+	qualification varchar(100) NOT NULL,
+	number varchar(100) NOT NULL,
+	file_url varchar(255),
+	issued_at timestamptz,
+	expires_at timestamptz NOT NULL,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_qualification_documents_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_qualification_documents_qualification ON qualification_documents (qualification);
+CREATE INDEX IF NOT EXISTS idx_qualification_documents_user_id ON qualification_documents (user_id);
+
+CREATE TABLE IF NOT EXISTS tender_qualifications (
+	id bigserial,
+	tender_id bigint NOT NULL,
+	qualification varchar(100) NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_tenders_qualifications FOREIGN KEY (tender_id) REFERENCES tenders(id)
+);
+CREATE INDEX IF NOT EXISTS idx_tender_qualifications_tender_id ON tender_qualifications (tender_id);
+
+CREATE TABLE IF NOT EXISTS tender_criterions (
+	id bigserial,
+	tender_id bigint NOT NULL,
+	criterion varchar(30) NOT NULL,
+	weight decimal(5,2) NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_tenders_criteria FOREIGN KEY (tender_id) REFERENCES tenders(id)
+);
+CREATE INDEX IF NOT EXISTS idx_tender_criterions_tender_id ON tender_criterions (tender_id);
+
+CREATE TABLE IF NOT EXISTS attachments (
+	id bigserial,
+	owner_type varchar(20) NOT NULL,
+	owner_id bigint NOT NULL,
+	file_name varchar(255) NOT NULL,
+	content_type varchar(100) NOT NULL,
+	size bigint NOT NULL,
+	sha256 varchar(64) NOT NULL,
+	storage_key varchar(255) NOT NULL,
+	uploaded_by bigint NOT NULL,
+	text_content text,
+	deleted_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT uni_attachments_storage_key UNIQUE (storage_key)
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_deleted_at ON attachments (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_attachment_owner ON attachments (owner_type,owner_id);
+
+CREATE TABLE IF NOT EXISTS notif_preferences (
+	id bigserial,
+	user_id bigint NOT NULL,
+	type varchar(50) NOT NULL,
+	in_app boolean NOT NULL,
+	email boolean NOT NULL,
+	sms boolean NOT NULL,
+	webhook boolean NOT NULL,
+	webhook_url varchar(255),
+	mode varchar(20) NOT NULL,
+	updated_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_notif_preferences_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notif_pref ON notif_preferences (user_id,type);
+
+CREATE TABLE IF NOT EXISTS notif_deliveries (
+	id bigserial,
+	notif_id bigint NOT NULL,
+	user_id bigint NOT NULL,
+	channel varchar(20) NOT NULL,
+	digest boolean NOT NULL DEFAULT false,
+	status varchar(20) NOT NULL,
+	attempts bigint NOT NULL DEFAULT 0,
+	last_error text,
+	sent_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_notif_deliveries_notif FOREIGN KEY (notif_id) REFERENCES notifs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_notif_deliveries_status ON notif_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_notif_deliveries_user_id ON notif_deliveries (user_id);
+CREATE INDEX IF NOT EXISTS idx_notif_deliveries_notif_id ON notif_deliveries (notif_id);
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+	id bigserial,
+	kind varchar(30) NOT NULL,
+	idempotency_key varchar(100) NOT NULL,
+	payload text NOT NULL,
+	status varchar(20) NOT NULL,
+	attempts bigint NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL,
+	last_error text,
+	processed_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_due ON outbox_messages (status,next_attempt_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_messages_idempotency_key ON outbox_messages (idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_kind ON outbox_messages (kind);
+
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+	id bigserial,
+	user_id bigint NOT NULL,
+	organization_id bigint,
+	url varchar(500) NOT NULL,
+	secret varchar(64) NOT NULL,
+	event_types text NOT NULL,
+	created_at timestamptz,
+	deleted_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_webhook_endpoints_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_organization_id ON webhook_endpoints (organization_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_user_id ON webhook_endpoints (user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_deleted_at ON webhook_endpoints (deleted_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id bigserial,
+	endpoint_id bigint NOT NULL,
+	event_id varchar(100) NOT NULL,
+	event_type varchar(50) NOT NULL,
+	payload text NOT NULL,
+	status varchar(20) NOT NULL,
+	attempts bigint NOT NULL DEFAULT 0,
+	response_status bigint,
+	last_error text,
+	delivered_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_webhook_deliveries_endpoint FOREIGN KEY (endpoint_id) REFERENCES webhook_endpoints(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint_id ON webhook_deliveries (endpoint_id);
+
+CREATE TABLE IF NOT EXISTS telegram_links (
+	id bigserial,
+	user_id bigint NOT NULL,
+	chat_id bigint NOT NULL,
+	username varchar(100),
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_telegram_links_users FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_telegram_links_chat_id ON telegram_links (chat_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_telegram_links_user_id ON telegram_links (user_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+	id bigserial,
+	code varchar(20) NOT NULL,
+	name varchar(500) NOT NULL,
+	parent_id bigint,
+	path varchar(500) NOT NULL,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE SET NULL,
+	CONSTRAINT uni_categories_code UNIQUE (code)
+);
+CREATE INDEX IF NOT EXISTS idx_categories_path ON categories (path);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+CREATE TABLE IF NOT EXISTS tender_categories (
+	tender_id bigint,
+	category_id bigint,
+	PRIMARY KEY (tender_id, category_id),
+	CONSTRAINT fk_tender_categories_tenders FOREIGN KEY (tender_id) REFERENCES tenders(id),
+	CONSTRAINT fk_tender_categories_category FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+CREATE INDEX IF NOT EXISTS idx_tender_categories_category_id ON tender_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS contractor_categories (
+	profile_id bigint,
+	category_id bigint,
+	PRIMARY KEY (profile_id, category_id),
+	CONSTRAINT fk_contractor_categories_contractor_profile FOREIGN KEY (profile_id) REFERENCES contractor_profiles(id),
+	CONSTRAINT fk_contractor_categories_category FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+-- One row per live tender for the analytics reports. Awarded tenders from
+-- before awarded_at was recorded fall back to their last update as award
+-- time. The unique index lets the view be refreshed concurrently.
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_tender_facts AS
+SELECT t.id AS tender_id,
+	t.client_id,
+	t.organization_id,
+	t.state,
+	t.budget,
+	t.created_at,
+	date_trunc('month', t.created_at) AS month,
+	CASE WHEN t.state = 'awarded' THEN COALESCE(t.awarded_at, t.updated_at) END AS awarded_at,
+	w.price AS awarded_price,
+	w.contractor_id AS winner_id,
+	(SELECT COUNT(*) FROM offers AS o WHERE o.tender_id = t.id AND o.deleted_at IS NULL) AS bid_count,
+	now() AS refreshed_at
+FROM tenders AS t
+LEFT JOIN offers AS w ON w.id = t.awarded_offer_id AND t.state = 'awarded'
+WHERE t.deleted_at IS NULL
+WITH DATA;
+CREATE UNIQUE INDEX IF NOT EXISTS report_tender_facts_tender_id ON report_tender_facts (tender_id);
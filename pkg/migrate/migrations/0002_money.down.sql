@@ -0,0 +1,26 @@
+DROP MATERIALIZED VIEW IF EXISTS report_tender_facts;
+
+ALTER TABLE offers DROP COLUMN currency;
+ALTER TABLE offers ALTER COLUMN price TYPE decimal(10,2);
+
+ALTER TABLE tenders DROP COLUMN currency;
+ALTER TABLE tenders ALTER COLUMN budget TYPE decimal(10,2);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_tender_facts AS
+SELECT t.id AS tender_id,
+	t.client_id,
+	t.organization_id,
+	t.state,
+	t.budget,
+	t.created_at,
+	date_trunc('month', t.created_at) AS month,
+	CASE WHEN t.state = 'awarded' THEN COALESCE(t.awarded_at, t.updated_at) END AS awarded_at,
+	w.price AS awarded_price,
+	w.contractor_id AS winner_id,
+	(SELECT COUNT(*) FROM offers AS o WHERE o.tender_id = t.id AND o.deleted_at IS NULL) AS bid_count,
+	now() AS refreshed_at
+FROM tenders AS t
+LEFT JOIN offers AS w ON w.id = t.awarded_offer_id AND t.state = 'awarded'
+WHERE t.deleted_at IS NULL
+WITH DATA;
+CREATE UNIQUE INDEX IF NOT EXISTS report_tender_facts_tender_id ON report_tender_facts (tender_id);
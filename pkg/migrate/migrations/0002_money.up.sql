@@ -0,0 +1,34 @@
+-- Amounts become numeric(20,2), up from decimal(10,2) which capped them
+-- below 100 million, and carry an ISO 4217 currency. Existing tenders are
+-- taken to be in UZS, the default currency, and offers in their tender's.
+-- The report view depends on the amount columns, so it is rebuilt.
+DROP MATERIALIZED VIEW IF EXISTS report_tender_facts;
+
+ALTER TABLE tenders ALTER COLUMN budget TYPE numeric(20,2);
+ALTER TABLE tenders ADD COLUMN currency varchar(3) NOT NULL DEFAULT 'UZS';
+ALTER TABLE tenders ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE offers ALTER COLUMN price TYPE numeric(20,2);
+ALTER TABLE offers ADD COLUMN currency varchar(3);
+UPDATE offers SET currency = tenders.currency FROM tenders WHERE tenders.id = offers.tender_id;
+ALTER TABLE offers ALTER COLUMN currency SET NOT NULL;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_tender_facts AS
+SELECT t.id AS tender_id,
+	t.client_id,
+	t.organization_id,
+	t.state,
+	t.budget,
+	t.currency,
+	t.created_at,
+	date_trunc('month', t.created_at) AS month,
+	CASE WHEN t.state = 'awarded' THEN COALESCE(t.awarded_at, t.updated_at) END AS awarded_at,
+	w.price AS awarded_price,
+	w.contractor_id AS winner_id,
+	(SELECT COUNT(*) FROM offers AS o WHERE o.tender_id = t.id AND o.deleted_at IS NULL) AS bid_count,
+	now() AS refreshed_at
+FROM tenders AS t
+LEFT JOIN offers AS w ON w.id = t.awarded_offer_id AND t.state = 'awarded'
+WHERE t.deleted_at IS NULL
+WITH DATA;
+CREATE UNIQUE INDEX IF NOT EXISTS report_tender_facts_tender_id ON report_tender_facts (tender_id);
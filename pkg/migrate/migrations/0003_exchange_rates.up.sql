@@ -0,0 +1,49 @@
+-- Offers may be priced in any currency their tender accepts and are
+-- compared at a normalized price in the tender currency, converted with
+-- Central Bank rates kept in exchange_rates. Each offer stores the rate and
+-- rate date it was valued at. Existing offers are all in their tender's
+-- currency, at a rate of 1. The report view now reports the normalized
+-- awarded price, so it is rebuilt.
+CREATE TABLE IF NOT EXISTS exchange_rates (
+	id bigserial,
+	currency varchar(3) NOT NULL,
+	date date NOT NULL,
+	rate numeric(24,10) NOT NULL,
+	source varchar(100),
+	created_at timestamptz,
+	PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exchange_rates_currency_date ON exchange_rates (currency, date);
+
+ALTER TABLE tenders ADD COLUMN accepted_currencies varchar(100);
+UPDATE tenders SET accepted_currencies = currency;
+ALTER TABLE tenders ALTER COLUMN accepted_currencies SET NOT NULL;
+
+ALTER TABLE offers ADD COLUMN normalized_price numeric(20,2);
+ALTER TABLE offers ADD COLUMN exchange_rate numeric(24,10);
+ALTER TABLE offers ADD COLUMN rate_date date;
+UPDATE offers SET normalized_price = price, exchange_rate = 1;
+ALTER TABLE offers ALTER COLUMN normalized_price SET NOT NULL;
+ALTER TABLE offers ALTER COLUMN exchange_rate SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_offers_normalized_price ON offers (normalized_price);
+
+DROP MATERIALIZED VIEW IF EXISTS report_tender_facts;
+CREATE MATERIALIZED VIEW IF NOT EXISTS report_tender_facts AS
+SELECT t.id AS tender_id,
+	t.client_id,
+	t.organization_id,
+	t.state,
+	t.budget,
+	t.currency,
+	t.created_at,
+	date_trunc('month', t.created_at) AS month,
+	CASE WHEN t.state = 'awarded' THEN COALESCE(t.awarded_at, t.updated_at) END AS awarded_at,
+	w.normalized_price AS awarded_price,
+	w.contractor_id AS winner_id,
+	(SELECT COUNT(*) FROM offers AS o WHERE o.tender_id = t.id AND o.deleted_at IS NULL) AS bid_count,
+	now() AS refreshed_at
+FROM tenders AS t
+LEFT JOIN offers AS w ON w.id = t.awarded_offer_id AND t.state = 'awarded'
+WHERE t.deleted_at IS NULL
+WITH DATA;
+CREATE UNIQUE INDEX IF NOT EXISTS report_tender_facts_tender_id ON report_tender_facts (tender_id);
@@ -0,0 +1,183 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"tender_management/config"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/mailer"
+	"tender_management/pkg/outbox"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SMSSender sends a text message to a phone number.
+type SMSSender interface {
+	SendSMS(ctx context.Context, phone, text string) error
+}
+
+// LogSMS is used until an SMS provider is configured: it only logs the
+// message.
+type LogSMS struct{}
+
+func (LogSMS) SendSMS(ctx context.Context, phone, text string) error {
+	log.Printf("[INFO] SMS to %s: %s\n", phone, text)
+	return nil
+}
+
+// Dispatcher delivers notifications over email, SMS and webhooks according
+// to the NotifDelivery rows created by the Notifier. Immediate deliveries
+// reach it through the outbox relay; digests through SendDigests.
+type Dispatcher struct {
+	db     *gorm.DB
+	cfg    *config.Config
+	sms    SMSSender
+	client *http.Client
+}
+
+func NewDispatcher(db *gorm.DB, cfg *config.Config, sms SMSSender) *Dispatcher {
+	return &Dispatcher{
+		db:     db,
+		cfg:    cfg,
+		sms:    sms,
+		client: outbox.NewWebhookClient(10*time.Second, cfg.WebhookAllowPrivate),
+	}
+}
+
+// HandleDelivery is the outbox handler for models.OutboxNotifDelivery: it
+// sends one immediate delivery. Deliveries already sent are skipped, so the
+// relay may safely call it again.
+func (d *Dispatcher) HandleDelivery(ctx context.Context, msg models.OutboxMessage) error {
+	var p models.NotifDeliveryPayload
+	if err := json.Unmarshal([]byte(msg.Payload), &p); err != nil {
+		return err
+	}
+
+	var delivery models.NotifDelivery
+	if err := d.db.WithContext(ctx).Preload("Notif").First(&delivery, p.DeliveryID).Error; err != nil {
+		return err
+	}
+	if delivery.Status == models.DeliverySent {
+		return nil
+	}
+
+	err := d.deliver(ctx, delivery, msg.IdempotencyKey)
+	d.finish(ctx, []uint{delivery.ID}, err)
+	return err
+}
+
+// SendDigests emails every user their pending digest notifications in a
+// single message, at most once per 24 hours.
+func (d *Dispatcher) SendDigests(ctx context.Context) error {
+	var userIDs []uint
+	if err := d.db.WithContext(ctx).Model(&models.NotifDelivery{}).
+		Where("status = ? AND digest = ?", models.DeliveryPending, true).
+		Distinct().Pluck("user_id", &userIDs).Error; err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+	for _, userID := range userIDs {
+		var recent int64
+		if err := d.db.WithContext(ctx).Model(&models.NotifDelivery{}).
+			Where("user_id = ? AND digest = ? AND status = ? AND sent_at > ?", userID, true, models.DeliverySent, since).
+			Count(&recent).Error; err != nil {
+			return err
+		}
+		if recent > 0 {
+			continue
+		}
+
+		if err := d.sendDigest(ctx, userID); err != nil {
+			log.Printf("[ERROR] Failed to send digest to user %d: %v\n", userID, err)
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) sendDigest(ctx context.Context, userID uint) error {
+	var user models.Users
+	if err := d.db.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return err
+	}
+
+	var deliveries []models.NotifDelivery
+	if err := d.db.WithContext(ctx).Preload("Notif").
+		Where("user_id = ? AND status = ? AND digest = ?", userID, models.DeliveryPending, true).
+		Order("created_at ASC").Find(&deliveries).Error; err != nil {
+		return err
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hello %s,\n\nHere is what happened since your last digest:\n\n", user.FirstName)
+
+	ids := make([]uint, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID)
+		if delivery.Notif != nil {
+			fmt.Fprintf(&body, "- [%s] %s\n", delivery.Notif.CreatedAt.Format(constants.Layout), delivery.Notif.Message)
+		}
+	}
+
+	err := mailer.Send(d.cfg, user.Email, fmt.Sprintf("Your daily digest: %d notifications", len(ids)), body.String())
+	d.finish(ctx, ids, err)
+	return err
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery models.NotifDelivery, key string) error {
+	if delivery.Notif == nil {
+		return fmt.Errorf("notification %d not found", delivery.NotifID)
+	}
+
+	var user models.Users
+	if err := d.db.WithContext(ctx).First(&user, delivery.UserID).Error; err != nil {
+		return err
+	}
+
+	notif := delivery.Notif
+	switch delivery.Channel {
+	case models.ChannelEmail:
+		return mailer.Send(d.cfg, user.Email, "Tender notification", notif.Message)
+	case models.ChannelSMS:
+		return d.sms.SendSMS(ctx, user.PhoneNumber, notif.Message)
+	case models.ChannelWebhook:
+		var pref models.NotifPreference
+		if err := d.db.WithContext(ctx).Where("user_id = ? AND type = ?", user.ID, notif.Type).First(&pref).Error; err != nil {
+			return err
+		}
+		payload, err := json.Marshal(notif)
+		if err != nil {
+			return err
+		}
+		return outbox.PostJSON(ctx, d.client, pref.WebhookURL, key, payload)
+	default:
+		return fmt.Errorf("unknown channel %q", delivery.Channel)
+	}
+}
+
+func (d *Dispatcher) finish(ctx context.Context, ids []uint, err error) {
+	fields := map[string]interface{}{
+		"attempts": gorm.Expr("attempts + 1"),
+		"status":   models.DeliverySent,
+		"sent_at":  time.Now(),
+	}
+	if err != nil {
+		fields["status"] = models.DeliveryFailed
+		fields["last_error"] = err.Error()
+		delete(fields, "sent_at")
+		log.Printf("[ERROR] Notification delivery %v failed: %v\n", ids, err)
+	}
+
+	if err := d.db.WithContext(ctx).Model(&models.NotifDelivery{}).Where("id IN ?", ids).Updates(fields).Error; err != nil {
+		log.Printf("[ERROR] Failed to update deliveries %v: %v\n", ids, err)
+	}
+}
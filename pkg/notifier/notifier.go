@@ -0,0 +1,304 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/outbox"
+
+	"gorm.io/gorm"
+)
+
+// Notifier turns domain events into models.Notif rows for everyone who
+// should hear about them, honouring each recipient's preferences.
+type Notifier struct {
+	db *gorm.DB
+}
+
+func Register(bus *events.Bus, db *gorm.DB) *Notifier {
+	n := &Notifier{db: db}
+
+	bus.Subscribe(events.OfferSubmitted, n.offerSubmitted)
+	bus.Subscribe(events.OfferUpdated, n.offerUpdated)
+	bus.Subscribe(events.TenderAmended, n.tenderAmended)
+	bus.Subscribe(events.TenderDeadlineSoon, n.tenderDeadlineSoon)
+	bus.Subscribe(events.TenderAwarded, n.tenderAwarded)
+	bus.Subscribe(events.TenderCancelled, n.tenderCancelled)
+
+	return n
+}
+
+func (n *Notifier) offerSubmitted(ctx context.Context, e events.Event) error {
+	tender, err := n.tender(ctx, e.TenderID)
+	if err != nil {
+		return err
+	}
+
+	recipients := n.tenderManagers(ctx, tender)
+
+	message := fmt.Sprintf("New offer #%d submitted on tender %q", e.OfferID, tender.Title)
+	return n.notify(ctx, e, recipients, models.NotifOfferSubmitted, e.OfferID, message)
+}
+
+func (n *Notifier) offerUpdated(ctx context.Context, e events.Event) error {
+	tender, err := n.tender(ctx, e.TenderID)
+	if err != nil {
+		return err
+	}
+
+	recipients := n.tenderManagers(ctx, tender)
+
+	message := fmt.Sprintf("Offer #%d on tender %q was updated", e.OfferID, tender.Title)
+	return n.notify(ctx, e, recipients, models.NotifOfferUpdated, e.OfferID, message)
+}
+
+func (n *Notifier) tenderAmended(ctx context.Context, e events.Event) error {
+	tender, err := n.tender(ctx, e.TenderID)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := n.bidders(ctx, tender.ID, 0)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Tender %q was amended. Please review your offer", tender.Title)
+	return n.notify(ctx, e, recipients, models.NotifTenderAmended, tender.ID, message)
+}
+
+func (n *Notifier) tenderDeadlineSoon(ctx context.Context, e events.Event) error {
+	tender, err := n.tender(ctx, e.TenderID)
+	if err != nil {
+		return err
+	}
+
+	bidders, err := n.bidders(ctx, tender.ID, 0)
+	if err != nil {
+		return err
+	}
+
+	managers := n.tenderManagers(ctx, tender)
+
+	message := fmt.Sprintf("Tender %q closes at %s", tender.Title, tender.Deadline.Format(constants.Layout))
+	return n.notify(ctx, e, append(bidders, managers...), models.NotifTenderDeadlineSoon, tender.ID, message)
+}
+
+func (n *Notifier) tenderAwarded(ctx context.Context, e events.Event) error {
+	tender, err := n.tender(ctx, e.TenderID)
+	if err != nil {
+		return err
+	}
+
+	var winner models.Offers
+	if err := n.db.WithContext(ctx).First(&winner, e.OfferID).Error; err != nil {
+		return err
+	}
+
+	winners := append([]uint{winner.ContractorID}, n.orgMembers(ctx, winner.OrganizationID)...)
+	message := fmt.Sprintf("Congratulations! Your offer #%d won tender %q", winner.ID, tender.Title)
+	if err := n.notify(ctx, e, winners, models.NotifTenderAwarded, tender.ID, message); err != nil {
+		return err
+	}
+
+	others, err := n.bidders(ctx, tender.ID, winner.ID)
+	if err != nil {
+		return err
+	}
+
+	message = fmt.Sprintf("Tender %q was awarded to another bidder", tender.Title)
+	return n.notify(ctx, e, exclude(others, winners), models.NotifTenderAwarded, tender.ID, message)
+}
+
+func (n *Notifier) tenderCancelled(ctx context.Context, e events.Event) error {
+	tender, err := n.tender(ctx, e.TenderID)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := n.bidders(ctx, tender.ID, 0)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Tender %q was cancelled", tender.Title)
+	return n.notify(ctx, e, recipients, models.NotifTenderCancelled, tender.ID, message)
+}
+
+func (n *Notifier) tender(ctx context.Context, id uint) (models.Tenders, error) {
+	var tender models.Tenders
+	err := n.db.WithContext(ctx).First(&tender, id).Error
+	return tender, err
+}
+
+// tenderManagers returns the tender's client and, for organization-owned
+// tenders, every member of the organization.
+func (n *Notifier) tenderManagers(ctx context.Context, tender models.Tenders) []uint {
+	return append([]uint{tender.ClientID}, n.orgMembers(ctx, tender.OrganizationID)...)
+}
+
+// bidders returns the contractors and contractor organization members of
+// every live offer on the tender, except the offer skipOfferID.
+func (n *Notifier) bidders(ctx context.Context, tenderID, skipOfferID uint) ([]uint, error) {
+	var offers []models.Offers
+	if err := n.db.WithContext(ctx).
+		Where("tender_id = ? AND id <> ? AND deleted_at IS NULL", tenderID, skipOfferID).
+		Find(&offers).Error; err != nil {
+		return nil, err
+	}
+
+	var users []uint
+	for _, offer := range offers {
+		users = append(users, offer.ContractorID)
+		users = append(users, n.orgMembers(ctx, offer.OrganizationID)...)
+	}
+	return users, nil
+}
+
+func (n *Notifier) orgMembers(ctx context.Context, orgID *uint) []uint {
+	if orgID == nil {
+		return nil
+	}
+
+	var users []uint
+	n.db.WithContext(ctx).Model(&models.OrganizationMember{}).
+		Where("organization_id = ?", *orgID).
+		Pluck("user_id", &users)
+	return users
+}
+
+// notify creates one notification per distinct recipient, skipping the user
+// who caused the event and users already notified of it, and queues its
+// delivery over the channels each recipient enabled for the type.
+func (n *Notifier) notify(ctx context.Context, e events.Event, recipients []uint, t models.NotifType, relationID uint, message string) error {
+	seen := map[uint]bool{e.ActorID: true, 0: true}
+	var users []uint
+
+	for _, userID := range recipients {
+		if !seen[userID] {
+			seen[userID] = true
+			users = append(users, userID)
+		}
+	}
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	return n.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if e.ID != "" {
+			var done []uint
+			if err := tx.Model(&models.Notif{}).
+				Where("event_id = ? AND user_id IN ?", e.ID, users).
+				Pluck("user_id", &done).Error; err != nil {
+				return err
+			}
+			if users = exclude(users, done); len(users) == 0 {
+				return nil
+			}
+		}
+
+		prefs, err := n.preferences(tx, users, t)
+		if err != nil {
+			return err
+		}
+
+		notifs := make([]models.Notif, 0, len(users))
+		for _, userID := range users {
+			notifs = append(notifs, models.Notif{
+				UserID:      userID,
+				Message:     message,
+				RelationID:  relationID,
+				Type:        t,
+				InboxHidden: !prefs[userID].InApp,
+				EventID:     e.ID,
+			})
+		}
+
+		if err := tx.Create(&notifs).Error; err != nil {
+			return err
+		}
+
+		var deliveries []models.NotifDelivery
+		for _, notif := range notifs {
+			deliveries = append(deliveries, deliveriesFor(notif, prefs[notif.UserID])...)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+		if err := tx.Create(&deliveries).Error; err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if delivery.Digest {
+				continue
+			}
+			key := fmt.Sprintf("%s:%d", models.OutboxNotifDelivery, delivery.ID)
+			if _, err := outbox.Enqueue(tx, models.OutboxNotifDelivery, key, models.NotifDeliveryPayload{DeliveryID: delivery.ID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// preferences returns the preference of every user for the type, falling
+// back to models.DefaultNotifPreference.
+func (n *Notifier) preferences(tx *gorm.DB, users []uint, t models.NotifType) (map[uint]models.NotifPreference, error) {
+	var rows []models.NotifPreference
+	if err := tx.Where("user_id IN ? AND type = ?", users, t).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	prefs := make(map[uint]models.NotifPreference, len(users))
+	for _, userID := range users {
+		prefs[userID] = models.DefaultNotifPreference(userID, t)
+	}
+	for _, row := range rows {
+		prefs[row.UserID] = row
+	}
+	return prefs, nil
+}
+
+func deliveriesFor(notif models.Notif, pref models.NotifPreference) []models.NotifDelivery {
+	var deliveries []models.NotifDelivery
+
+	add := func(channel string, digest bool) {
+		deliveries = append(deliveries, models.NotifDelivery{
+			NotifID: notif.ID,
+			UserID:  notif.UserID,
+			Channel: channel,
+			Digest:  digest,
+			Status:  models.DeliveryPending,
+		})
+	}
+
+	if pref.Email {
+		add(models.ChannelEmail, pref.Mode == models.DeliveryDigest)
+	}
+	if pref.SMS {
+		add(models.ChannelSMS, false)
+	}
+	if pref.Webhook {
+		add(models.ChannelWebhook, false)
+	}
+	return deliveries
+}
+
+func exclude(users, skip []uint) []uint {
+	skipped := make(map[uint]bool, len(skip))
+	for _, u := range skip {
+		skipped[u] = true
+	}
+
+	var out []uint
+	for _, u := range users {
+		if !skipped[u] {
+			out = append(out, u)
+		}
+	}
+	return out
+}
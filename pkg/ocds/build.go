@@ -0,0 +1,375 @@
+package ocds
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"tender_management/models"
+	"tender_management/pkg/evaluation"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// Builder turns tenders into releases.
+type Builder struct {
+	db  *gorm.DB
+	pub Publication
+}
+
+func NewBuilder(db *gorm.DB, pub Publication) *Builder {
+	return &Builder{db: db, pub: pub}
+}
+
+// OCID is the contracting process identifier of a tender.
+func (b *Builder) OCID(tenderID uint) string {
+	return fmt.Sprintf("%s-%d", b.pub.Prefix, tenderID)
+}
+
+// Changed selects the live tenders whose release date falls in [from, to):
+// those updated in the range, and those whose bids were unsealed in it.
+// Either bound may be nil.
+func Changed(db *gorm.DB, from, to *time.Time) *gorm.DB {
+	tenders := db.Model(&models.Tenders{}).Where("deleted_at IS NULL")
+	if from == nil && to == nil {
+		return tenders
+	}
+
+	updated := db.Session(&gorm.Session{NewDB: true})
+	unsealed := db.Session(&gorm.Session{NewDB: true}).Where("deadline <= ?", time.Now())
+	if from != nil {
+		updated = updated.Where("updated_at >= ?", *from)
+		unsealed = unsealed.Where("deadline >= ?", *from)
+	}
+	if to != nil {
+		updated = updated.Where("updated_at < ?", *to)
+		unsealed = unsealed.Where("deadline < ?", *to)
+	}
+	return tenders.Where(updated.Or(unsealed))
+}
+
+// Release builds the release of one live tender.
+func (b *Builder) Release(tenderID uint) (Release, error) {
+	var tender models.Tenders
+	if err := b.db.Preload("Categories").Preload("Criteria").
+		Where("id = ? AND deleted_at IS NULL", tenderID).First(&tender).Error; err != nil {
+		return Release{}, err
+	}
+
+	releases, err := b.Releases([]models.Tenders{tender})
+	if err != nil {
+		return Release{}, err
+	}
+	return releases[0], nil
+}
+
+// Releases builds the releases of tenders, which must have their
+// Categories and Criteria loaded, fetching everything else in bulk.
+func (b *Builder) Releases(tenders []models.Tenders) ([]Release, error) {
+	rel, err := b.load(tenders)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, len(tenders))
+	for i, tender := range tenders {
+		releases[i] = b.release(tender, rel)
+	}
+	return releases, nil
+}
+
+// related holds what the releases of a batch of tenders refer to.
+type related struct {
+	offers      map[uint][]models.Offers
+	attachments map[uint][]models.Attachment
+	orgs        map[uint]models.Organization
+	users       map[uint]models.Users
+	profiles    map[uint]models.ContractorProfile
+}
+
+func (b *Builder) load(tenders []models.Tenders) (related, error) {
+	rel := related{
+		offers:      make(map[uint][]models.Offers),
+		attachments: make(map[uint][]models.Attachment),
+		orgs:        make(map[uint]models.Organization),
+		users:       make(map[uint]models.Users),
+		profiles:    make(map[uint]models.ContractorProfile),
+	}
+
+	var tenderIDs, userIDs, orgIDs, contractorIDs []uint
+	for _, t := range tenders {
+		tenderIDs = append(tenderIDs, t.ID)
+		userIDs = append(userIDs, t.ClientID)
+		if t.OrganizationID != nil {
+			orgIDs = append(orgIDs, *t.OrganizationID)
+		}
+	}
+	if len(tenderIDs) == 0 {
+		return rel, nil
+	}
+
+	var offers []models.Offers
+	if err := b.db.Where("tender_id IN ? AND deleted_at IS NULL", tenderIDs).Order("id").Find(&offers).Error; err != nil {
+		return rel, err
+	}
+	for _, o := range offers {
+		rel.offers[o.TenderID] = append(rel.offers[o.TenderID], o)
+		userIDs = append(userIDs, o.ContractorID)
+		contractorIDs = append(contractorIDs, o.ContractorID)
+		if o.OrganizationID != nil {
+			orgIDs = append(orgIDs, *o.OrganizationID)
+		}
+	}
+
+	var attachments []models.Attachment
+	if err := b.db.Where("owner_type = ? AND owner_id IN ? AND deleted_at IS NULL", models.AttachmentOwnerTender, tenderIDs).
+		Order("id").Find(&attachments).Error; err != nil {
+		return rel, err
+	}
+	for _, a := range attachments {
+		rel.attachments[a.OwnerID] = append(rel.attachments[a.OwnerID], a)
+	}
+
+	// Only names are published; contact details stay private.
+	var users []models.Users
+	if err := b.db.Select("id, first_name").Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return rel, err
+	}
+	for _, u := range users {
+		rel.users[u.ID] = u
+	}
+
+	if len(orgIDs) > 0 {
+		var orgs []models.Organization
+		if err := b.db.Select("id, name").Where("id IN ?", orgIDs).Find(&orgs).Error; err != nil {
+			return rel, err
+		}
+		for _, o := range orgs {
+			rel.orgs[o.ID] = o
+		}
+	}
+
+	if len(contractorIDs) > 0 {
+		var profiles []models.ContractorProfile
+		if err := b.db.Select("user_id, legal_name, tax_id").Where("user_id IN ?", contractorIDs).Find(&profiles).Error; err != nil {
+			return rel, err
+		}
+		for _, p := range profiles {
+			rel.profiles[p.UserID] = p
+		}
+	}
+	return rel, nil
+}
+
+func (b *Builder) release(t models.Tenders, rel related) Release {
+	now := time.Now()
+	sealed := t.Deadline != nil && now.Before(*t.Deadline)
+
+	// The release changes when the tender does and when its bids unseal.
+	var date time.Time
+	for _, d := range []*time.Time{t.CreatedAt, t.UpdatedAt, t.AwardedAt} {
+		if d != nil && d.After(date) {
+			date = *d
+		}
+	}
+	if !sealed && t.Deadline != nil && t.Deadline.After(date) {
+		date = *t.Deadline
+	}
+	if date.IsZero() {
+		date = now
+	}
+
+	parties := &partyList{}
+	buyer := parties.add(rel.party(t.ClientID, t.OrganizationID, false), "buyer", "procuringEntity")
+
+	r := Release{
+		OCID:           b.OCID(t.ID),
+		ID:             fmt.Sprintf("%d-%d", t.ID, date.Unix()),
+		Date:           date,
+		InitiationType: "tender",
+		Buyer:          &buyer,
+		Tender: Tender{
+			ID:                strconv.FormatUint(uint64(t.ID), 10),
+			Title:             t.Title,
+			Description:       t.Description,
+			ProcurementMethod: "open",
+			TenderPeriod:      &Period{StartDate: t.CreatedAt, EndDate: t.Deadline},
+			Items:             items(t.Categories),
+			Documents:         b.documents(t, rel.attachments[t.ID]),
+		},
+	}
+	if !t.HideBudget {
+		r.Tender.Value = b.value(t.Budget, t.Currency)
+	}
+	r.Tender.AwardCriteria, r.Tender.AwardCriteriaDetails = awardCriteria(t.Criteria)
+
+	switch t.State {
+	case models.TenderStateCancelled:
+		r.Tag = []string{"tenderCancellation"}
+		r.Tender.Status = "cancelled"
+	case models.TenderStateAwarded:
+		r.Tag = []string{"tender", "award"}
+		r.Tender.Status = "complete"
+	default:
+		r.Tag = []string{"tender"}
+		r.Tender.Status = "active"
+	}
+
+	if !sealed {
+		b.bids(&r, t, rel, parties)
+	}
+
+	r.Parties = parties.list
+	return r
+}
+
+// bids publishes the offers of an unsealed tender, its tenderers and, once
+// awarded, the award.
+func (b *Builder) bids(r *Release, t models.Tenders, rel related, parties *partyList) {
+	r.Bids = &Bids{Details: []Bid{}}
+	r.Tender.Tenderers = []OrganizationRef{}
+	seen := make(map[string]bool)
+
+	for _, o := range rel.offers[t.ID] {
+		winner := t.State == models.TenderStateAwarded && t.AwardedOfferID != nil && *t.AwardedOfferID == o.ID
+		roles := []string{"tenderer"}
+		if winner {
+			roles = append(roles, "supplier")
+		}
+		ref := parties.add(rel.party(o.ContractorID, o.OrganizationID, true), roles...)
+
+		if !seen[ref.ID] {
+			seen[ref.ID] = true
+			r.Tender.Tenderers = append(r.Tender.Tenderers, ref)
+		}
+
+		bidID := strconv.FormatUint(uint64(o.ID), 10)
+		r.Bids.Details = append(r.Bids.Details, Bid{
+			ID:        bidID,
+			Date:      o.CreatedAt,
+			Status:    "valid",
+			Tenderers: []OrganizationRef{ref},
+			Value:     b.value(o.Price, o.Currency),
+		})
+
+		if winner {
+			r.Awards = append(r.Awards, Award{
+				ID:          fmt.Sprintf("%d-%d", t.ID, o.ID),
+				Status:      "active",
+				Date:        t.AwardedAt,
+				Value:       b.value(o.Price, o.Currency),
+				Suppliers:   []OrganizationRef{ref},
+				RelatedBids: []string{bidID},
+			})
+		}
+	}
+
+	count := len(r.Tender.Tenderers)
+	r.Tender.NumberOfTenderers = &count
+}
+
+func (b *Builder) value(amount decimal.Decimal, currency string) *Value {
+	return &Value{Amount: json.Number(amount.StringFixed(2)), Currency: currency}
+}
+
+func (b *Builder) documents(t models.Tenders, attachments []models.Attachment) []Document {
+	var docs []Document
+	if t.FileURL != "" {
+		docs = append(docs, Document{ID: "notice", DocumentType: "tenderNotice", URL: t.FileURL, DatePublished: t.CreatedAt})
+	}
+	for _, a := range attachments {
+		doc := Document{
+			ID:            fmt.Sprintf("attachment-%d", a.ID),
+			DocumentType:  "biddingDocuments",
+			Title:         a.FileName,
+			Format:        a.ContentType,
+			DatePublished: a.CreatedAt,
+		}
+		if b.pub.BaseURL != "" {
+			doc.URL = fmt.Sprintf("%s/attachments/%d/download", strings.TrimRight(b.pub.BaseURL, "/"), a.ID)
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func items(categories []models.Category) []Item {
+	var list []Item
+	for i, c := range categories {
+		list = append(list, Item{
+			ID:             strconv.Itoa(i + 1),
+			Description:    c.Name,
+			Classification: &Classification{Scheme: ClassificationScheme, ID: c.Code, Description: c.Name},
+		})
+	}
+	return list
+}
+
+// awardCriteria maps the evaluation weights to the OCDS codelist, spelling
+// out the weights when more than price counts.
+func awardCriteria(criteria []models.TenderCriterion) (string, string) {
+	if len(criteria) == 0 {
+		criteria = evaluation.DefaultCriteria
+	}
+	if len(criteria) == 1 && criteria[0].Criterion == models.CriterionPrice {
+		return "priceOnly", ""
+	}
+
+	var total float64
+	for _, c := range criteria {
+		total += c.Weight
+	}
+	details := make([]string, len(criteria))
+	for i, c := range criteria {
+		details[i] = fmt.Sprintf("%s %g%%", c.Criterion, c.Weight/total*100)
+	}
+	return "ratedCriteria", strings.Join(details, ", ")
+}
+
+// party describes a user, or the organization they acted for. Contractors
+// acting for themselves are named and identified by their profile.
+func (rel related) party(userID uint, orgID *uint, contractor bool) Party {
+	if orgID != nil {
+		return Party{ID: fmt.Sprintf("org-%d", *orgID), Name: rel.orgs[*orgID].Name}
+	}
+
+	p := Party{ID: fmt.Sprintf("user-%d", userID), Name: rel.users[userID].FirstName}
+	if profile, ok := rel.profiles[userID]; ok && contractor {
+		p.Name = profile.LegalName
+		p.Identifier = &Identifier{ID: profile.TaxID, LegalName: profile.LegalName}
+	}
+	return p
+}
+
+// partyList collects the parties of a release, merging the roles of a
+// party that appears more than once.
+type partyList struct {
+	list []Party
+}
+
+func (l *partyList) add(p Party, roles ...string) OrganizationRef {
+	for i := range l.list {
+		if l.list[i].ID == p.ID {
+			for _, role := range roles {
+				if !contains(l.list[i].Roles, role) {
+					l.list[i].Roles = append(l.list[i].Roles, role)
+				}
+			}
+			return OrganizationRef{ID: p.ID, Name: p.Name}
+		}
+	}
+	p.Roles = roles
+	l.list = append(l.list, p)
+	return OrganizationRef{ID: p.ID, Name: p.Name}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
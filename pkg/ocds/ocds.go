@@ -0,0 +1,163 @@
+// Package ocds maps tenders, their offers and awards to the Open
+// Contracting Data Standard (OCDS 1.1).
+//
+// The application keeps only the current state of a tender, so every
+// tender is published as a single release describing that state; its id
+// changes whenever the tender does. Bids are published with the bid
+// extension once the tender deadline has passed and never before.
+package ocds
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	Version = "1.1"
+
+	// BidExtension describes the bids section of a release.
+	BidExtension = "https://raw.githubusercontent.com/open-contracting-extensions/ocds_bid_extension/master/extension.json"
+
+	// ClassificationScheme is the scheme of the category codes.
+	ClassificationScheme = "CPV"
+)
+
+// Publication describes the publisher and the identifiers it uses.
+type Publication struct {
+	// Prefix is the registered OCID prefix, e.g. "ocds-a1b2c3".
+	Prefix        string
+	PublisherName string
+	PublisherURI  string
+	// BaseURL, when set, is used to link documents.
+	BaseURL string
+}
+
+// Package is the metadata shared by release and record packages.
+type Package struct {
+	URI           string    `json:"uri"`
+	Version       string    `json:"version"`
+	Extensions    []string  `json:"extensions,omitempty"`
+	PublishedDate time.Time `json:"publishedDate"`
+	Publisher     Publisher `json:"publisher"`
+}
+
+type ReleasePackage struct {
+	Package
+	Releases []Release `json:"releases"`
+}
+
+type RecordPackage struct {
+	Package
+	Records []Record `json:"records"`
+}
+
+type Publisher struct {
+	Name string `json:"name"`
+	URI  string `json:"uri,omitempty"`
+}
+
+// Record holds the releases of one contracting process. With one release
+// per tender the compiled release is that release.
+type Record struct {
+	OCID            string    `json:"ocid"`
+	Releases        []Release `json:"releases"`
+	CompiledRelease Release   `json:"compiledRelease"`
+}
+
+type Release struct {
+	OCID           string           `json:"ocid"`
+	ID             string           `json:"id"`
+	Date           time.Time        `json:"date"`
+	Tag            []string         `json:"tag"`
+	InitiationType string           `json:"initiationType"`
+	Parties        []Party          `json:"parties"`
+	Buyer          *OrganizationRef `json:"buyer,omitempty"`
+	Tender         Tender           `json:"tender"`
+	Bids           *Bids            `json:"bids,omitempty"`
+	Awards         []Award          `json:"awards,omitempty"`
+}
+
+type Party struct {
+	ID         string      `json:"id"`
+	Name       string      `json:"name"`
+	Identifier *Identifier `json:"identifier,omitempty"`
+	Roles      []string    `json:"roles"`
+}
+
+type Identifier struct {
+	ID        string `json:"id"`
+	LegalName string `json:"legalName,omitempty"`
+}
+
+type OrganizationRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Value struct {
+	Amount   json.Number `json:"amount" swaggertype:"number"`
+	Currency string      `json:"currency"`
+}
+
+type Period struct {
+	StartDate *time.Time `json:"startDate,omitempty"`
+	EndDate   *time.Time `json:"endDate,omitempty"`
+}
+
+type Classification struct {
+	Scheme      string `json:"scheme"`
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+}
+
+type Item struct {
+	ID             string          `json:"id"`
+	Description    string          `json:"description,omitempty"`
+	Classification *Classification `json:"classification,omitempty"`
+}
+
+type Document struct {
+	ID            string     `json:"id"`
+	DocumentType  string     `json:"documentType,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	URL           string     `json:"url,omitempty"`
+	Format        string     `json:"format,omitempty"`
+	DatePublished *time.Time `json:"datePublished,omitempty"`
+}
+
+type Tender struct {
+	ID                   string            `json:"id"`
+	Title                string            `json:"title"`
+	Description          string            `json:"description,omitempty"`
+	Status               string            `json:"status"`
+	Value                *Value            `json:"value,omitempty"`
+	ProcurementMethod    string            `json:"procurementMethod"`
+	AwardCriteria        string            `json:"awardCriteria,omitempty"`
+	AwardCriteriaDetails string            `json:"awardCriteriaDetails,omitempty"`
+	TenderPeriod         *Period           `json:"tenderPeriod,omitempty"`
+	Items                []Item            `json:"items,omitempty"`
+	NumberOfTenderers    *int              `json:"numberOfTenderers,omitempty"`
+	Tenderers            []OrganizationRef `json:"tenderers,omitempty"`
+	Documents            []Document        `json:"documents,omitempty"`
+}
+
+type Bids struct {
+	Details []Bid `json:"details"`
+}
+
+type Bid struct {
+	ID        string            `json:"id"`
+	Date      *time.Time        `json:"date,omitempty"`
+	Status    string            `json:"status"`
+	Tenderers []OrganizationRef `json:"tenderers"`
+	Value     *Value            `json:"value,omitempty"`
+}
+
+type Award struct {
+	ID          string            `json:"id"`
+	Status      string            `json:"status"`
+	Date        *time.Time        `json:"date,omitempty"`
+	Value       *Value            `json:"value,omitempty"`
+	Suppliers   []OrganizationRef `json:"suppliers"`
+	RelatedBids []string          `json:"relatedBids,omitempty"`
+}
@@ -0,0 +1,307 @@
+package ocds
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"tender_management/models"
+	"testing"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	schemaBase    = "https://standard.open-contracting.org/schema/1__1__5/"
+	releaseSchema = schemaBase + "release-schema.json"
+)
+
+var publication = Publication{
+	Prefix:        "ocds-a1b2c3",
+	PublisherName: "Tender Management",
+	PublisherURI:  "https://tenders.example.uz",
+	BaseURL:       "https://tenders.example.uz/api/",
+}
+
+// compileSchema compiles a package schema from testdata/schema, with the
+// bid extension applied to the release schema it refers to.
+func compileSchema(t *testing.T, name string) *jsonschema.Schema {
+	t.Helper()
+
+	release := readJSON(t, "release-schema.json")
+	mergePatch(release, readJSON(t, "bid-extension-release-schema.json"))
+	extended, err := json.Marshal(release)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft4
+	c.AssertFormat = true
+	if err := c.AddResource(releaseSchema, bytes.NewReader(extended)); err != nil {
+		t.Fatalf("add release schema: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", "schema", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddResource(schemaBase+name, bytes.NewReader(data)); err != nil {
+		t.Fatalf("add %s: %v", name, err)
+	}
+
+	schema, err := c.Compile(schemaBase + name)
+	if err != nil {
+		t.Fatalf("compile %s: %v", name, err)
+	}
+	return schema
+}
+
+func readJSON(t *testing.T, name string) map[string]interface{} {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "schema", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return doc
+}
+
+// mergePatch applies an RFC 7386 JSON Merge Patch to target.
+func mergePatch(target, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		sub, ok := value.(map[string]interface{})
+		if !ok {
+			target[key] = value
+			continue
+		}
+		existing, ok := target[key].(map[string]interface{})
+		if !ok {
+			existing = map[string]interface{}{}
+			target[key] = existing
+		}
+		mergePatch(existing, sub)
+	}
+}
+
+// validate checks data against schema, decoding numbers exactly.
+func validate(t *testing.T, schema *jsonschema.Schema, data []byte) {
+	t.Helper()
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, data)
+	}
+	if err := schema.Validate(doc); err != nil {
+		t.Fatalf("%#v\n%s", err, data)
+	}
+}
+
+// openFixtures stores an awarded tender with two bids, an open tender
+// whose bid is still sealed and a cancelled tender without bids.
+func openFixtures(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "ocds.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	// SQLite has no GIN indexes; full text search is not exported anyway.
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&models.Tenders{}); err != nil {
+		t.Fatal(err)
+	}
+	delete(stmt.Schema.LookUpField("search_vector").TagSettings, "INDEX")
+
+	if err := db.AutoMigrate(&models.Users{}, &models.Organization{}, &models.ContractorProfile{}, &models.Category{},
+		&models.Tenders{}, &models.TenderCriterion{}, &models.Offers{}, &models.Attachment{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	price := func(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+	orgID := uint(10)
+	awardedOfferID := uint(2)
+
+	fixtures := []interface{}{
+		&[]models.Users{
+			{ID: 1, FirstName: "Dilnoza", Email: "client@example.uz", PhoneNumber: "+998901000001", Role: "client"},
+			{ID: 2, FirstName: "Aziz", Email: "aziz@example.uz", PhoneNumber: "+998901000002", Role: "contractor"},
+			{ID: 3, FirstName: "Bekzod", Email: "bekzod@example.uz", PhoneNumber: "+998901000003", Role: "contractor"},
+		},
+		&models.Organization{ID: orgID, Name: "Qurilish Invest LLC", Kind: "contractor"},
+		&models.ContractorProfile{UserID: 2, LegalName: "Aziz Build LLC", TaxID: "301234567", Address: "Tashkent"},
+		&models.Category{ID: 1, Code: "45000000-7", Name: "Construction work", Path: "1"},
+		&[]models.Tenders{
+			{
+				ID: 1, Title: "School roof repair", Description: "Replace the roof of school No. 5",
+				Deadline: at(-48 * time.Hour), Budget: price("125000000.00"), Currency: "UZS", AcceptedCurrencies: "UZS,USD",
+				FileURL: "https://tenders.example.uz/files/notice-1.pdf", ClientID: 1, State: models.TenderStateAwarded,
+				AwardedOfferID: &awardedOfferID, AwardedAt: at(-24 * time.Hour),
+				CreatedAt: at(-240 * time.Hour), UpdatedAt: at(-24 * time.Hour),
+				Categories: []models.Category{{ID: 1, Code: "45000000-7", Name: "Construction work", Path: "1"}},
+				Criteria: []models.TenderCriterion{
+					{Criterion: models.CriterionPrice, Weight: 70},
+					{Criterion: models.CriterionDelivery, Weight: 30},
+				},
+			},
+			{
+				ID: 2, Title: "Office furniture", Description: "Desks and chairs",
+				Deadline: at(72 * time.Hour), Budget: price("40000000.00"), Currency: "UZS", AcceptedCurrencies: "UZS",
+				HideBudget: true, ClientID: 1, State: models.TenderStateOpen,
+				CreatedAt: at(-24 * time.Hour), UpdatedAt: at(-24 * time.Hour),
+			},
+			{
+				ID: 3, Title: "Road markings", Description: "Repaint road markings",
+				Deadline: at(-24 * time.Hour), Budget: price("9000.00"), Currency: "USD", AcceptedCurrencies: "USD",
+				ClientID: 1, State: models.TenderStateCancelled,
+				CreatedAt: at(-120 * time.Hour), UpdatedAt: at(-12 * time.Hour),
+			},
+		},
+		&[]models.Offers{
+			{
+				ID: 1, TenderID: 1, ContractorID: 2, Price: price("118500000.00"), Currency: "UZS",
+				NormalizedPrice: price("118500000.00"), ExchangeRate: price("1"),
+				DeliveryTime: at(30 * 24 * time.Hour), Comments: "Materials included", Status: true, CreatedAt: at(-72 * time.Hour),
+			},
+			{
+				ID: 2, TenderID: 1, ContractorID: 3, OrganizationID: &orgID, Price: price("9100.00"), Currency: "USD",
+				NormalizedPrice: price("115928902.00"), ExchangeRate: price("12739.4400000000"), RateDate: at(-48 * time.Hour),
+				DeliveryTime: at(20 * 24 * time.Hour), Comments: "Two crews", Status: true, CreatedAt: at(-60 * time.Hour),
+			},
+			{
+				ID: 3, TenderID: 2, ContractorID: 2, Price: price("38000000.00"), Currency: "UZS",
+				NormalizedPrice: price("38000000.00"), ExchangeRate: price("1"),
+				DeliveryTime: at(10 * 24 * time.Hour), Comments: "In stock", Status: true, CreatedAt: at(-2 * time.Hour),
+			},
+		},
+		&models.Attachment{
+			OwnerType: models.AttachmentOwnerTender, OwnerID: 1, FileName: "specification.pdf", ContentType: "application/pdf",
+			Size: 1024, SHA256: strings.Repeat("a", 64), StorageKey: "tenders/1/specification.pdf", UploadedBy: 1, CreatedAt: at(-200 * time.Hour),
+		},
+	}
+	for _, f := range fixtures {
+		if err := db.Create(f).Error; err != nil {
+			t.Fatalf("create %T: %v", f, err)
+		}
+	}
+	return db
+}
+
+func TestReleasePackageValidates(t *testing.T) {
+	db := openFixtures(t)
+	b := NewBuilder(db, publication)
+
+	var buf bytes.Buffer
+	if err := b.WriteReleasePackage(&buf, "https://tenders.example.uz/api/ocds/releases", Changed(db, nil, nil)); err != nil {
+		t.Fatalf("WriteReleasePackage: %v", err)
+	}
+	validate(t, compileSchema(t, "release-package-schema.json"), buf.Bytes())
+
+	var pkg ReleasePackage
+	if err := json.Unmarshal(buf.Bytes(), &pkg); err != nil {
+		t.Fatal(err)
+	}
+	if len(pkg.Releases) != 3 {
+		t.Fatalf("got %d releases, want 3", len(pkg.Releases))
+	}
+
+	awarded, open, cancelled := pkg.Releases[0], pkg.Releases[1], pkg.Releases[2]
+	if awarded.Bids == nil || len(awarded.Bids.Details) != 2 || len(awarded.Awards) != 1 {
+		t.Errorf("awarded tender: bids %+v, awards %+v", awarded.Bids, awarded.Awards)
+	}
+	if open.Bids != nil || open.Tender.Value != nil {
+		t.Errorf("open tender published its sealed bids or hidden budget: bids %+v, value %+v", open.Bids, open.Tender.Value)
+	}
+	if cancelled.Tender.Status != "cancelled" || cancelled.Tag[0] != "tenderCancellation" {
+		t.Errorf("cancelled tender: status %q, tag %v", cancelled.Tender.Status, cancelled.Tag)
+	}
+}
+
+func TestRecordPackageValidates(t *testing.T) {
+	db := openFixtures(t)
+	b := NewBuilder(db, publication)
+
+	var buf bytes.Buffer
+	if err := b.WriteRecordPackage(&buf, "https://tenders.example.uz/api/ocds/records", Changed(db, nil, nil)); err != nil {
+		t.Fatalf("WriteRecordPackage: %v", err)
+	}
+	validate(t, compileSchema(t, "record-package-schema.json"), buf.Bytes())
+}
+
+func TestSingleReleaseValidates(t *testing.T) {
+	db := openFixtures(t)
+	b := NewBuilder(db, publication)
+
+	release, err := b.Release(1)
+	if err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	data, err := json.Marshal(b.NewReleasePackage("https://tenders.example.uz/api/ocds/tenders/1", release))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate(t, compileSchema(t, "release-package-schema.json"), data)
+}
+
+// The schemas must catch what the export could get wrong, or the tests
+// above prove nothing.
+func TestSchemaRejectsInvalidRelease(t *testing.T) {
+	db := openFixtures(t)
+	b := NewBuilder(db, publication)
+	schema := compileSchema(t, "release-package-schema.json")
+
+	release, err := b.Release(1)
+	if err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	broken := map[string]func(r *Release){
+		"tender status": func(r *Release) { r.Tender.Status = "awarded" },
+		"bid status":    func(r *Release) { r.Bids.Details[0].Status = "won" },
+		"currency":      func(r *Release) { r.Tender.Value.Currency = "SUM" },
+		"release tag":   func(r *Release) { r.Tag = []string{} },
+	}
+	for name, breakIt := range broken {
+		r := release
+		r.Tender.Value = &Value{Amount: release.Tender.Value.Amount, Currency: release.Tender.Value.Currency}
+		r.Bids = &Bids{Details: append([]Bid(nil), release.Bids.Details...)}
+		breakIt(&r)
+
+		data, err := json.Marshal(b.NewReleasePackage("https://tenders.example.uz/api/ocds/tenders/1", r))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		var doc interface{}
+		if err := dec.Decode(&doc); err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.Validate(doc); err == nil {
+			t.Errorf("%s: invalid release passed validation", name)
+		}
+	}
+}
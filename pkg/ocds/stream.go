@@ -0,0 +1,87 @@
+package ocds
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"tender_management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// batchSize is how many tenders are turned into releases at a time.
+const batchSize = 100
+
+// WriteReleasePackage streams a ReleasePackage with the release of every
+// tender selected by tenders, e.g. Changed(db, from, to), so large exports
+// never sit in memory.
+func (b *Builder) WriteReleasePackage(w io.Writer, uri string, tenders *gorm.DB) error {
+	return b.stream(w, b.header(uri), "releases", tenders, func(r Release) interface{} {
+		return r
+	})
+}
+
+// WriteRecordPackage streams a RecordPackage like WriteReleasePackage.
+func (b *Builder) WriteRecordPackage(w io.Writer, uri string, tenders *gorm.DB) error {
+	return b.stream(w, b.header(uri), "records", tenders, func(r Release) interface{} {
+		return Record{OCID: r.OCID, Releases: []Release{r}, CompiledRelease: r}
+	})
+}
+
+// NewReleasePackage wraps releases built in memory.
+func (b *Builder) NewReleasePackage(uri string, releases ...Release) ReleasePackage {
+	return ReleasePackage{Package: b.header(uri), Releases: releases}
+}
+
+func (b *Builder) header(uri string) Package {
+	return Package{
+		URI:           uri,
+		Version:       Version,
+		Extensions:    []string{BidExtension},
+		PublishedDate: time.Now().UTC(),
+		Publisher:     Publisher{Name: b.pub.PublisherName, URI: b.pub.PublisherURI},
+	}
+}
+
+// stream writes the package metadata with an array under key, filled
+// batch by batch from tenders in id order.
+func (b *Builder) stream(w io.Writer, h Package, key string, tenders *gorm.DB, item func(Release) interface{}) error {
+	head, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	head = bytes.TrimSuffix(head, []byte("}"))
+	if _, err := io.WriteString(w, string(head)+`,"`+key+`":[`); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	first := true
+	var batch []models.Tenders
+	result := tenders.Preload("Categories").Preload("Criteria").
+		FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+			releases, err := b.Releases(batch)
+			if err != nil {
+				return err
+			}
+			for _, r := range releases {
+				if !first {
+					if _, err := io.WriteString(w, ","); err != nil {
+						return err
+					}
+				}
+				first = false
+				if err := enc.Encode(item(r)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	_, err = io.WriteString(w, "]}\n")
+	return err
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"tender_management/validation"
+	"time"
+)
+
+// NewWebhookClient returns the HTTP client for posting to user supplied
+// URLs. Unless allowPrivate is set it refuses to connect to loopback,
+// private and link-local addresses, whatever the URL's host resolves to,
+// so webhooks cannot reach internal services.
+func NewWebhookClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !validation.IsPublicIP(ip) {
+				return fmt.Errorf("refusing to connect to non-public address %s", host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
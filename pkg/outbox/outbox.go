@@ -0,0 +1,84 @@
+// Package outbox implements the transactional outbox: side effects such as
+// domain events, emails and webhooks are stored in the same transaction as
+// the business change and delivered afterwards by a Relay.
+package outbox
+
+import (
+	"encoding/json"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Enqueue stores a message of the given kind for delivery. Pass the
+// transaction of the business change as tx. An empty key gets a random
+// one; enqueueing the same key twice keeps only the first message.
+func Enqueue(tx *gorm.DB, kind, key string, payload interface{}) (models.OutboxMessage, error) {
+	if key == "" {
+		random, err := utils.RandomHex(16)
+		if err != nil {
+			return models.OutboxMessage{}, err
+		}
+		key = kind + ":" + random
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return models.OutboxMessage{}, err
+	}
+
+	msg := models.OutboxMessage{
+		Kind:           kind,
+		IdempotencyKey: key,
+		Payload:        string(body),
+		Status:         models.OutboxPending,
+		NextAttemptAt:  time.Now(),
+	}
+
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "idempotency_key"}},
+		DoNothing: true,
+	}).Create(&msg).Error
+	return msg, err
+}
+
+// Publish stores a domain event. The relay hands it to the in-process
+// subscribers and to Redis pub/sub once tx commits.
+func Publish(tx *gorm.DB, e events.Event) error {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now()
+	}
+	if e.ID == "" {
+		random, err := utils.RandomHex(16)
+		if err != nil {
+			return err
+		}
+		e.ID = string(e.Type) + ":" + random
+	}
+
+	_, err := Enqueue(tx, models.OutboxEvent, e.ID, e)
+	return err
+}
+
+// Email stores an email to be sent once tx commits.
+func Email(tx *gorm.DB, to, subject, body string) error {
+	_, err := Enqueue(tx, models.OutboxEmail, "", models.EmailPayload{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	})
+	return err
+}
+
+// Webhook stores a JSON POST of body to url, made once tx commits.
+func Webhook(tx *gorm.DB, url, body string) error {
+	_, err := Enqueue(tx, models.OutboxWebhook, "", models.WebhookPayload{
+		URL:  url,
+		Body: body,
+	})
+	return err
+}
@@ -0,0 +1,197 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"tender_management/config"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/mailer"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	batchSize = 50
+	// MaxAttempts is how many times a message is tried before it is marked
+	// failed.
+	MaxAttempts = 10
+	// lease is how long a claimed message is hidden from other relays. If
+	// the process dies mid-delivery, the message is retried after it.
+	lease = 5 * time.Minute
+
+	// IdempotencyHeader carries the message key on outgoing webhooks.
+	IdempotencyHeader = "Idempotency-Key"
+)
+
+// Handler delivers one message. Returning an error schedules a retry.
+type Handler func(ctx context.Context, msg models.OutboxMessage) error
+
+// Relay delivers pending outbox messages to the handler of their kind.
+type Relay struct {
+	db       *gorm.DB
+	handlers map[string]Handler
+}
+
+func NewRelay(db *gorm.DB) *Relay {
+	return &Relay{
+		db:       db,
+		handlers: make(map[string]Handler),
+	}
+}
+
+func (r *Relay) Handle(kind string, h Handler) {
+	r.handlers[kind] = h
+}
+
+// RelayBatch claims up to batchSize due messages and delivers them. It is
+// safe to run from several processes at once.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var msgs []models.OutboxMessage
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, now).
+			Order("id ASC").Limit(batchSize).
+			Find(&msgs).Error; err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(msgs))
+		for _, msg := range msgs {
+			ids = append(ids, msg.ID)
+		}
+		return tx.Model(&models.OutboxMessage{}).Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, msg := range msgs {
+		r.finish(ctx, msg, r.deliver(ctx, msg))
+	}
+	return len(msgs), nil
+}
+
+func (r *Relay) deliver(ctx context.Context, msg models.OutboxMessage) error {
+	h, ok := r.handlers[msg.Kind]
+	if !ok {
+		return fmt.Errorf("no handler for outbox kind %q", msg.Kind)
+	}
+	return h(ctx, msg)
+}
+
+func (r *Relay) finish(ctx context.Context, msg models.OutboxMessage, err error) {
+	now := time.Now()
+	attempts := msg.Attempts + 1
+	fields := map[string]interface{}{
+		"attempts":     attempts,
+		"status":       models.OutboxSent,
+		"processed_at": now,
+		"last_error":   "",
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Outbox message %d (%s) attempt %d failed: %v\n", msg.ID, msg.Kind, attempts, err)
+		fields["last_error"] = err.Error()
+		if attempts >= MaxAttempts {
+			fields["status"] = models.OutboxFailed
+		} else {
+			fields["status"] = models.OutboxPending
+			fields["next_attempt_at"] = now.Add(Backoff(attempts))
+			delete(fields, "processed_at")
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.OutboxMessage{}).Where("id = ?", msg.ID).Updates(fields).Error; err != nil {
+		log.Printf("[ERROR] Failed to update outbox message %d: %v\n", msg.ID, err)
+	}
+}
+
+// Backoff returns the wait before retry number attempt: 30s doubling each
+// time, capped at six hours.
+func Backoff(attempt int) time.Duration {
+	wait := 30 * time.Second
+	for i := 1; i < attempt && wait < 6*time.Hour; i++ {
+		wait *= 2
+	}
+	if wait > 6*time.Hour {
+		wait = 6 * time.Hour
+	}
+	return wait
+}
+
+// EventHandler passes events to the in-process subscribers, then publishes
+// them on the Redis channel "events:<type>" for other services. Subscribers
+// may see an event more than once and should dedupe on Event.ID.
+func EventHandler(bus *events.Bus, rdb *redis.Client) Handler {
+	return func(ctx context.Context, msg models.OutboxMessage) error {
+		var e events.Event
+		if err := json.Unmarshal([]byte(msg.Payload), &e); err != nil {
+			return err
+		}
+
+		if err := bus.Dispatch(ctx, e); err != nil {
+			return err
+		}
+		return rdb.Publish(ctx, "events:"+string(e.Type), msg.Payload).Err()
+	}
+}
+
+func EmailHandler(cfg *config.Config) Handler {
+	return func(ctx context.Context, msg models.OutboxMessage) error {
+		var p models.EmailPayload
+		if err := json.Unmarshal([]byte(msg.Payload), &p); err != nil {
+			return err
+		}
+		return mailer.Send(cfg, p.To, p.Subject, p.Body)
+	}
+}
+
+func WebhookHandler(client *http.Client) Handler {
+	return func(ctx context.Context, msg models.OutboxMessage) error {
+		var p models.WebhookPayload
+		if err := json.Unmarshal([]byte(msg.Payload), &p); err != nil {
+			return err
+		}
+		return PostJSON(ctx, client, p.URL, msg.IdempotencyKey, []byte(p.Body))
+	}
+}
+
+// PostJSON posts body to url with the idempotency key header and treats any
+// non-2xx response as a failure.
+func PostJSON(ctx context.Context, client *http.Client, url, key string, body []byte) error {
+	if url == "" {
+		return fmt.Errorf("no webhook url configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyHeader, key)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
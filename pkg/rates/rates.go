@@ -0,0 +1,293 @@
+// Package rates imports Central Bank exchange rates and converts amounts
+// between currencies at the rates in force on a given date.
+//
+// Rates are stored against the base currency, the configured default
+// currency (UZS): the value of one unit of a foreign currency in it. Other
+// pairs are crossed through the base.
+package rates
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"tender_management/models"
+	"tender_management/validation"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Places is the precision stored exchange rates and cross rates keep.
+const Places = 10
+
+// ErrNoRate is returned when no rate was published for a currency on or
+// before the date asked for.
+var ErrNoRate = errors.New("no exchange rate")
+
+var dateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// ParseCSV reads rows with a "currency,rate,date[,nominal]" header. The
+// Central Bank column names Ccy and Nominal are accepted as well.
+func ParseCSV(r io.Reader) ([]models.ExchangeRateRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty file")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name == "ccy" {
+			name = "currency"
+		}
+		columns[name] = i
+	}
+	for _, name := range []string{"currency", "rate", "date"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing %q column", name)
+		}
+	}
+	nominalCol, hasNominal := columns["nominal"]
+
+	rows := make([]models.ExchangeRateRow, 0, len(records)-1)
+	for n, record := range records[1:] {
+		if len(record) <= columns["currency"] || len(record) <= columns["rate"] || len(record) <= columns["date"] {
+			return nil, fmt.Errorf("line %d: too few columns", n+2)
+		}
+		row := models.ExchangeRateRow{
+			Currency: record[columns["currency"]],
+			Rate:     record[columns["rate"]],
+			Date:     record[columns["date"]],
+		}
+		if hasNominal && len(record) > nominalCol {
+			row.Nominal = record[nominalCol]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// jsonRate is an entry of a JSON rate file: either models.ExchangeRateRow
+// or the Central Bank's {"Ccy", "Rate", "Nominal", "Date"}, with numbers
+// quoted or not.
+type jsonRate struct {
+	Ccy      string
+	Currency string
+	Rate     json.Number
+	Nominal  json.Number
+	Date     string
+}
+
+// ParseJSON reads an array of rates as published by the Central Bank, or
+// of models.ExchangeRateRow.
+func ParseJSON(r io.Reader) ([]models.ExchangeRateRow, error) {
+	var entries []jsonRate
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+
+	rows := make([]models.ExchangeRateRow, 0, len(entries))
+	for _, e := range entries {
+		currency := e.Currency
+		if currency == "" {
+			currency = e.Ccy
+		}
+		rows = append(rows, models.ExchangeRateRow{
+			Currency: currency,
+			Rate:     e.Rate.String(),
+			Nominal:  e.Nominal.String(),
+			Date:     e.Date,
+		})
+	}
+	return rows, nil
+}
+
+// Import creates or replaces the rates in rows, one per currency and date,
+// recording source as where they came from. It returns the number of rates
+// imported.
+func Import(db *gorm.DB, rows []models.ExchangeRateRow, source string) (int, error) {
+	rates := make([]models.ExchangeRate, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+
+	for i, row := range rows {
+		rate, err := parseRow(row)
+		if err != nil {
+			return 0, fmt.Errorf("row %d: %w", i+1, err)
+		}
+
+		key := rate.Currency + rate.Date.Format(time.DateOnly)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		rate.Source = source
+		rates = append(rates, rate)
+	}
+	if len(rates) == 0 {
+		return 0, errors.New("no rates to import")
+	}
+
+	err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "currency"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "source"}),
+	}).CreateInBatches(&rates, 500).Error
+	if err != nil {
+		return 0, err
+	}
+	return len(rates), nil
+}
+
+func parseRow(row models.ExchangeRateRow) (models.ExchangeRate, error) {
+	currency, ok := validation.NormalizeCurrency(row.Currency)
+	if !ok {
+		return models.ExchangeRate{}, fmt.Errorf("currency %q is not an ISO 4217 code", row.Currency)
+	}
+
+	rate, err := decimal.NewFromString(strings.TrimSpace(row.Rate))
+	if err != nil || !rate.IsPositive() {
+		return models.ExchangeRate{}, fmt.Errorf("rate %q of %s must be a positive number", row.Rate, currency)
+	}
+
+	nominal := decimal.NewFromInt(1)
+	if strings.TrimSpace(row.Nominal) != "" {
+		nominal, err = decimal.NewFromString(strings.TrimSpace(row.Nominal))
+		if err != nil || !nominal.IsPositive() {
+			return models.ExchangeRate{}, fmt.Errorf("nominal %q of %s must be a positive number", row.Nominal, currency)
+		}
+	}
+
+	date, err := parseDate(row.Date)
+	if err != nil {
+		return models.ExchangeRate{}, fmt.Errorf("date %q of %s: use YYYY-MM-DD or DD.MM.YYYY", row.Date, currency)
+	}
+
+	return models.ExchangeRate{
+		Currency: currency,
+		Date:     date,
+		Rate:     rate.DivRound(nominal, Places),
+	}, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	var err error
+	for _, layout := range dateLayouts {
+		var date time.Time
+		if date, err = time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// Table holds the latest rate of each currency published on or before
+// AsOf.
+type Table struct {
+	Base  string
+	AsOf  time.Time
+	rates map[string]models.ExchangeRate
+}
+
+// Load reads the rates in force on the date of asOf against the base
+// currency.
+func Load(db *gorm.DB, base string, asOf time.Time) (Table, error) {
+	day := Day(asOf)
+
+	var latest []models.ExchangeRate
+	if err := db.Select("DISTINCT ON (currency) *").
+		Where("date <= ?", day.Format(time.DateOnly)).
+		Order("currency, date DESC").
+		Find(&latest).Error; err != nil {
+		return Table{}, err
+	}
+
+	table := Table{Base: base, AsOf: day, rates: make(map[string]models.ExchangeRate, len(latest))}
+	for _, rate := range latest {
+		table.rates[rate.Currency] = rate
+	}
+	return table, nil
+}
+
+// Rates lists the rates of the table by currency.
+func (t Table) Rates() []models.ExchangeRate {
+	list := make([]models.ExchangeRate, 0, len(t.rates))
+	for _, rate := range t.rates {
+		list = append(list, rate)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Currency < list[j].Currency })
+	return list
+}
+
+// Quote is the rate converting amounts of one currency into another.
+// Date is when the rates it derives from were published, the older of the
+// two for a cross rate, and nil when both currencies are the same.
+type Quote struct {
+	Rate decimal.Decimal
+	Date *time.Time
+}
+
+// Quote returns the rate from one currency into another. It fails with
+// ErrNoRate if either currency has no rate in the table.
+func (t Table) Quote(from, to string) (Quote, error) {
+	if from == to {
+		return Quote{Rate: decimal.NewFromInt(1)}, nil
+	}
+
+	fromRate, fromDate, err := t.rate(from)
+	if err != nil {
+		return Quote{}, err
+	}
+	toRate, toDate, err := t.rate(to)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	quote := Quote{Rate: fromRate.DivRound(toRate, Places)}
+	switch {
+	case fromDate == nil:
+		quote.Date = toDate
+	case toDate == nil || fromDate.Before(*toDate):
+		quote.Date = fromDate
+	default:
+		quote.Date = toDate
+	}
+	return quote, nil
+}
+
+// Convert returns amount in the target currency of the quote, rounded to
+// cents.
+func (q Quote) Convert(amount decimal.Decimal) decimal.Decimal {
+	return amount.Mul(q.Rate).Round(2)
+}
+
+// rate returns the value of one unit of currency in the base currency.
+func (t Table) rate(currency string) (decimal.Decimal, *time.Time, error) {
+	if currency == t.Base {
+		return decimal.NewFromInt(1), nil, nil
+	}
+	rate, ok := t.rates[currency]
+	if !ok {
+		return decimal.Decimal{}, nil, fmt.Errorf("%w for %s on or before %s", ErrNoRate, currency, t.AsOf.Format(time.DateOnly))
+	}
+	date := rate.Date
+	return rate.Rate, &date, nil
+}
+
+// Day truncates t to its calendar date in its own location, as UTC
+// midnight like the dates read from the database.
+func Day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
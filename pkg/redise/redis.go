@@ -45,4 +45,25 @@ func (r *RedisDB) Exists(ctx context.Context, key string) (bool, error) {
 
 func (r *RedisDB) SetEx(ctx context.Context, key string, value interface{}, duration time.Duration) error {
 	return r.Rdb.SetEx(ctx, key, value, duration).Err()
-}
\ No newline at end of file
+}
+
+// SetNX sets key only if it does not exist yet, expiring after duration,
+// and reports whether it was set.
+func (r *RedisDB) SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error) {
+	return r.Rdb.SetNX(ctx, key, value, duration).Result()
+}
+
+// Incr increments the counter at key and returns its new value. The
+// counter expires after duration from its first increment.
+func (r *RedisDB) Incr(ctx context.Context, key string, duration time.Duration) (int64, error) {
+	count, err := r.Rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := r.Rdb.Expire(ctx, key, duration).Err(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
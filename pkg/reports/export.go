@@ -0,0 +1,56 @@
+package reports
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"tender_management/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// WriteSummaryCSV writes the rows of a Summary report as CSV.
+func WriteSummaryCSV(w io.Writer, rows []models.ReportRow) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"Group", "Label", "Currency", "Tenders", "Awarded", "Awarded budget", "Awarded value",
+		"Savings", "Savings %", "Average bids", "Average days to award"}); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		if err := out.Write([]string{r.Group, r.Label, r.Currency, strconv.FormatInt(r.Tenders, 10),
+			strconv.FormatInt(r.Awarded, 10), money(r.AwardedBudget), money(r.AwardedValue), money(r.Savings),
+			fixed(r.SavingsPct), fixed(r.AvgBids), fixed(r.AvgDaysToAward)}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// WriteContractorsCSV writes the rows of a TopContractors report as CSV.
+func WriteContractorsCSV(w io.Writer, rows []models.ContractorReportRow) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"Contractor ID", "Legal name", "Currency", "Wins", "Awarded value", "Savings"}); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		if err := out.Write([]string{strconv.FormatUint(uint64(r.ContractorID), 10), r.LegalName, r.Currency,
+			strconv.FormatInt(r.Wins, 10), money(r.AwardedValue), money(r.Savings)}); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func money(v decimal.Decimal) string {
+	return v.StringFixed(2)
+}
+
+func fixed(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,149 @@
+// Package reports answers the procurement analytics queries: savings
+// against budget, bids per tender, time to award and top contractors.
+//
+// The reports read report_tender_facts, a materialised view with one row
+// per tender, instead of the transactional tables. The view is created by
+// the initial migration. Refresh rebuilds it; the scheduler does so
+// periodically and admins can force it.
+package reports
+
+import (
+	"errors"
+	"tender_management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const view = "report_tender_facts"
+
+// ErrGroupBy is returned for an unsupported Query.GroupBy.
+var ErrGroupBy = errors.New("group_by must be month, category, client or organization")
+
+// Refresh rebuilds the view without blocking readers.
+func Refresh(db *gorm.DB) error {
+	return db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + view).Error
+}
+
+// Query selects the tenders a report covers.
+type Query struct {
+	GroupBy string
+	// From and To bound the tender publication date, To exclusive.
+	From, To *time.Time
+	// UserID limits the report to tenders the user published or that
+	// belong to one of their organizations; 0 covers every tender.
+	UserID uint
+}
+
+var groups = map[string]struct{ key, label string }{
+	models.ReportByMonth:        {"to_char(f.month, 'YYYY-MM')", "''"},
+	models.ReportByCategory:     {"categories.code", "MIN(categories.name)"},
+	models.ReportByClient:       {"f.client_id::text", "MIN(users.email)"},
+	models.ReportByOrganization: {"COALESCE(f.organization_id::text, 'none')", "COALESCE(MIN(organizations.name), '')"},
+}
+
+// Summary aggregates the covered tenders per group and tender currency, in
+// group order. Amounts are only summed within a currency, so a group with
+// tenders in several currencies has a row for each. In the category report
+// a tender counts towards each of its categories.
+func Summary(db *gorm.DB, q Query) (models.Report, error) {
+	group, ok := groups[q.GroupBy]
+	if !ok {
+		return models.Report{}, ErrGroupBy
+	}
+
+	query := q.scope(db.Table(view + " AS f"))
+	switch q.GroupBy {
+	case models.ReportByCategory:
+		query = query.
+			Joins("JOIN tender_categories ON tender_categories.tender_id = f.tender_id").
+			Joins("JOIN categories ON categories.id = tender_categories.category_id")
+	case models.ReportByClient:
+		query = query.Joins("LEFT JOIN users ON users.id = f.client_id")
+	case models.ReportByOrganization:
+		query = query.Joins("LEFT JOIN organizations ON organizations.id = f.organization_id")
+	}
+
+	var rows []models.ReportRow
+	if err := query.
+		Select(group.key + ` AS "group", ` + group.label + ` AS label, f.currency,
+			COUNT(*) AS tenders,
+			COUNT(f.awarded_price) AS awarded,
+			COALESCE(SUM(f.budget) FILTER (WHERE f.awarded_price IS NOT NULL), 0) AS awarded_budget,
+			COALESCE(SUM(f.awarded_price), 0) AS awarded_value,
+			COALESCE(SUM(f.budget - f.awarded_price), 0) AS savings,
+			COALESCE(ROUND(AVG(f.bid_count), 2), 0) AS avg_bids,
+			COALESCE(ROUND(AVG(EXTRACT(EPOCH FROM f.awarded_at - f.created_at) / 86400)::numeric, 2), 0) AS avg_days_to_award`).
+		Group(group.key + ", f.currency").
+		Order(group.key + ", f.currency").
+		Scan(&rows).Error; err != nil {
+		return models.Report{}, err
+	}
+
+	for i := range rows {
+		if rows[i].AwardedBudget.IsPositive() {
+			rows[i].SavingsPct = rows[i].Savings.Div(rows[i].AwardedBudget).Shift(2).Truncate(2).InexactFloat64()
+		}
+	}
+	if rows == nil {
+		rows = []models.ReportRow{}
+	}
+
+	return q.report(db, rows)
+}
+
+// TopContractors ranks contractors by tenders won, then by awarded value,
+// per tender currency: a contractor who won tenders in several currencies
+// has a row for each.
+func TopContractors(db *gorm.DB, q Query, limit int) (models.Report, error) {
+	var rows []models.ContractorReportRow
+	if err := q.scope(db.Table(view + " AS f")).
+		Joins("LEFT JOIN contractor_profiles ON contractor_profiles.user_id = f.winner_id").
+		Select(`f.winner_id AS contractor_id,
+			COALESCE(MIN(contractor_profiles.legal_name), '') AS legal_name,
+			f.currency,
+			COUNT(*) AS wins,
+			SUM(f.awarded_price) AS awarded_value,
+			SUM(f.budget - f.awarded_price) AS savings`).
+		Where("f.winner_id IS NOT NULL").
+		Group("f.winner_id, f.currency").
+		Order("wins DESC, awarded_value DESC, f.winner_id, f.currency").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return models.Report{}, err
+	}
+	if rows == nil {
+		rows = []models.ContractorReportRow{}
+	}
+
+	q.GroupBy = ""
+	return q.report(db, rows)
+}
+
+func (q Query) scope(db *gorm.DB) *gorm.DB {
+	if q.From != nil {
+		db = db.Where("f.created_at >= ?", *q.From)
+	}
+	if q.To != nil {
+		db = db.Where("f.created_at < ?", *q.To)
+	}
+	if q.UserID != 0 {
+		db = db.Where("f.client_id = ? OR f.organization_id IN (?)", q.UserID,
+			db.Session(&gorm.Session{NewDB: true}).Model(&models.OrganizationMember{}).
+				Select("organization_id").Where("user_id = ?", q.UserID))
+	}
+	return db
+}
+
+func (q Query) report(db *gorm.DB, rows interface{}) (models.Report, error) {
+	var refreshed []time.Time
+	if err := db.Table(view).Limit(1).Pluck("refreshed_at", &refreshed).Error; err != nil {
+		return models.Report{}, err
+	}
+
+	report := models.Report{GroupBy: q.GroupBy, From: q.From, To: q.To, Rows: rows}
+	if len(refreshed) > 0 {
+		report.RefreshedAt = &refreshed[0]
+	}
+	return report, nil
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/outbox"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const reminderWindow = 24 * time.Hour
+
+// DeadlineReminders publishes a TenderDeadlineSoon event, once per tender,
+// for open tenders whose deadline is less than 24 hours away. It runs until
+// ctx is cancelled.
+func DeadlineReminders(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := remindDeadlines(ctx, db); err != nil {
+			log.Printf("[ERROR] Deadline reminders: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func remindDeadlines(ctx context.Context, db *gorm.DB) error {
+	now := time.Now()
+
+	var tenders []models.Tenders
+	if err := db.WithContext(ctx).
+		Where("state = ? AND deleted_at IS NULL AND reminder_sent_at IS NULL", models.TenderStateOpen).
+		Where("deadline > ? AND deadline <= ?", now, now.Add(reminderWindow)).
+		Find(&tenders).Error; err != nil {
+		return err
+	}
+
+	for _, tender := range tenders {
+		// Claiming the reminder and queueing the event together means
+		// concurrent instances cannot send it twice, and a crash cannot
+		// lose it.
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			result := tx.Model(&models.Tenders{}).
+				Where("id = ? AND reminder_sent_at IS NULL", tender.ID).
+				Update("reminder_sent_at", now)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			return outbox.Publish(tx, events.Event{
+				Type:     events.TenderDeadlineSoon,
+				TenderID: tender.ID,
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
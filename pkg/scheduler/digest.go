@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"tender_management/pkg/notifier"
+	"time"
+)
+
+// Digests periodically emails pending digest notifications. The dispatcher
+// limits each user to one digest per day, so interval only controls how
+// soon after that day has passed the next digest goes out.
+func Digests(ctx context.Context, dispatcher *notifier.Dispatcher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := dispatcher.SendDigests(ctx); err != nil {
+			log.Printf("[ERROR] Digests: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"tender_management/pkg/outbox"
+	"time"
+)
+
+// OutboxRelay delivers pending outbox messages every interval, draining
+// the backlog in batches. It runs until ctx is cancelled.
+func OutboxRelay(ctx context.Context, relay *outbox.Relay, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := relay.RelayBatch(ctx)
+			if err != nil {
+				log.Printf("[ERROR] Outbox relay: %v\n", err)
+			}
+			if err != nil || n == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"tender_management/pkg/reports"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReportRefresh rebuilds the analytics snapshot every interval until ctx
+// is cancelled, so reports lag the live data by at most that long.
+func ReportRefresh(ctx context.Context, db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := reports.Refresh(db.WithContext(ctx)); err != nil {
+			log.Printf("[ERROR] Report refresh: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"tender_management/service"
+	"time"
+)
+
+// revalueWindow is how long after its deadline a tender keeps being
+// revalued, so that rates imported late for the deadline day still reach
+// its offers.
+const revalueWindow = 72 * time.Hour
+
+// Revaluations values the offers on open tenders at their deadline rates
+// once the deadline has passed, until ctx is cancelled. Revaluing is
+// idempotent, so overlapping runs only repeat work.
+func Revaluations(ctx context.Context, tenders *service.TenderService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := tenders.Revalue(time.Now().Add(-revalueWindow)); err != nil {
+			log.Printf("[ERROR] Revaluations: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
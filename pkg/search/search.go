@@ -0,0 +1,130 @@
+// Package search maintains the full-text index of tenders and turns user
+// input into Postgres text search queries.
+//
+// There is no Uzbek text search configuration in Postgres, so every document
+// is indexed three times: with 'simple' (exact words, used for Uzbek in both
+// Latin and Cyrillic script), 'english' and 'russian' (stemmed). A query in a
+// known language is matched against its own configuration; without a
+// language the three are OR-ed.
+package search
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"tender_management/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LangUzbek   = "uz"
+	LangRussian = "ru"
+	LangEnglish = "en"
+)
+
+// configs maps a language to its text search configuration. The empty
+// language searches all of them.
+var configs = map[string][]string{
+	"":          {"simple", "english", "russian"},
+	LangUzbek:   {"simple"},
+	LangRussian: {"russian"},
+	LangEnglish: {"english"},
+}
+
+// ts_headline returns the text around the matches as written, so matches
+// are not marked with HTML there: a title or description holding markup
+// would reach clients that render highlights as HTML. They are marked with
+// the STX and ETX control characters instead, and Highlight escapes the
+// text before turning the markers into <b> and </b>.
+const (
+	startSel = "\x02"
+	stopSel  = "\x03"
+)
+
+// TitleHeadlineOptions mark every match in a title.
+const TitleHeadlineOptions = `StartSel="` + startSel + `", StopSel="` + stopSel + `", HighlightAll=true`
+
+// HeadlineOptions mark matches and cut descriptions to a couple of short
+// fragments.
+const HeadlineOptions = `StartSel="` + startSel + `", StopSel="` + stopSel + `", MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" ... "`
+
+var selectors = strings.NewReplacer(startSel, "<b>", stopSel, "</b>")
+
+// Highlight turns a ts_headline result built with HeadlineOptions or
+// TitleHeadlineOptions into HTML: the text is escaped and matches are
+// wrapped in <b>.
+func Highlight(headline string) string {
+	return selectors.Replace(html.EscapeString(headline))
+}
+
+// attachmentText is the text of the tender's live attachments; t is the
+// tender being indexed.
+const attachmentText = `(SELECT string_agg(a.text_content, ' ') FROM attachments AS a
+	WHERE a.owner_type = '` + models.AttachmentOwnerTender + `' AND a.owner_id = t.id AND a.deleted_at IS NULL)`
+
+// vector is the search_vector expression: title weighs most, then the
+// description, then attachment text.
+var vector = func() string {
+	fields := []struct{ expr, weight string }{
+		{"t.title", "A"},
+		{"t.description", "B"},
+		{attachmentText, "C"},
+	}
+
+	var parts []string
+	for _, config := range configs[""] {
+		for _, f := range fields {
+			parts = append(parts, fmt.Sprintf("setweight(to_tsvector('%s', coalesce(%s, '')), '%s')", config, f.expr, f.weight))
+		}
+	}
+	return strings.Join(parts, " || ")
+}()
+
+// RefreshTender recomputes the search vector of one tender. Call it in the
+// transaction that changes the tender or its attachments.
+func RefreshTender(db *gorm.DB, tenderID uint) error {
+	return db.Exec("UPDATE tenders AS t SET search_vector = "+vector+" WHERE t.id = ?", tenderID).Error
+}
+
+// RefreshMissing indexes tenders that have no search vector yet, e.g. rows
+// created before the column existed.
+func RefreshMissing(db *gorm.DB) error {
+	return db.Exec("UPDATE tenders AS t SET search_vector = " + vector + " WHERE t.search_vector IS NULL").Error
+}
+
+// Query is a parsed search: Expr is a tsquery expression with its Args, and
+// Config the configuration snippets are highlighted with.
+type Query struct {
+	Expr   string
+	Args   []interface{}
+	Config string
+}
+
+// Parse builds the tsquery for text in lang ("uz", "ru", "en" or empty for
+// any). text uses web search syntax: quoted phrases, "or" and -exclusions.
+func Parse(text, lang string) (Query, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return Query{}, errors.New("search text is required")
+	}
+
+	langConfigs, ok := configs[strings.ToLower(lang)]
+	if !ok {
+		return Query{}, fmt.Errorf("unsupported language %q, use uz, ru or en", lang)
+	}
+
+	parts := make([]string, 0, len(langConfigs))
+	args := make([]interface{}, 0, len(langConfigs))
+	for _, config := range langConfigs {
+		parts = append(parts, fmt.Sprintf("websearch_to_tsquery('%s', ?)", config))
+		args = append(args, text)
+	}
+
+	return Query{
+		Expr:   "(" + strings.Join(parts, " || ") + ")",
+		Args:   args,
+		Config: langConfigs[0],
+	}, nil
+}
@@ -0,0 +1,48 @@
+package search
+
+import "testing"
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name, headline, want string
+	}{
+		{"plain", "Office \x02furniture\x03 supply", "Office <b>furniture</b> supply"},
+		{"markup in the text", "<img src=x onerror=alert(1)> \x02desk\x03", "&lt;img src=x onerror=alert(1)&gt; <b>desk</b>"},
+		{"markup in a match", "\x02<script>\x03", "<b>&lt;script&gt;</b>"},
+		{"quotes and ampersands", "\"A & B\" \x02chairs\x03", "&#34;A &amp; B&#34; <b>chairs</b>"},
+		{"fragments", "\x02desk\x03 ... \x02desk\x03", "<b>desk</b> ... <b>desk</b>"},
+	}
+	for _, tt := range tests {
+		if got := Highlight(tt.headline); got != tt.want {
+			t.Errorf("%s: Highlight(%q) = %q, want %q", tt.name, tt.headline, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	q, err := Parse(" desks -chairs ", "ru")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Expr != "(websearch_to_tsquery('russian', ?))" || q.Config != "russian" {
+		t.Errorf("got %+v, want the russian configuration", q)
+	}
+	if len(q.Args) != 1 || q.Args[0] != "desks -chairs" {
+		t.Errorf("got args %v, want the trimmed text", q.Args)
+	}
+
+	q, err = Parse("desks", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Args) != 3 || q.Config != "simple" {
+		t.Errorf("got %+v, want all three configurations", q)
+	}
+
+	if _, err := Parse("  ", ""); err == nil {
+		t.Error("got no error for empty text")
+	}
+	if _, err := Parse("desks", "de"); err == nil {
+		t.Error("got no error for an unsupported language")
+	}
+}
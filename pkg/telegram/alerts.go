@@ -0,0 +1,145 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/outbox"
+
+	"gorm.io/gorm"
+)
+
+// Register subscribes to the events linked contractors are alerted about.
+// Messages go through the outbox, keyed by event and chat, so a redelivered
+// event does not message anyone twice.
+func Register(bus *events.Bus, db *gorm.DB) {
+	bus.Subscribe(events.TenderPublished, func(ctx context.Context, e events.Event) error {
+		return tenderPublished(ctx, db, e)
+	})
+	bus.Subscribe(events.TenderAwarded, func(ctx context.Context, e events.Event) error {
+		return tenderAwarded(ctx, db, e)
+	})
+}
+
+// Handler is the outbox handler for models.OutboxTelegram.
+func Handler(client Client) outbox.Handler {
+	return func(ctx context.Context, msg models.OutboxMessage) error {
+		var p models.TelegramPayload
+		if err := json.Unmarshal([]byte(msg.Payload), &p); err != nil {
+			return err
+		}
+		return client.SendMessage(ctx, p.ChatID, p.Text)
+	}
+}
+
+// tenderPublished alerts linked contractors who serve one of the tender's
+// categories (or a parent of one), or, without categories, whose
+// specialisations the tender mentions.
+func tenderPublished(ctx context.Context, db *gorm.DB, e events.Event) error {
+	var tender models.Tenders
+	if err := db.WithContext(ctx).Preload("Categories").First(&tender, e.TenderID).Error; err != nil {
+		return err
+	}
+
+	var links []models.TelegramLink
+	if err := db.WithContext(ctx).Find(&links).Error; err != nil {
+		return err
+	}
+
+	userIDs := make([]uint, 0, len(links))
+	for _, link := range links {
+		userIDs = append(userIDs, link.UserID)
+	}
+
+	var profiles []models.ContractorProfile
+	if err := db.WithContext(ctx).Preload("Categories").Where("user_id IN ?", userIDs).Find(&profiles).Error; err != nil {
+		return err
+	}
+	byUser := make(map[uint]models.ContractorProfile, len(profiles))
+	for _, profile := range profiles {
+		byUser[profile.UserID] = profile
+	}
+
+	message := fmt.Sprintf("New tender #%d: %s\nBudget: %s %s, closes %s",
+		tender.ID, tender.Title, tender.Budget.StringFixed(2), tender.Currency, tender.Deadline.Format(constants.Layout))
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, link := range links {
+			profile, ok := byUser[link.UserID]
+			if !ok || !matches(tender, profile) {
+				continue
+			}
+			if err := enqueue(tx, e, link.ChatID, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// tenderAwarded tells every linked bidder whether their offer won.
+func tenderAwarded(ctx context.Context, db *gorm.DB, e events.Event) error {
+	var tender models.Tenders
+	if err := db.WithContext(ctx).First(&tender, e.TenderID).Error; err != nil {
+		return err
+	}
+
+	var rows []struct {
+		ChatID  int64
+		OfferID uint
+	}
+	if err := db.WithContext(ctx).Table("offers").
+		Select("telegram_links.chat_id, offers.id AS offer_id").
+		Joins("JOIN telegram_links ON telegram_links.user_id = offers.contractor_id").
+		Where("offers.tender_id = ? AND offers.deleted_at IS NULL", tender.ID).
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			message := fmt.Sprintf("Tender %q was awarded to another bidder.", tender.Title)
+			if row.OfferID == e.OfferID {
+				message = fmt.Sprintf("Congratulations! Your offer #%d won tender %q.", row.OfferID, tender.Title)
+			}
+			if err := enqueue(tx, e, row.ChatID, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func enqueue(tx *gorm.DB, e events.Event, chatID int64, text string) error {
+	key := ""
+	if e.ID != "" {
+		key = fmt.Sprintf("%s:%s:%d", models.OutboxTelegram, e.ID, chatID)
+	}
+	_, err := outbox.Enqueue(tx, models.OutboxTelegram, key, models.TelegramPayload{ChatID: chatID, Text: text})
+	return err
+}
+
+func matches(tender models.Tenders, profile models.ContractorProfile) bool {
+	if len(profile.Categories) > 0 {
+		for _, served := range profile.Categories {
+			for _, cat := range tender.Categories {
+				if strings.HasPrefix(cat.Path, served.Path) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	text := strings.ToLower(tender.Title + " " + tender.Description)
+	for _, s := range profile.SplitSpecialisations() {
+		if strings.Contains(text, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
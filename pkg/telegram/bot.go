@@ -0,0 +1,270 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/categories"
+	"tender_management/pkg/redise"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// LinkCodeTTL is how long a code from POST /telegram/link-code is valid.
+	LinkCodeTTL = 10 * time.Minute
+
+	pollTimeout = 30
+	listLimit   = 10
+)
+
+// LinkCodeKey is the Redis key holding the user ID a link code belongs to.
+func LinkCodeKey(code string) string {
+	return "tg:link:" + strings.ToUpper(code)
+}
+
+// Bot answers chat commands. Start it with Run.
+type Bot struct {
+	db     *gorm.DB
+	redis  *redise.RedisDB
+	client Client
+}
+
+func NewBot(db *gorm.DB, redis *redise.RedisDB, client Client) *Bot {
+	return &Bot{db: db, redis: redis, client: client}
+}
+
+// Run long-polls for updates until ctx is cancelled.
+func (b *Bot) Run(ctx context.Context) {
+	var offset int64
+
+	for ctx.Err() == nil {
+		updates, err := b.client.GetUpdates(ctx, offset, pollTimeout)
+		if err != nil {
+			log.Printf("[ERROR] Telegram getUpdates: %v\n", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+			if update.Message == nil {
+				continue
+			}
+
+			reply := b.handle(ctx, *update.Message)
+			if err := b.client.SendMessage(ctx, update.Message.Chat.ID, reply); err != nil {
+				log.Printf("[ERROR] Telegram sendMessage: %v\n", err)
+			}
+		}
+	}
+}
+
+func (b *Bot) handle(ctx context.Context, msg Message) string {
+	fields := strings.Fields(msg.Text)
+	if len(fields) == 0 {
+		return helpText
+	}
+
+	// Commands may be addressed as /cmd@BotName in group chats.
+	command := strings.SplitN(fields[0], "@", 2)[0]
+	args := fields[1:]
+
+	var reply string
+	var err error
+	switch command {
+	case "/start", "/link":
+		if len(args) == 0 {
+			return helpText
+		}
+		reply, err = b.link(ctx, msg, args[0])
+	case "/tenders":
+		reply, err = b.tenders(ctx, msg.Chat.ID)
+	case "/offers":
+		reply, err = b.offers(ctx, msg.Chat.ID)
+	case "/unlink":
+		reply, err = b.unlink(ctx, msg.Chat.ID)
+	default:
+		return helpText
+	}
+
+	if err != nil {
+		log.Printf("[ERROR] Telegram %s: %v\n", command, err)
+		return "Something went wrong. Please try again later."
+	}
+	return reply
+}
+
+const helpText = `Commands:
+/link <code> - link your account with a code from the API
+/tenders - open tenders in your categories
+/offers - status of your offers
+/unlink - stop receiving messages here`
+
+func (b *Bot) link(ctx context.Context, msg Message, code string) (string, error) {
+	// GETDEL makes the code single-use even if sent twice at once.
+	value, err := b.redis.Rdb.GetDel(ctx, LinkCodeKey(code)).Result()
+	if err != nil {
+		return "This code is invalid or has expired. Request a new one from the API.", nil
+	}
+
+	userID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return "", err
+	}
+
+	var username string
+	if msg.From != nil {
+		username = msg.From.Username
+	}
+
+	err = b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A chat or a user may only be linked once: drop older links.
+		if err := tx.Where("user_id = ? OR chat_id = ?", userID, msg.Chat.ID).Delete(&models.TelegramLink{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.TelegramLink{
+			UserID:   uint(userID),
+			ChatID:   msg.Chat.ID,
+			Username: username,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return "Your account is linked. You will get new tender and award alerts here.\n\n" + helpText, nil
+}
+
+func (b *Bot) unlink(ctx context.Context, chatID int64) (string, error) {
+	if err := b.db.WithContext(ctx).Where("chat_id = ?", chatID).Delete(&models.TelegramLink{}).Error; err != nil {
+		return "", err
+	}
+	return "This chat is no longer linked.", nil
+}
+
+func (b *Bot) tenders(ctx context.Context, chatID int64) (string, error) {
+	link, err := b.linkFor(ctx, chatID)
+	if err != nil || link == nil {
+		return notLinked, err
+	}
+
+	var profile models.ContractorProfile
+	if err := b.db.WithContext(ctx).Preload("Categories").Where("user_id = ?", link.UserID).First(&profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "Create your contractor profile with categories first.", nil
+		}
+		return "", err
+	}
+
+	specialisations := profile.SplitSpecialisations()
+	if len(profile.Categories) == 0 && len(specialisations) == 0 {
+		return "Add categories to your contractor profile to see matching tenders.", nil
+	}
+
+	var tenders []models.Tenders
+	if err := openTenders(b.db.WithContext(ctx), profile.Categories, specialisations).
+		Order("deadline ASC").Limit(listLimit).
+		Find(&tenders).Error; err != nil {
+		return "", err
+	}
+	if len(tenders) == 0 {
+		return "No open tenders match your categories right now.", nil
+	}
+
+	var out strings.Builder
+	out.WriteString("Open tenders for you:\n")
+	for _, tender := range tenders {
+		fmt.Fprintf(&out, "\n#%d %s\nBudget: %s %s, closes %s\n", tender.ID, tender.Title, tender.Budget.StringFixed(2), tender.Currency, tender.Deadline.Format(constants.Layout))
+	}
+	return out.String(), nil
+}
+
+func (b *Bot) offers(ctx context.Context, chatID int64) (string, error) {
+	link, err := b.linkFor(ctx, chatID)
+	if err != nil || link == nil {
+		return notLinked, err
+	}
+
+	var offers []models.Offers
+	if err := b.db.WithContext(ctx).Preload("Tenders").
+		Where("contractor_id = ? AND deleted_at IS NULL", link.UserID).
+		Order("created_at DESC").Limit(listLimit).
+		Find(&offers).Error; err != nil {
+		return "", err
+	}
+	if len(offers) == 0 {
+		return "You have not submitted any offers yet.", nil
+	}
+
+	var out strings.Builder
+	out.WriteString("Your latest offers:\n")
+	for _, offer := range offers {
+		title, status := "(deleted tender)", "unknown"
+		if offer.Tenders != nil {
+			title = offer.Tenders.Title
+			status = offerStatus(offer, *offer.Tenders)
+		}
+		fmt.Fprintf(&out, "\n#%d on %q\nPrice: %s %s, %s\n", offer.ID, title, offer.Price.StringFixed(2), offer.Currency, status)
+	}
+	return out.String(), nil
+}
+
+const notLinked = "This chat is not linked. Request a code with POST /telegram/link-code and send /link <code>."
+
+func (b *Bot) linkFor(ctx context.Context, chatID int64) (*models.TelegramLink, error) {
+	var link models.TelegramLink
+	err := b.db.WithContext(ctx).Where("chat_id = ?", chatID).First(&link).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// openTenders returns open tenders in any of the categories or their
+// subcategories. Contractors who have not picked categories yet are matched
+// on specialisations mentioned in the title or description instead.
+func openTenders(db *gorm.DB, cats []models.Category, specialisations []string) *gorm.DB {
+	query := db.Model(&models.Tenders{}).
+		Where("state = ? AND deleted_at IS NULL AND deadline > ?", models.TenderStateOpen, time.Now())
+
+	if len(cats) > 0 {
+		return query.Where("id IN (?)", categories.TenderIDs(db, cats))
+	}
+
+	match := db.Where("1 = 0")
+	for _, s := range specialisations {
+		pattern := "%" + s + "%"
+		match = match.Or("title ILIKE ? OR description ILIKE ?", pattern, pattern)
+	}
+	return query.Where(match)
+}
+
+func offerStatus(offer models.Offers, tender models.Tenders) string {
+	switch tender.State {
+	case models.TenderStateAwarded:
+		if tender.AwardedOfferID != nil && *tender.AwardedOfferID == offer.ID {
+			return "won"
+		}
+		return "not selected"
+	case models.TenderStateCancelled:
+		return "tender cancelled"
+	}
+	if tender.Deadline != nil && tender.Deadline.Before(time.Now()) {
+		return "under evaluation"
+	}
+	return "submitted, tender open"
+}
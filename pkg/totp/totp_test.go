@@ -0,0 +1,123 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of RFC 6238 appendix B, the ASCII string
+// "12345678901234567890", in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// The RFC lists 8-digit codes; with 6 digits they are the last 6.
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	for _, v := range rfcVectors {
+		got, err := GenerateCode(rfcSecret, time.Unix(v.unix, 0))
+		if err != nil {
+			t.Fatalf("at %d: %v", v.unix, err)
+		}
+		if got != v.code {
+			t.Errorf("at %d got %s, want %s", v.unix, got, v.code)
+		}
+	}
+}
+
+func TestGenerateCodeAcceptsLowercaseSecret(t *testing.T) {
+	got, err := GenerateCode(" "+strings.ToLower(rfcSecret)+" ", time.Unix(59, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "287082" {
+		t.Errorf("got %s, want 287082", got)
+	}
+}
+
+func TestGenerateCodeRejectsInvalidSecret(t *testing.T) {
+	if _, err := GenerateCode("not base32!", time.Now()); err == nil {
+		t.Error("got no error for a secret that is not base32")
+	}
+}
+
+func TestValidateSkewWindow(t *testing.T) {
+	// 1111111111 is 1 second into its period, so the window is the
+	// periods starting 30 seconds before and after that one.
+	now := time.Unix(1111111111, 0)
+	code, err := GenerateCode(rfcSecret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		want   bool
+	}{
+		{"same period", 0, true},
+		{"end of the same period", 28 * time.Second, true},
+		{"one period later", Period, true},
+		{"one period earlier", -Period, true},
+		{"two periods later", 2 * Period, false},
+		{"two periods earlier", -2 * Period, false},
+	}
+	for _, tt := range tests {
+		if got := Validate(code, rfcSecret, now.Add(tt.offset)); got != tt.want {
+			t.Errorf("%s: Validate = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestValidateRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"287082", true},
+		{" 287082 ", true},
+		{"94287082", false},
+		{"28708", false},
+		{"287083", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := Validate(tt.code, rfcSecret, now); got != tt.want {
+			t.Errorf("Validate(%q) = %t, want %t", tt.code, got, tt.want)
+		}
+	}
+	if Validate("287082", "not base32!", now) {
+		t.Error("Validate accepted a code for an invalid secret")
+	}
+}
+
+func TestGenerateSecretRoundTrips(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("secret %q has %d characters, want 32 for 20 bytes", secret, len(secret))
+	}
+
+	now := time.Now()
+	code, err := GenerateCode(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Validate(code, secret, now) {
+		t.Errorf("code %s of a generated secret does not validate", code)
+	}
+}
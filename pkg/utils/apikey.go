@@ -0,0 +1,35 @@
+package utils
+
+import "strings"
+
+const apiKeyPrefix = "tm_"
+
+// GenerateAPIKey returns a new API key of the form tm_<lookup>_<secret>
+// together with its lookup part, which is stored in clear to find the key
+// without scanning every hash.
+func GenerateAPIKey() (key, lookup string, err error) {
+	lookup, err = RandomHex(6)
+	if err != nil {
+		return "", "", err
+	}
+
+	secret, err := RandomHex(24)
+	if err != nil {
+		return "", "", err
+	}
+
+	return apiKeyPrefix + lookup + "_" + secret, lookup, nil
+}
+
+// ParseAPIKey extracts the lookup part of an API key.
+func ParseAPIKey(key string) (string, bool) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(key, apiKeyPrefix), "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return parts[0], true
+}
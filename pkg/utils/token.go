@@ -1,34 +1,56 @@
 package utils
 
 import (
-	"tender_management/config"
+	"fmt"
 	"tender_management/models"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-
-func GenerateToken(email, role string) (string, error) {
-	claims := &models.Claims{
-		Email: email,
-		Role: role,
+// GenerateToken issues a session token signed with secret, the configured
+// SEKRET_KEY.
+func GenerateToken(secret []byte, userID uint, email, role string) (string, error) {
+	return signToken(secret, &models.Claims{
+		UserID: userID,
+		Email:  email,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(48 * time.Hour)),
 		},
-	}
+	})
+}
+
+// GenerateEnrollToken issues a short-lived token that only grants access to
+// the /auth/2fa endpoints, for users whose role requires 2FA but who have
+// not enrolled yet.
+func GenerateEnrollToken(secret []byte, userID uint, email, role string) (string, error) {
+	return signToken(secret, &models.Claims{
+		UserID:        userID,
+		Email:         email,
+		Role:          role,
+		MFAEnrollOnly: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
+		},
+	})
+}
 
+func signToken(secret []byte, claims *models.Claims) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	var key config.Config
-	return token.SignedString(key.SecretKey)
+	return token.SignedString(secret)
 }
 
-func ValidateToken(tokenString string) (*models.Claims, error) {
+// ValidateToken parses a token signed with secret by GenerateToken or
+// GenerateEnrollToken. Tokens signed with any other method are rejected.
+func ValidateToken(secret []byte, tokenString string) (*models.Claims, error) {
 	claims := &models.Claims{}
-	key := &config.Config{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return key.SecretKey, nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return secret, nil
 	})
 
 	if err != nil || !token.Valid {
@@ -36,4 +58,4 @@ func ValidateToken(tokenString string) (*models.Claims, error) {
 	}
 
 	return claims, nil
-}
\ No newline at end of file
+}
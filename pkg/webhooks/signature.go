@@ -0,0 +1,52 @@
+// Package webhooks delivers tender and offer events to endpoints registered
+// by users and organizations. Every request is signed so that receivers can
+// check it came from us and was not replayed.
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader holds "sha256=" followed by the hex HMAC-SHA256 of
+	// "<timestamp>.<body>" keyed with the endpoint secret.
+	SignatureHeader = "X-Tender-Signature"
+	// TimestampHeader holds the Unix time the request was signed at.
+	TimestampHeader = "X-Tender-Timestamp"
+	EventHeader     = "X-Tender-Event"
+)
+
+// Sign returns the SignatureHeader value for body sent at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a received request the way receivers should: the signature
+// must match and the timestamp must be within tolerance of now.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid timestamp")
+	}
+
+	age := time.Since(time.Unix(ts, 0))
+	if age > tolerance || age < -tolerance {
+		return errors.New("timestamp outside tolerance")
+	}
+
+	expected := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimSpace(signature))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/outbox"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Register subscribes to every event type and queues a delivery for each
+// endpoint whose owner is involved in the tender and asked for the type.
+// A published tender is public, so it goes to every endpoint that asked
+// for tender.published, e.g. a contractor's ERP watching for new tenders.
+func Register(bus *events.Bus, db *gorm.DB) {
+	for _, t := range events.Types {
+		bus.Subscribe(t, func(ctx context.Context, e events.Event) error {
+			return queue(ctx, db, e)
+		})
+	}
+}
+
+func queue(ctx context.Context, db *gorm.DB, e events.Event) error {
+	query := db.WithContext(ctx).Where("deleted_at IS NULL")
+	if e.Type != events.TenderPublished {
+		users, orgs, err := audience(ctx, db, e)
+		if err != nil {
+			return err
+		}
+		query = query.Where("(organization_id IS NULL AND user_id IN ?) OR organization_id IN ?", users, orgs)
+	}
+
+	var endpoints []models.WebhookEndpoint
+	if err := query.Find(&endpoints).Error; err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, endpoint := range endpoints {
+			if !subscribed(endpoint, e.Type) {
+				continue
+			}
+
+			// A redelivered event must not create a second delivery.
+			if e.ID != "" {
+				var existing int64
+				if err := tx.Model(&models.WebhookDelivery{}).
+					Where("endpoint_id = ? AND event_id = ?", endpoint.ID, e.ID).
+					Count(&existing).Error; err != nil {
+					return err
+				}
+				if existing > 0 {
+					continue
+				}
+			}
+
+			delivery := models.WebhookDelivery{
+				EndpointID: endpoint.ID,
+				EventID:    e.ID,
+				EventType:  string(e.Type),
+				Payload:    string(payload),
+				Status:     models.WebhookDeliveryPending,
+			}
+			if err := tx.Create(&delivery).Error; err != nil {
+				return err
+			}
+			if err := Enqueue(tx, delivery.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Enqueue queues an attempt to send the delivery. Every call creates a new
+// outbox message, so it is also used to redeliver dead deliveries.
+func Enqueue(tx *gorm.DB, deliveryID uint) error {
+	_, err := outbox.Enqueue(tx, models.OutboxWebhookDelivery, "", models.WebhookDeliveryPayload{DeliveryID: deliveryID})
+	return err
+}
+
+// audience returns the users and organizations involved in the event's
+// tender: its owner, and the bidders. For offer events only the offer's own
+// bidder is included, so that sealed bids stay hidden from competitors.
+func audience(ctx context.Context, db *gorm.DB, e events.Event) ([]uint, []uint, error) {
+	var tender models.Tenders
+	if err := db.WithContext(ctx).First(&tender, e.TenderID).Error; err != nil {
+		return nil, nil, err
+	}
+
+	users := []uint{tender.ClientID}
+	orgs := []uint{0}
+	if tender.OrganizationID != nil {
+		orgs = append(orgs, *tender.OrganizationID)
+	}
+
+	query := db.WithContext(ctx).Where("tender_id = ? AND deleted_at IS NULL", tender.ID)
+	if e.Type == events.OfferSubmitted || e.Type == events.OfferUpdated {
+		query = query.Where("id = ?", e.OfferID)
+	}
+
+	var offers []models.Offers
+	if err := query.Find(&offers).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, offer := range offers {
+		users = append(users, offer.ContractorID)
+		if offer.OrganizationID != nil {
+			orgs = append(orgs, *offer.OrganizationID)
+		}
+	}
+	return users, orgs, nil
+}
+
+func subscribed(endpoint models.WebhookEndpoint, t events.Type) bool {
+	for _, et := range endpoint.SplitEventTypes() {
+		if et == string(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sender signs and posts deliveries.
+type Sender struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+func NewSender(db *gorm.DB, client *http.Client) *Sender {
+	return &Sender{db: db, client: client}
+}
+
+// HandleDelivery is the outbox handler for models.OutboxWebhookDelivery.
+// Failures are retried by the relay with exponential backoff; the delivery
+// is marked dead when the relay gives up.
+func (s *Sender) HandleDelivery(ctx context.Context, msg models.OutboxMessage) error {
+	var p models.WebhookDeliveryPayload
+	if err := json.Unmarshal([]byte(msg.Payload), &p); err != nil {
+		return err
+	}
+
+	var delivery models.WebhookDelivery
+	if err := s.db.WithContext(ctx).Preload("Endpoint").First(&delivery, p.DeliveryID).Error; err != nil {
+		return err
+	}
+	if delivery.Status == models.WebhookDeliverySent {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"attempts": gorm.Expr("attempts + 1"),
+	}
+
+	var status int
+	var err error
+	if delivery.Endpoint == nil || delivery.Endpoint.DeletedAt != nil {
+		err = fmt.Errorf("webhook endpoint %d was deleted", delivery.EndpointID)
+		fields["status"] = models.WebhookDeliveryDead
+	} else {
+		key := fmt.Sprintf("%s:%d", models.OutboxWebhookDelivery, delivery.ID)
+		status, err = s.Send(ctx, *delivery.Endpoint, key, delivery.EventType, []byte(delivery.Payload))
+		fields["response_status"] = status
+	}
+
+	switch {
+	case err == nil:
+		fields["status"] = models.WebhookDeliverySent
+		fields["delivered_at"] = time.Now()
+		fields["last_error"] = ""
+	case msg.Attempts+1 >= outbox.MaxAttempts:
+		fields["status"] = models.WebhookDeliveryDead
+		fields["last_error"] = err.Error()
+	default:
+		fields["last_error"] = err.Error()
+	}
+
+	if uerr := s.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(fields).Error; uerr != nil {
+		return uerr
+	}
+	if fields["status"] == models.WebhookDeliveryDead {
+		// Nothing left to retry; the delivery waits in the dead-letter list.
+		return nil
+	}
+	return err
+}
+
+// Send posts a signed body to the endpoint and returns the response status.
+// Any non-2xx status is an error.
+func (s *Sender) Send(ctx context.Context, endpoint models.WebhookEndpoint, key, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(outbox.IdempotencyHeader, key)
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,320 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/outbox"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const secret = "test-secret"
+
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "webhooks.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	// SQLite has no GIN indexes; tenders are only read here.
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&models.Tenders{}); err != nil {
+		t.Fatal(err)
+	}
+	delete(stmt.Schema.LookUpField("search_vector").TagSettings, "INDEX")
+
+	if err := db.AutoMigrate(&models.WebhookEndpoint{}, &models.WebhookDelivery{}, &models.OutboxMessage{},
+		&models.Tenders{}, &models.Offers{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// createDelivery stores a pending delivery to an endpoint at url and
+// returns the outbox message that sends it.
+func createDelivery(t *testing.T, db *gorm.DB, url string) (models.WebhookDelivery, models.OutboxMessage) {
+	t.Helper()
+
+	endpoint := models.WebhookEndpoint{UserID: 1, URL: url, Secret: secret, EventTypes: string(events.TenderPublished)}
+	if err := db.Create(&endpoint).Error; err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	delivery := models.WebhookDelivery{
+		EndpointID: endpoint.ID,
+		EventID:    "event-1",
+		EventType:  string(events.TenderPublished),
+		Payload:    `{"type":"tender.published","tender_id":7}`,
+		Status:     models.WebhookDeliveryPending,
+	}
+	if err := db.Create(&delivery).Error; err != nil {
+		t.Fatalf("create delivery: %v", err)
+	}
+
+	payload, err := json.Marshal(models.WebhookDeliveryPayload{DeliveryID: delivery.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delivery, models.OutboxMessage{Kind: models.OutboxWebhookDelivery, Payload: string(payload)}
+}
+
+func reload(t *testing.T, db *gorm.DB, id uint) models.WebhookDelivery {
+	t.Helper()
+
+	var delivery models.WebhookDelivery
+	if err := db.First(&delivery, id).Error; err != nil {
+		t.Fatalf("reload delivery: %v", err)
+	}
+	return delivery
+}
+
+// respond returns a server answering with the given statuses in turn, the
+// last one repeated, and a counter of the requests it received.
+func respond(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n > len(statuses) {
+			n = len(statuses)
+		}
+		w.WriteHeader(statuses[n-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestSendSignsRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewSender(nil, server.Client())
+	endpoint := models.WebhookEndpoint{URL: server.URL, Secret: secret}
+	payload := []byte(`{"type":"offer.submitted"}`)
+
+	status, err := sender.Send(context.Background(), endpoint, "webhook_delivery:1", "offer.submitted", payload)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if status != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	if string(body) != string(payload) {
+		t.Errorf("body = %s, want %s", body, payload)
+	}
+	if h := got.Header.Get(EventHeader); h != "offer.submitted" {
+		t.Errorf("%s = %q", EventHeader, h)
+	}
+	if h := got.Header.Get(outbox.IdempotencyHeader); h != "webhook_delivery:1" {
+		t.Errorf("%s = %q", outbox.IdempotencyHeader, h)
+	}
+
+	timestamp := got.Header.Get(TimestampHeader)
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		t.Fatalf("%s = %q is not a Unix time", TimestampHeader, timestamp)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age < -time.Second || age > 5*time.Second {
+		t.Errorf("%s is %s off now", TimestampHeader, age)
+	}
+
+	signature := got.Header.Get(SignatureHeader)
+	if signature != Sign(secret, ts, payload) {
+		t.Errorf("%s = %q, want %q", SignatureHeader, signature, Sign(secret, ts, payload))
+	}
+	if err := Verify(secret, timestamp, signature, body, time.Minute); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := Verify("other-secret", timestamp, signature, body, time.Minute); err == nil {
+		t.Error("Verify accepted a signature made with another secret")
+	}
+}
+
+func TestHandleDeliveryRetriesServerErrors(t *testing.T) {
+	db := openDB(t)
+	server, calls := respond(t, http.StatusServiceUnavailable, http.StatusOK)
+	sender := NewSender(db, server.Client())
+	delivery, msg := createDelivery(t, db, server.URL)
+
+	if err := sender.HandleDelivery(context.Background(), msg); err == nil {
+		t.Fatal("HandleDelivery returned nil for a 503, so the relay would not retry")
+	}
+	got := reload(t, db, delivery.ID)
+	if got.Status != models.WebhookDeliveryPending || got.Attempts != 1 || got.ResponseStatus != http.StatusServiceUnavailable {
+		t.Errorf("after 503: status %q, attempts %d, response %d", got.Status, got.Attempts, got.ResponseStatus)
+	}
+	if got.LastError == "" {
+		t.Error("after 503: last error not recorded")
+	}
+
+	msg.Attempts = 1
+	if err := sender.HandleDelivery(context.Background(), msg); err != nil {
+		t.Fatalf("HandleDelivery on retry: %v", err)
+	}
+	got = reload(t, db, delivery.ID)
+	if got.Status != models.WebhookDeliverySent || got.Attempts != 2 || got.DeliveredAt == nil || got.LastError != "" {
+		t.Errorf("after retry: status %q, attempts %d, delivered %v, error %q", got.Status, got.Attempts, got.DeliveredAt, got.LastError)
+	}
+
+	// A sent delivery is not posted again when its message is redelivered.
+	if err := sender.HandleDelivery(context.Background(), msg); err != nil {
+		t.Fatalf("HandleDelivery after sent: %v", err)
+	}
+	if n := atomic.LoadInt32(calls); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestHandleDeliveryGivesUpAfterLastAttempt(t *testing.T) {
+	db := openDB(t)
+	server, calls := respond(t, http.StatusInternalServerError)
+	sender := NewSender(db, server.Client())
+	delivery, msg := createDelivery(t, db, server.URL)
+
+	msg.Attempts = outbox.MaxAttempts - 2
+	if err := sender.HandleDelivery(context.Background(), msg); err == nil {
+		t.Fatal("HandleDelivery returned nil before the last attempt")
+	}
+	if got := reload(t, db, delivery.ID); got.Status != models.WebhookDeliveryPending {
+		t.Errorf("before the last attempt: status %q, want %q", got.Status, models.WebhookDeliveryPending)
+	}
+
+	msg.Attempts = outbox.MaxAttempts - 1
+	if err := sender.HandleDelivery(context.Background(), msg); err != nil {
+		t.Fatalf("HandleDelivery on the last attempt: %v, want nil so the relay stops", err)
+	}
+	got := reload(t, db, delivery.ID)
+	if got.Status != models.WebhookDeliveryDead || got.ResponseStatus != http.StatusInternalServerError || got.LastError == "" {
+		t.Errorf("after the last attempt: status %q, response %d, error %q", got.Status, got.ResponseStatus, got.LastError)
+	}
+	if n := atomic.LoadInt32(calls); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestHandleDeliveryDeletedEndpoint(t *testing.T) {
+	db := openDB(t)
+	server, calls := respond(t, http.StatusOK)
+	sender := NewSender(db, server.Client())
+	delivery, msg := createDelivery(t, db, server.URL)
+
+	if err := db.Model(&models.WebhookEndpoint{}).Where("id = ?", delivery.EndpointID).
+		Update("deleted_at", time.Now()).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sender.HandleDelivery(context.Background(), msg); err != nil {
+		t.Fatalf("HandleDelivery: %v", err)
+	}
+	if got := reload(t, db, delivery.ID); got.Status != models.WebhookDeliveryDead {
+		t.Errorf("status %q, want %q", got.Status, models.WebhookDeliveryDead)
+	}
+	if n := atomic.LoadInt32(calls); n != 0 {
+		t.Errorf("server received %d requests for a deleted endpoint", n)
+	}
+}
+
+// deliveredTo returns the IDs of the endpoints with a delivery of the
+// event type.
+func deliveredTo(t *testing.T, db *gorm.DB, eventType events.Type) map[uint]bool {
+	t.Helper()
+
+	var deliveries []models.WebhookDelivery
+	if err := db.Where("event_type = ?", string(eventType)).Find(&deliveries).Error; err != nil {
+		t.Fatal(err)
+	}
+	endpoints := map[uint]bool{}
+	for _, d := range deliveries {
+		endpoints[d.EndpointID] = true
+	}
+	return endpoints
+}
+
+func TestQueueAudience(t *testing.T) {
+	db := openDB(t)
+	deadline := time.Now().Add(24 * time.Hour)
+	tender := models.Tenders{Title: "Paper", Description: "A4", Deadline: &deadline, Currency: "UZS",
+		AcceptedCurrencies: "UZS", ClientID: 1, State: models.TenderStateOpen}
+	if err := db.Create(&tender).Error; err != nil {
+		t.Fatal(err)
+	}
+	offers := []models.Offers{
+		{TenderID: tender.ID, ContractorID: 2, Currency: "UZS", DeliveryTime: &deadline},
+		{TenderID: tender.ID, ContractorID: 3, Currency: "UZS", DeliveryTime: &deadline},
+	}
+	if err := db.Create(&offers).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	types := string(events.TenderPublished) + "," + string(events.OfferSubmitted)
+	endpoints := []models.WebhookEndpoint{
+		{UserID: 1, URL: "https://client.example", Secret: secret, EventTypes: types},
+		{UserID: 2, URL: "https://bidder.example", Secret: secret, EventTypes: types},
+		{UserID: 3, URL: "https://rival.example", Secret: secret, EventTypes: types},
+		{UserID: 4, URL: "https://erp.example", Secret: secret, EventTypes: types},
+		{UserID: 5, URL: "https://offers-only.example", Secret: secret, EventTypes: string(events.OfferSubmitted)},
+	}
+	if err := db.Create(&endpoints).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := queue(ctx, db, events.Event{ID: "published", Type: events.TenderPublished, TenderID: tender.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue(ctx, db, events.Event{ID: "submitted", Type: events.OfferSubmitted, TenderID: tender.ID,
+		OfferID: offers[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A published tender reaches every subscriber, bidder or not.
+	published := deliveredTo(t, db, events.TenderPublished)
+	for _, e := range endpoints[:4] {
+		if !published[e.ID] {
+			t.Errorf("endpoint of user %d got no tender.published delivery", e.UserID)
+		}
+	}
+	if published[endpoints[4].ID] {
+		t.Error("endpoint not subscribed to tender.published got a delivery")
+	}
+
+	// An offer only reaches the tender's client and its own bidder.
+	submitted := deliveredTo(t, db, events.OfferSubmitted)
+	want := map[uint]bool{endpoints[0].ID: true, endpoints[1].ID: true}
+	if len(submitted) != len(want) || !submitted[endpoints[0].ID] || !submitted[endpoints[1].ID] {
+		t.Errorf("offer.submitted went to endpoints %v, want %v", submitted, want)
+	}
+
+	// Queueing the same event again adds no delivery.
+	if err := queue(ctx, db, events.Event{ID: "published", Type: events.TenderPublished, TenderID: tender.ID}); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	db.Model(&models.WebhookDelivery{}).Where("event_id = ?", "published").Count(&count)
+	if count != 4 {
+		t.Errorf("got %d tender.published deliveries after redelivery, want 4", count)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/storage"
+	"time"
+)
+
+// canManage reports whether the user may change a tender or offer: the
+// individual owner always can, and so can editors of the owning organization.
+func canManage(store storage.Store, userID, ownerID uint, orgID *uint, kind string) (bool, error) {
+	if userID == ownerID {
+		return true, nil
+	}
+	if orgID == nil {
+		return false, nil
+	}
+	return store.Organizations().CanEdit(*orgID, userID, kind)
+}
+
+// checkOrganization fails unless orgID is nil or the user edits that
+// organization of the given kind.
+func checkOrganization(store storage.Store, orgID *uint, userID uint, kind string) error {
+	if orgID == nil {
+		return nil
+	}
+
+	allowed, err := store.Organizations().CanEdit(*orgID, userID, kind)
+	if err != nil {
+		return failed("Failed to check organization membership", err)
+	}
+	if !allowed {
+		return forbidden("You are not an editor of this organization")
+	}
+	return nil
+}
+
+// acceptsOffers reports whether the tender is open and before its
+// deadline, while its offers and documents may still change.
+func acceptsOffers(tender models.Tenders) bool {
+	return tender.State == models.TenderStateOpen && tender.Deadline != nil && tender.Deadline.After(time.Now())
+}
+
+// AccessService answers who may act on tenders, offers and organizations
+// for the resources that hang off them, such as attachments and webhooks.
+type AccessService struct {
+	store storage.Store
+}
+
+func NewAccessService(store storage.Store) *AccessService {
+	return &AccessService{store: store}
+}
+
+// EditsOrganization reports whether the user is an owner or editor of the
+// live organization of the given kind.
+func (s *AccessService) EditsOrganization(userID, orgID uint, kind string) (bool, error) {
+	allowed, err := s.store.Organizations().CanEdit(orgID, userID, kind)
+	if err != nil {
+		return false, failed("Failed to check organization membership", err)
+	}
+	return allowed, nil
+}
+
+// ChangesTender checks that the user manages the live tender and that it
+// is still open before its deadline, after which its documents are fixed.
+func (s *AccessService) ChangesTender(userID, tenderID uint) error {
+	tender, err := s.store.Tenders().Get(tenderID)
+	if err != nil {
+		return lookup("Tender not found", "Failed to fetch tender", err)
+	}
+
+	allowed, err := s.manages(userID, tender.ClientID, tender.OrganizationID, constants.RoleClient)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return forbidden("You are not allowed to manage this tender")
+	}
+	if !acceptsOffers(tender) {
+		return invalid("Tender documents cannot change once it is closed or past its deadline", nil)
+	}
+	return nil
+}
+
+// ChangesOffer checks that the user manages the live offer and that its
+// tender still accepts offers, as for editing the offer itself.
+func (s *AccessService) ChangesOffer(userID, offerID uint) error {
+	offer, err := s.store.Offers().Get(offerID)
+	if err != nil {
+		return lookup("Offer not found", "Failed to fetch offer", err)
+	}
+
+	allowed, err := s.manages(userID, offer.ContractorID, offer.OrganizationID, constants.RoleContractor)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return forbidden("You are not allowed to manage this offer")
+	}
+
+	tender, err := s.store.Tenders().Get(offer.TenderID)
+	if err != nil {
+		return lookup("Tender not found", "Failed to fetch tender", err)
+	}
+	if !acceptsOffers(tender) {
+		return invalid("Tender is closed for offers", nil)
+	}
+	return nil
+}
+
+// SeesOffer applies sealed-bid visibility to the live offer: those who
+// manage it always see it, those who manage its tender once the deadline
+// has passed.
+func (s *AccessService) SeesOffer(userID, offerID uint) (bool, error) {
+	offer, err := s.store.Offers().Get(offerID)
+	if err != nil {
+		return false, lookup("Offer not found", "Failed to fetch offer", err)
+	}
+
+	owner, err := s.manages(userID, offer.ContractorID, offer.OrganizationID, constants.RoleContractor)
+	if err != nil || owner {
+		return owner, err
+	}
+
+	tender, err := s.store.Tenders().Get(offer.TenderID)
+	if err != nil {
+		return false, lookup("Tender not found", "Failed to fetch tender", err)
+	}
+	if tender.Deadline == nil || tender.Deadline.After(time.Now()) {
+		return false, nil
+	}
+	return s.manages(userID, tender.ClientID, tender.OrganizationID, constants.RoleClient)
+}
+
+func (s *AccessService) manages(userID, ownerID uint, orgID *uint, kind string) (bool, error) {
+	allowed, err := canManage(s.store, userID, ownerID, orgID, kind)
+	if err != nil {
+		return false, failed("Failed to check organization membership", err)
+	}
+	return allowed, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"tender_management/models"
+	"testing"
+	"time"
+)
+
+func TestSeesOfferSealed(t *testing.T) {
+	store := newOfferFixture()
+	access := NewAccessService(store)
+
+	tests := []struct {
+		userID uint
+		want   bool
+	}{
+		{contractor, true},
+		{client, false},
+		{stranger, false},
+	}
+	for _, tt := range tests {
+		got, err := access.SeesOffer(tt.userID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("user %d sees the sealed offer: %t, want %t", tt.userID, got, tt.want)
+		}
+	}
+
+	tender := store.tenders[1]
+	tender.Deadline = at(-time.Hour)
+	store.tenders[1] = tender
+
+	if got, err := access.SeesOffer(client, 1); err != nil || !got {
+		t.Errorf("client sees the offer after the deadline: %t, %v", got, err)
+	}
+	if got, err := access.SeesOffer(stranger, 1); err != nil || got {
+		t.Errorf("stranger sees the offer after the deadline: %t, %v", got, err)
+	}
+}
+
+func TestChangesTender(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		change func(*models.Tenders)
+		want   Kind
+	}{
+		{"by a contractor", contractor, nil, Forbidden},
+		{"by a viewer of its organization", stranger, nil, Forbidden},
+		{"past its deadline", client, func(t *models.Tenders) { t.Deadline = at(-time.Hour) }, Invalid},
+		{"once awarded", colleague, func(t *models.Tenders) { t.State = models.TenderStateAwarded }, Invalid},
+		{"once cancelled", client, func(t *models.Tenders) { t.State = models.TenderStateCancelled }, Invalid},
+		{"once deleted", client, func(t *models.Tenders) { t.DeletedAt = at(-time.Hour) }, NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newAwardFixture()
+			tender := store.tenders[1]
+			tender.Deadline = at(time.Hour)
+			if tt.change != nil {
+				tt.change(&tender)
+			}
+			store.tenders[1] = tender
+
+			wantKind(t, NewAccessService(store).ChangesTender(tt.userID, 1), tt.want)
+		})
+	}
+
+	store := newAwardFixture()
+	tender := store.tenders[1]
+	tender.Deadline = at(time.Hour)
+	store.tenders[1] = tender
+	for _, userID := range []uint{client, colleague} {
+		if err := NewAccessService(store).ChangesTender(userID, 1); err != nil {
+			t.Errorf("user %d cannot change the open tender: %v", userID, err)
+		}
+	}
+}
+
+func TestChangesOffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID uint
+		change func(*models.Tenders)
+		want   Kind
+	}{
+		{"by the tender client", client, nil, Forbidden},
+		{"by another contractor", stranger, nil, Forbidden},
+		{"past the deadline", contractor, func(t *models.Tenders) { t.Deadline = at(-time.Hour) }, Invalid},
+		{"once the tender is awarded", contractor, func(t *models.Tenders) { t.State = models.TenderStateAwarded }, Invalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOfferFixture()
+			if tt.change != nil {
+				tender := store.tenders[1]
+				tt.change(&tender)
+				store.tenders[1] = tender
+			}
+
+			wantKind(t, NewAccessService(store).ChangesOffer(tt.userID, 1), tt.want)
+		})
+	}
+
+	if err := NewAccessService(newOfferFixture()).ChangesOffer(contractor, 1); err != nil {
+		t.Errorf("contractor cannot change the offer on an open tender: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Package service holds the business rules of tenders, offers,
+// notifications and users on top of the storage repositories, so that the
+// HTTP controllers and other front ends share them.
+package service
+
+import (
+	"errors"
+	"tender_management/storage"
+)
+
+// Kind classifies a service error for the caller, e.g. to pick an HTTP
+// status.
+type Kind int
+
+const (
+	// Internal is a failure of the storage or another dependency.
+	Internal Kind = iota
+	// Invalid is input that breaks a rule.
+	Invalid
+	// Unauthorized is a failed login.
+	Unauthorized
+	// Forbidden is an action the user may not take.
+	Forbidden
+	// NotFound is a missing or invisible record.
+	NotFound
+)
+
+// Error is what services return. Message is meant for the user; Err is the
+// cause, if any, and is only logged.
+type Error struct {
+	Kind    Kind
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf returns the kind of err, Internal for errors that did not come
+// from a service.
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
+
+func invalid(message string, err error) error {
+	return &Error{Kind: Invalid, Message: message, Err: err}
+}
+
+func forbidden(message string) error {
+	return &Error{Kind: Forbidden, Message: message}
+}
+
+func failed(message string, err error) error {
+	return &Error{Kind: Internal, Message: message, Err: err}
+}
+
+// lookup reports a failed read as NotFound with the missing message when
+// the record does not exist, and as Internal with broken otherwise.
+func lookup(missing, broken string, err error) error {
+	if errors.Is(err, storage.ErrNotFound) {
+		return &Error{Kind: NotFound, Message: missing, Err: err}
+	}
+	return &Error{Kind: Internal, Message: broken, Err: err}
+}
+
+func missing(message string) error {
+	return &Error{Kind: NotFound, Message: message}
+}
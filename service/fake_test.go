@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/listing"
+	"tender_management/pkg/rates"
+	"tender_management/storage"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// fakeStore is an in-memory storage.Store. Its repositories embed the
+// storage interfaces and implement what the services under test call; any
+// other method panics, which points at a missing fake rather than passing
+// silently.
+type fakeStore struct {
+	tenders map[uint]models.Tenders
+	offers  map[uint]models.Offers
+	// members maps an organization to the roles of its members.
+	members map[uint]map[uint]string
+	// orgKinds is the kind of each organization, client or contractor.
+	orgKinds map[uint]string
+	// lacking maps a contractor to the qualifications they have no
+	// document for.
+	lacking map[uint][]string
+	events  []events.Event
+
+	users map[uint]models.Users
+	// recoveryCodes maps a user to the hashes of their recovery codes and
+	// whether each was used.
+	recoveryCodes map[uint]map[string]bool
+	policies      map[string]models.TwoFactorPolicy
+
+	stats       []models.TenderStats
+	statsFilter *storage.OfferFilter
+	listFilter  *storage.OfferFilter
+	// rateDates lists the dates rate tables were loaded for. The fake has
+	// no rates, so any conversion fails with rates.ErrNoRate.
+	rateDates []time.Time
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		tenders:  map[uint]models.Tenders{},
+		offers:   map[uint]models.Offers{},
+		members:  map[uint]map[uint]string{},
+		orgKinds: map[uint]string{},
+		lacking:  map[uint][]string{},
+
+		users:         map[uint]models.Users{},
+		recoveryCodes: map[uint]map[string]bool{},
+		policies:      map[string]models.TwoFactorPolicy{},
+	}
+}
+
+func (s *fakeStore) addMember(orgID uint, kind string, userID uint, role string) {
+	s.orgKinds[orgID] = kind
+	if s.members[orgID] == nil {
+		s.members[orgID] = map[uint]string{}
+	}
+	s.members[orgID][userID] = role
+}
+
+func (s *fakeStore) Users() storage.UserRepository                 { return fakeUsers{s: s} }
+func (s *fakeStore) Tenders() storage.TenderRepository             { return fakeTenders{s: s} }
+func (s *fakeStore) Offers() storage.OfferRepository               { return fakeOffers{s: s} }
+func (s *fakeStore) Notifs() storage.NotifRepository               { return fakeNotifs{} }
+func (s *fakeStore) Organizations() storage.OrganizationRepository { return fakeOrganizations{s: s} }
+func (s *fakeStore) Outbox() storage.OutboxRepository              { return fakeOutbox{} }
+func (s *fakeStore) Rates() storage.RateRepository                 { return fakeRates{s: s} }
+
+func (s *fakeStore) Publish(e events.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+// Transaction runs fn against the store and puts back the rows and events
+// it had if fn fails.
+func (s *fakeStore) Transaction(fn func(tx storage.Store) error) error {
+	tenders := make(map[uint]models.Tenders, len(s.tenders))
+	for id, t := range s.tenders {
+		tenders[id] = t
+	}
+	offers := make(map[uint]models.Offers, len(s.offers))
+	for id, o := range s.offers {
+		offers[id] = o
+	}
+	published := len(s.events)
+
+	if err := fn(s); err != nil {
+		s.tenders, s.offers, s.events = tenders, offers, s.events[:published]
+		return err
+	}
+	return nil
+}
+
+type fakeNotifs struct{ storage.NotifRepository }
+type fakeOutbox struct{ storage.OutboxRepository }
+
+type fakeRates struct {
+	storage.RateRepository
+	s *fakeStore
+}
+
+func (r fakeRates) Table(base string, asOf time.Time) (rates.Table, error) {
+	r.s.rateDates = append(r.s.rateDates, asOf)
+	return rates.Table{Base: base}, nil
+}
+
+type fakeUsers struct {
+	storage.UserRepository
+	s *fakeStore
+}
+
+func (r fakeUsers) GetActive(id uint) (models.Users, error) {
+	user, ok := r.s.users[id]
+	if !ok || !user.IsActive {
+		return models.Users{}, storage.ErrNotFound
+	}
+	return user, nil
+}
+
+func (r fakeUsers) SetTwoFactor(id uint, secret string, enabled bool) error {
+	user, ok := r.s.users[id]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	user.TwoFactorSecret = secret
+	user.TwoFactorEnabled = enabled
+	r.s.users[id] = user
+	return nil
+}
+
+func (r fakeUsers) ReplaceRecoveryCodes(userID uint, hashes []string) error {
+	r.s.recoveryCodes[userID] = map[string]bool{}
+	for _, hash := range hashes {
+		r.s.recoveryCodes[userID][hash] = false
+	}
+	return nil
+}
+
+func (r fakeUsers) UseRecoveryCode(userID uint, hash string) (bool, error) {
+	used, ok := r.s.recoveryCodes[userID][hash]
+	if !ok || used {
+		return false, nil
+	}
+	r.s.recoveryCodes[userID][hash] = true
+	return true, nil
+}
+
+func (r fakeUsers) TwoFactorPolicy(role string) (models.TwoFactorPolicy, error) {
+	policy, ok := r.s.policies[role]
+	if !ok {
+		return models.TwoFactorPolicy{}, storage.ErrNotFound
+	}
+	return policy, nil
+}
+
+func (r fakeUsers) SaveTwoFactorPolicy(policy *models.TwoFactorPolicy) error {
+	r.s.policies[policy.Role] = *policy
+	return nil
+}
+
+// fakeUsedCodes is an in-memory UsedCodes that never expires keys.
+type fakeUsedCodes map[string]bool
+
+func (c fakeUsedCodes) SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error) {
+	if c[key] {
+		return false, nil
+	}
+	c[key] = true
+	return true, nil
+}
+
+type fakeTenders struct {
+	storage.TenderRepository
+	s *fakeStore
+}
+
+func (r fakeTenders) Get(id uint) (models.Tenders, error) {
+	tender, ok := r.s.tenders[id]
+	if !ok || tender.DeletedAt != nil {
+		return models.Tenders{}, storage.ErrNotFound
+	}
+	return tender, nil
+}
+
+func (r fakeTenders) PastDeadline(since, until time.Time) ([]models.Tenders, error) {
+	var tenders []models.Tenders
+	for _, tender := range r.s.tenders {
+		if tender.State == models.TenderStateOpen && tender.DeletedAt == nil &&
+			!tender.Deadline.Before(since) && tender.Deadline.Before(until) {
+			tenders = append(tenders, tender)
+		}
+	}
+	return tenders, nil
+}
+
+func (r fakeTenders) SetFields(tender *models.Tenders, fields map[string]interface{}) error {
+	for column, value := range fields {
+		switch column {
+		case "state":
+			tender.State = value.(string)
+		case "status":
+			tender.Status = value.(bool)
+		case "awarded_offer_id":
+			id := value.(uint)
+			tender.AwardedOfferID = &id
+		case "awarded_at":
+			at := value.(time.Time)
+			tender.AwardedAt = &at
+		default:
+			return errors.New("fakeTenders.SetFields: unsupported column " + column)
+		}
+	}
+	r.s.tenders[tender.ID] = *tender
+	return nil
+}
+
+func (r fakeTenders) Create(tender *models.Tenders) error {
+	tender.ID = uint(len(r.s.tenders) + 1)
+	for r.s.tenders[tender.ID].ID != 0 {
+		tender.ID++
+	}
+	r.s.tenders[tender.ID] = *tender
+	return nil
+}
+
+// Update applies fields to the stored tender, as the SQL storage writes
+// only those columns.
+func (r fakeTenders) Update(tender *models.Tenders, fields map[string]interface{}) error {
+	stored, ok := r.s.tenders[tender.ID]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	for column, value := range fields {
+		switch column {
+		case "title":
+			stored.Title = value.(string)
+		case "description":
+			stored.Description = value.(string)
+		case "deadline":
+			stored.Deadline = value.(*time.Time)
+		case "budget":
+			stored.Budget = value.(decimal.Decimal)
+		case "hide_budget":
+			stored.HideBudget = value.(bool)
+		case "accepted_currencies":
+			stored.AcceptedCurrencies = value.(string)
+		case "file_url":
+			stored.FileURL = value.(string)
+		case "client_id":
+			stored.ClientID = value.(uint)
+		case "organization_id":
+			stored.OrganizationID = value.(*uint)
+		case "reminder_sent_at":
+			stored.ReminderSentAt = nil
+		default:
+			return errors.New("fakeTenders.Update: unsupported column " + column)
+		}
+	}
+	r.s.tenders[tender.ID] = stored
+	return nil
+}
+
+func (r fakeTenders) ResolveCategories(codes []string) ([]models.Category, error) {
+	if len(codes) > 0 {
+		return nil, errors.New("fakeTenders.ResolveCategories: no categories")
+	}
+	return nil, nil
+}
+
+func (r fakeTenders) MissingQualifications(tenderID, contractorID uint) ([]string, error) {
+	return r.s.lacking[contractorID], nil
+}
+
+type fakeOffers struct {
+	storage.OfferRepository
+	s *fakeStore
+}
+
+func (r fakeOffers) Get(id uint) (models.Offers, error) {
+	offer, ok := r.s.offers[id]
+	if !ok || offer.DeletedAt != nil {
+		return models.Offers{}, storage.ErrNotFound
+	}
+	return offer, nil
+}
+
+func (r fakeOffers) GetOnTender(id, tenderID uint) (models.Offers, error) {
+	offer, err := r.Get(id)
+	if err == nil && offer.TenderID != tenderID {
+		return models.Offers{}, storage.ErrNotFound
+	}
+	return offer, err
+}
+
+func (r fakeOffers) OnTender(tenderID uint) ([]models.Offers, error) {
+	var offers []models.Offers
+	for _, offer := range r.s.offers {
+		if offer.TenderID == tenderID && offer.DeletedAt == nil {
+			offers = append(offers, offer)
+		}
+	}
+	return offers, nil
+}
+
+func (r fakeOffers) FindByContractor(contractorID uint) (models.Offers, error) {
+	for _, offer := range r.s.offers {
+		if offer.ContractorID == contractorID && offer.DeletedAt == nil {
+			return offer, nil
+		}
+	}
+	return models.Offers{}, storage.ErrNotFound
+}
+
+func (r fakeOffers) List(filter storage.OfferFilter, params listing.Params) ([]models.Offers, listing.Meta, error) {
+	r.s.listFilter = &filter
+	return nil, listing.Meta{}, nil
+}
+
+func (r fakeOffers) Stats(filter storage.OfferFilter, params listing.Params) ([]models.TenderStats, error) {
+	r.s.statsFilter = &filter
+	return r.s.stats, nil
+}
+
+func (r fakeOffers) Create(offer *models.Offers) error {
+	offer.ID = uint(len(r.s.offers) + 1)
+	for r.s.offers[offer.ID].ID != 0 {
+		offer.ID++
+	}
+	r.s.offers[offer.ID] = *offer
+	return nil
+}
+
+func (r fakeOffers) Update(id uint, fields map[string]interface{}) error {
+	offer, ok := r.s.offers[id]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	for column, value := range fields {
+		switch column {
+		case "price":
+			offer.Price = value.(decimal.Decimal)
+		case "currency":
+			offer.Currency = value.(string)
+		case "normalized_price":
+			offer.NormalizedPrice = value.(decimal.Decimal)
+		case "exchange_rate":
+			offer.ExchangeRate = value.(decimal.Decimal)
+		case "rate_date":
+			offer.RateDate = value.(*time.Time)
+		case "delivery_time":
+			offer.DeliveryTime = value.(*time.Time)
+		case "comments":
+			offer.Comments = value.(string)
+		case "status":
+			offer.Status = value.(bool)
+		case "organization_id":
+			offer.OrganizationID = value.(*uint)
+		default:
+			return errors.New("fakeOffers.Update: unsupported column " + column)
+		}
+	}
+	r.s.offers[id] = offer
+	return nil
+}
+
+type fakeOrganizations struct {
+	storage.OrganizationRepository
+	s *fakeStore
+}
+
+func (r fakeOrganizations) CanEdit(orgID, userID uint, kind string) (bool, error) {
+	role := r.s.members[orgID][userID]
+	return r.s.orgKinds[orgID] == kind && (role == models.OrgRoleOwner || role == models.OrgRoleEditor), nil
+}
+
+func (r fakeOrganizations) IsMember(orgID, userID uint, kind string) (bool, error) {
+	return r.s.orgKinds[orgID] == kind && r.s.members[orgID][userID] != "", nil
+}
+
+// wantKind fails the test unless err is a service error of the kind.
+func wantKind(t *testing.T, err error, kind Kind) {
+	t.Helper()
+
+	if err == nil {
+		t.Fatalf("got no error, want kind %d", kind)
+	}
+	if got := KindOf(err); got != kind {
+		t.Fatalf("got %v (kind %d), want kind %d", err, got, kind)
+	}
+}
+
+func wantEvents(t *testing.T, store *fakeStore, types ...events.Type) {
+	t.Helper()
+
+	if len(store.events) != len(types) {
+		t.Fatalf("published %d events %v, want %v", len(store.events), store.events, types)
+	}
+	for i, e := range store.events {
+		if e.Type != types[i] {
+			t.Errorf("event %d is %s, want %s", i, e.Type, types[i])
+		}
+	}
+}
+
+func at(d time.Duration) *time.Time {
+	t := time.Now().Add(d)
+	return &t
+}
+
+func amount(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"tender_management/models"
+	"tender_management/storage"
+	"tender_management/validation"
+	"time"
+)
+
+type NotifService struct {
+	store storage.Store
+	// allowPrivateURLs lets webhook URLs point to internal addresses.
+	allowPrivateURLs bool
+}
+
+func NewNotifService(store storage.Store, allowPrivateURLs bool) *NotifService {
+	return &NotifService{store: store, allowPrivateURLs: allowPrivateURLs}
+}
+
+// Create stores a notification as is. Notifications about tenders and
+// offers are created from their events instead.
+func (s *NotifService) Create(req models.NotifRequest) (models.Notif, error) {
+	notif := models.Notif{
+		UserID:     req.UserID,
+		Message:    req.Message,
+		RelationID: req.RelationID,
+		Type:       req.Type,
+	}
+
+	if err := s.store.Notifs().Create(&notif); err != nil {
+		return models.Notif{}, failed("Failed to create notification", err)
+	}
+	return notif, nil
+}
+
+// ForRelation returns the user's visible notifications about one tender or
+// offer, and NotFound if there are none.
+func (s *NotifService) ForRelation(userID, relationID uint) ([]models.Notif, error) {
+	notifs, err := s.store.Notifs().ForRelation(userID, relationID)
+	if err != nil {
+		return nil, failed("Failed to fetch notifications", err)
+	}
+
+	if len(notifs) == 0 {
+		return nil, missing("Notif not found")
+	}
+	return notifs, nil
+}
+
+// Inbox returns a page of the user's notifications and how many match in
+// total.
+func (s *NotifService) Inbox(userID uint, filter storage.InboxFilter, page, pageSize int) ([]models.Notif, int64, error) {
+	notifs, total, err := s.store.Notifs().Inbox(userID, filter, page, pageSize)
+	if err != nil {
+		return nil, 0, failed("Failed to fetch notifications", err)
+	}
+	return notifs, total, nil
+}
+
+func (s *NotifService) UnreadCount(userID uint) (int64, error) {
+	count, err := s.store.Notifs().UnreadCount(userID)
+	if err != nil {
+		return 0, failed("Failed to count notifications", err)
+	}
+	return count, nil
+}
+
+func (s *NotifService) MarkRead(userID, id uint) error {
+	if err := s.store.Notifs().MarkRead(userID, id, time.Now()); err != nil {
+		return lookup("Notif not found", "Failed to update notification", err)
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification of the user as read and
+// returns how many there were.
+func (s *NotifService) MarkAllRead(userID uint) (int64, error) {
+	updated, err := s.store.Notifs().MarkAllRead(userID, time.Now())
+	if err != nil {
+		return 0, failed("Failed to mark notifications as read", err)
+	}
+	return updated, nil
+}
+
+// Archive hides a notification from the inbox; it stays in the archive.
+func (s *NotifService) Archive(userID, id uint) error {
+	if err := s.store.Notifs().Archive(userID, id, time.Now()); err != nil {
+		return lookup("Notif not found", "Failed to update notification", err)
+	}
+	return nil
+}
+
+func (s *NotifService) Delete(userID, id uint) error {
+	if err := s.store.Notifs().Delete(userID, id); err != nil {
+		return lookup("Notif not found", "Failed to delete notification", err)
+	}
+	return nil
+}
+
+// Preferences returns the user's delivery preference for every type, the
+// default one for types the user has not configured.
+func (s *NotifService) Preferences(userID uint) ([]models.NotifPreference, error) {
+	rows, err := s.store.Notifs().Preferences(userID)
+	if err != nil {
+		return nil, failed("Failed to fetch preferences", err)
+	}
+
+	saved := make(map[models.NotifType]models.NotifPreference, len(rows))
+	for _, row := range rows {
+		saved[row.Type] = row
+	}
+
+	prefs := make([]models.NotifPreference, 0, len(models.NotifTypes))
+	for _, t := range models.NotifTypes {
+		pref, ok := saved[t]
+		if !ok {
+			pref = models.DefaultNotifPreference(userID, t)
+		}
+		prefs = append(prefs, pref)
+	}
+	return prefs, nil
+}
+
+// SetPreference saves the user's delivery preference for one type.
+// Webhook delivery needs a URL, which must not point to an internal host.
+func (s *NotifService) SetPreference(userID uint, req models.NotifPreferenceRequest) (models.NotifPreference, error) {
+	if req.Webhook && req.WebhookURL == "" {
+		return models.NotifPreference{}, invalid("webhook_url is required when webhook delivery is enabled", nil)
+	}
+	if req.WebhookURL != "" {
+		if err := validation.ValidateWebhookURL(req.WebhookURL, s.allowPrivateURLs); err != nil {
+			return models.NotifPreference{}, invalid("Invalid webhook_url: "+err.Error(), err)
+		}
+	}
+
+	pref := models.NotifPreference{
+		UserID:     userID,
+		Type:       req.Type,
+		InApp:      req.InApp,
+		Email:      req.Email,
+		SMS:        req.SMS,
+		Webhook:    req.Webhook,
+		WebhookURL: req.WebhookURL,
+		Mode:       req.Mode,
+	}
+	if err := s.store.Notifs().SavePreference(&pref); err != nil {
+		return models.NotifPreference{}, failed("Failed to save preference", err)
+	}
+	return pref, nil
+}
+
+// notifKinds are the outbox messages that create or deliver notifications.
+var notifKinds = []string{models.OutboxEvent, models.OutboxNotifDelivery, models.OutboxTelegram}
+
+// Reprocess queues the failed messages that create or deliver
+// notifications for the relay again and returns how many there were.
+func (s *NotifService) Reprocess() (int64, error) {
+	count, err := s.store.Outbox().RetryFailed(notifKinds)
+	if err != nil {
+		return 0, failed("Failed to retry notification messages", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/listing"
+	"tender_management/pkg/rates"
+	"tender_management/storage"
+	"tender_management/validation"
+	"time"
+)
+
+type OfferService struct {
+	store storage.Store
+	// currency is the base currency of the exchange rates.
+	currency string
+}
+
+func NewOfferService(store storage.Store, currency string) *OfferService {
+	return &OfferService{store: store, currency: currency}
+}
+
+// Create submits an offer on an open tender before its deadline, in any
+// currency the tender accepts. The contractor must be the user, or a
+// member of the organization the offer is made for, which only its editors
+// may do. The contractor must hold every qualification the tender
+// requires.
+func (s *OfferService) Create(userID uint, req models.OffersRequest) (models.Offers, error) {
+	deliveryTime, err := validation.ParseFutureTime(req.DeliveryTime)
+	if err != nil {
+		return models.Offers{}, invalid("invalid delivery time format", err)
+	}
+
+	tender, err := s.openTender(req.TenderID)
+	if err != nil {
+		return models.Offers{}, err
+	}
+
+	currency, err := checkPrice(req, tender)
+	if err != nil {
+		return models.Offers{}, err
+	}
+
+	if err := s.checkContractor(userID, req); err != nil {
+		return models.Offers{}, err
+	}
+
+	lacking, err := s.store.Tenders().MissingQualifications(tender.ID, req.ContractorID)
+	if err != nil {
+		return models.Offers{}, failed("Failed to check contractor qualifications", err)
+	}
+	if len(lacking) > 0 {
+		return models.Offers{}, forbidden("Contractor lacks required qualifications or documents have expired: " + strings.Join(lacking, ", "))
+	}
+
+	offer := models.Offers{
+		TenderID:       req.TenderID,
+		ContractorID:   req.ContractorID,
+		Price:          req.Price,
+		Currency:       currency,
+		DeliveryTime:   deliveryTime,
+		Comments:       req.Comments,
+		Status:         req.Status,
+		OrganizationID: req.OrganizationID,
+	}
+	if err := s.normalize(&offer, tender); err != nil {
+		return models.Offers{}, err
+	}
+
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Offers().Create(&offer); err != nil {
+			return err
+		}
+
+		return tx.Publish(events.Event{
+			Type:     events.OfferSubmitted,
+			TenderID: offer.TenderID,
+			OfferID:  offer.ID,
+			ActorID:  userID,
+		})
+	})
+	if err != nil {
+		return models.Offers{}, failed("Failed to create offer", err)
+	}
+	return offer, nil
+}
+
+// List returns a page of the live offers the user may see: their own, and
+// those on tenders they manage whose deadline has unsealed them.
+func (s *OfferService) List(userID uint, params listing.Params) ([]models.Offers, listing.Meta, error) {
+	offers, meta, err := s.store.Offers().List(storage.OfferFilter{VisibleTo: userID}, params)
+	if err != nil {
+		return nil, listing.Meta{}, failed("Failed to fetch offers", err)
+	}
+	return offers, meta, nil
+}
+
+// ByContractor returns a live offer of the contractor, who must be the
+// user.
+func (s *OfferService) ByContractor(userID, contractorID uint) (models.Offers, error) {
+	if userID != contractorID {
+		return models.Offers{}, forbidden("You can only fetch your own offers")
+	}
+
+	offer, err := s.store.Offers().FindByContractor(contractorID)
+	if err != nil {
+		return offer, lookup(constants.ErrRecordNotFound, "Failed to fetch offer", err)
+	}
+	return offer, nil
+}
+
+// Stats computes the statistics of every tender with offers matching the
+// listing filters, among the tenders the user manages whose deadline has
+// unsealed their offers.
+func (s *OfferService) Stats(userID uint, params listing.Params) ([]models.TenderStats, error) {
+	stats, err := s.store.Offers().Stats(storage.OfferFilter{ManagerID: userID, Unsealed: true}, params)
+	if err != nil {
+		return nil, failed("Failed to fetch statistics", err)
+	}
+	return stats, nil
+}
+
+// Update replaces the details of an offer the user manages while its
+// tender still accepts offers. The tender and contractor of an offer are
+// fixed, as its qualifications were checked against them. It returns the
+// offer as updated.
+func (s *OfferService) Update(userID, offerID uint, req models.OffersRequest) (models.Offers, error) {
+	deliveryTime, err := validation.ParseFutureTime(req.DeliveryTime)
+	if err != nil {
+		return models.Offers{}, invalid("invalid delivery time format", err)
+	}
+
+	offer, err := s.store.Offers().Get(offerID)
+	if err != nil {
+		return models.Offers{}, lookup("Offer not found", "Failed to fetch offer", err)
+	}
+
+	if err := s.authorize(userID, offer); err != nil {
+		return models.Offers{}, err
+	}
+
+	if req.TenderID != offer.TenderID || req.ContractorID != offer.ContractorID {
+		return models.Offers{}, invalid("The tender and contractor of an offer cannot be changed", nil)
+	}
+
+	tender, err := s.openTender(offer.TenderID)
+	if err != nil {
+		return models.Offers{}, err
+	}
+
+	currency, err := checkPrice(req, tender)
+	if err != nil {
+		return models.Offers{}, err
+	}
+
+	if err := s.checkContractor(userID, req); err != nil {
+		return models.Offers{}, err
+	}
+
+	offer.Price = req.Price
+	offer.Currency = currency
+	offer.DeliveryTime = deliveryTime
+	offer.Comments = req.Comments
+	offer.Status = req.Status
+	offer.OrganizationID = req.OrganizationID
+	if err := s.normalize(&offer, tender); err != nil {
+		return models.Offers{}, err
+	}
+
+	fields := map[string]interface{}{
+		"price":            offer.Price,
+		"currency":         offer.Currency,
+		"normalized_price": offer.NormalizedPrice,
+		"exchange_rate":    offer.ExchangeRate,
+		"rate_date":        offer.RateDate,
+		"delivery_time":    offer.DeliveryTime,
+		"comments":         offer.Comments,
+		"status":           offer.Status,
+		"organization_id":  offer.OrganizationID,
+	}
+
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Offers().Update(offer.ID, fields); err != nil {
+			return err
+		}
+
+		return tx.Publish(events.Event{
+			Type:     events.OfferUpdated,
+			TenderID: offer.TenderID,
+			OfferID:  offer.ID,
+			ActorID:  userID,
+		})
+	})
+	if err != nil {
+		return models.Offers{}, failed("Failed to update offer", err)
+	}
+	return offer, nil
+}
+
+// Delete soft deletes an offer the user manages.
+func (s *OfferService) Delete(userID, offerID uint) error {
+	offer, err := s.store.Offers().GetUnscoped(offerID)
+	if err != nil {
+		return lookup(constants.ErrRecordNotFound, "Failed to fetch offer", err)
+	}
+
+	if offer.DeletedAt != nil {
+		return missing("Offer already deleted")
+	}
+
+	if err := s.authorize(userID, offer); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.store.Offers().SetDeletedAt(&offer, &now); err != nil {
+		return failed("Failed to soft delete offer", err)
+	}
+	return nil
+}
+
+// Restore brings back a soft deleted offer the user manages.
+func (s *OfferService) Restore(userID, offerID uint) error {
+	offer, err := s.store.Offers().GetUnscoped(offerID)
+	if err != nil {
+		return lookup("Failed to find offer or it may not be soft deleted", "Failed to fetch offer", err)
+	}
+
+	if offer.DeletedAt == nil {
+		return invalid("Offer is not soft deleted", nil)
+	}
+
+	if err := s.authorize(userID, offer); err != nil {
+		return err
+	}
+
+	if err := s.store.Offers().SetDeletedAt(&offer, nil); err != nil {
+		return failed("Failed to restore offer", err)
+	}
+	return nil
+}
+
+// openTender loads a live tender and checks that it accepts offers.
+func (s *OfferService) openTender(tenderID uint) (models.Tenders, error) {
+	tender, err := s.store.Tenders().Get(tenderID)
+	if err != nil {
+		return tender, lookup("Tender not found", "Failed to fetch tender", err)
+	}
+
+	if !acceptsOffers(tender) {
+		return tender, invalid("Tender is closed for offers", nil)
+	}
+	return tender, nil
+}
+
+// checkContractor checks that the user may bid as the contractor of the
+// request: as themselves, or for an organization they edit that the
+// contractor belongs to.
+func (s *OfferService) checkContractor(userID uint, req models.OffersRequest) error {
+	if err := checkOrganization(s.store, req.OrganizationID, userID, constants.RoleContractor); err != nil {
+		return err
+	}
+	if req.ContractorID == userID {
+		return nil
+	}
+	if req.OrganizationID == nil {
+		return forbidden("You can only submit offers as yourself")
+	}
+
+	member, err := s.store.Organizations().IsMember(*req.OrganizationID, req.ContractorID, constants.RoleContractor)
+	if err != nil {
+		return failed("Failed to check organization membership", err)
+	}
+	if !member {
+		return forbidden("Contractor is not a member of this organization")
+	}
+	return nil
+}
+
+// checkPrice validates the offer price and returns its currency, which
+// must be one the tender accepts and defaults to the tender currency.
+func checkPrice(req models.OffersRequest, tender models.Tenders) (string, error) {
+	if err := validation.ValidateAmount(req.Price); err != nil {
+		return "", invalid("Invalid price: "+err.Error(), err)
+	}
+
+	if req.Currency == "" {
+		return tender.Currency, nil
+	}
+	currency, ok := validation.NormalizeCurrency(req.Currency)
+	if !ok {
+		return "", invalid("Currency must be an ISO 4217 code such as UZS", nil)
+	}
+
+	accepted := tender.SplitAcceptedCurrencies()
+	if len(accepted) == 0 {
+		accepted = []string{tender.Currency}
+	}
+	for _, code := range accepted {
+		if currency == code {
+			return currency, nil
+		}
+	}
+	return "", invalid("Tender only accepts offers in "+strings.Join(accepted, ", "), nil)
+}
+
+// normalize prices the offer in the tender currency at the latest rates
+// published, until the tender deadline revalues it.
+func (s *OfferService) normalize(offer *models.Offers, tender models.Tenders) error {
+	if offer.Currency == tender.Currency {
+		return normalize(offer, tender, rates.Table{Base: s.currency})
+	}
+
+	table, err := s.store.Rates().Table(s.currency, valuationDate(tender))
+	if err != nil {
+		return failed("Failed to load exchange rates", err)
+	}
+	if err := normalize(offer, tender, table); err != nil {
+		return rateError(err)
+	}
+	return nil
+}
+
+// authorize checks that the user may manage the offer, either as its
+// contractor or as an editor of its organization.
+func (s *OfferService) authorize(userID uint, offer models.Offers) error {
+	allowed, err := canManage(s.store, userID, offer.ContractorID, offer.OrganizationID, constants.RoleContractor)
+	if err != nil {
+		return failed("Failed to check offer ownership", err)
+	}
+	if !allowed {
+		return forbidden("You are not allowed to manage this offer")
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"tender_management/pkg/listing"
+	"testing"
+	"time"
+)
+
+const (
+	client     uint = 1
+	contractor uint = 2
+	colleague  uint = 3
+	stranger   uint = 4
+
+	clientOrg     uint = 10
+	contractorOrg uint = 20
+)
+
+// newOfferFixture stores an open tender of client and an offer of
+// contractor on it. colleague edits contractorOrg, which contractor is a
+// member of.
+func newOfferFixture() *fakeStore {
+	store := newFakeStore()
+	store.tenders[1] = models.Tenders{
+		ID:       1,
+		ClientID: client,
+		Deadline: at(24 * time.Hour),
+		Budget:   amount("1000.00"),
+		Currency: "UZS",
+		State:    models.TenderStateOpen,
+		Status:   true,
+	}
+	store.offers[1] = models.Offers{
+		ID:              1,
+		TenderID:        1,
+		ContractorID:    contractor,
+		Price:           amount("900.00"),
+		Currency:        "UZS",
+		NormalizedPrice: amount("900.00"),
+		ExchangeRate:    amount("1"),
+		DeliveryTime:    at(48 * time.Hour),
+		Status:          true,
+	}
+	store.addMember(contractorOrg, constants.RoleContractor, contractor, models.OrgRoleViewer)
+	store.addMember(contractorOrg, constants.RoleContractor, colleague, models.OrgRoleEditor)
+	return store
+}
+
+func offerRequest(contractorID uint, orgID *uint) models.OffersRequest {
+	return models.OffersRequest{
+		TenderID:       1,
+		ContractorID:   contractorID,
+		Price:          amount("850.00"),
+		DeliveryTime:   time.Now().Add(72 * time.Hour).Format(constants.Layout),
+		Comments:       "Delivery in three days",
+		Status:         true,
+		OrganizationID: orgID,
+	}
+}
+
+func TestCreateOffer(t *testing.T) {
+	store := newOfferFixture()
+	s := NewOfferService(store, "UZS")
+
+	offer, err := s.Create(contractor, offerRequest(contractor, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.ID == 0 || store.offers[offer.ID].ContractorID != contractor {
+		t.Fatalf("offer %+v was not stored", offer)
+	}
+	if offer.Currency != "UZS" || !offer.NormalizedPrice.Equal(amount("850.00")) {
+		t.Errorf("offer priced %s %s normalized to %s, want UZS 850.00", offer.Price, offer.Currency, offer.NormalizedPrice)
+	}
+	wantEvents(t, store, events.OfferSubmitted)
+}
+
+func TestCreateOfferAuthorization(t *testing.T) {
+	org := contractorOrg
+	otherOrg := uint(21)
+
+	tests := []struct {
+		name   string
+		userID uint
+		req    models.OffersRequest
+		want   Kind
+	}{
+		{"for another user", stranger, offerRequest(contractor, nil), Forbidden},
+		{"for an organization the user does not edit", contractor, offerRequest(contractor, &org), Forbidden},
+		{"for a contractor outside the organization", colleague, offerRequest(stranger, &org), Forbidden},
+		{"for an unknown organization", colleague, offerRequest(contractor, &otherOrg), Forbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOfferFixture()
+
+			_, err := NewOfferService(store, "UZS").Create(tt.userID, tt.req)
+			wantKind(t, err, tt.want)
+			if len(store.offers) != 1 {
+				t.Errorf("stored %d offers, want only the fixture", len(store.offers))
+			}
+			wantEvents(t, store)
+		})
+	}
+}
+
+func TestCreateOfferForOrganization(t *testing.T) {
+	store := newOfferFixture()
+	org := contractorOrg
+
+	offer, err := NewOfferService(store, "UZS").Create(colleague, offerRequest(contractor, &org))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.OrganizationID == nil || *offer.OrganizationID != org {
+		t.Errorf("offer organization is %v, want %d", offer.OrganizationID, org)
+	}
+	wantEvents(t, store, events.OfferSubmitted)
+}
+
+func TestCreateOfferChecksTender(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*models.Tenders)
+		want   Kind
+	}{
+		{"past its deadline", func(t *models.Tenders) { t.Deadline = at(-time.Hour) }, Invalid},
+		{"awarded", func(t *models.Tenders) { t.State = models.TenderStateAwarded }, Invalid},
+		{"deleted", func(t *models.Tenders) { t.DeletedAt = at(-time.Hour) }, NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOfferFixture()
+			tender := store.tenders[1]
+			tt.change(&tender)
+			store.tenders[1] = tender
+
+			_, err := NewOfferService(store, "UZS").Create(contractor, offerRequest(contractor, nil))
+			wantKind(t, err, tt.want)
+			wantEvents(t, store)
+		})
+	}
+}
+
+func TestCreateOfferRequiresQualifications(t *testing.T) {
+	store := newOfferFixture()
+	store.lacking[contractor] = []string{"ISO 9001"}
+
+	_, err := NewOfferService(store, "UZS").Create(contractor, offerRequest(contractor, nil))
+	wantKind(t, err, Forbidden)
+	wantEvents(t, store)
+}
+
+func TestCreateOfferRejectsCurrency(t *testing.T) {
+	store := newOfferFixture()
+	req := offerRequest(contractor, nil)
+	req.Currency = "USD"
+
+	_, err := NewOfferService(store, "UZS").Create(contractor, req)
+	wantKind(t, err, Invalid)
+}
+
+func TestUpdateOffer(t *testing.T) {
+	org := contractorOrg
+
+	tests := []struct {
+		name   string
+		userID uint
+		req    models.OffersRequest
+	}{
+		{"by its contractor", contractor, offerRequest(contractor, nil)},
+		{"by an editor of its organization", colleague, offerRequest(contractor, &org)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOfferFixture()
+			if tt.userID == colleague {
+				offer := store.offers[1]
+				offer.OrganizationID = &org
+				store.offers[1] = offer
+			}
+
+			offer, err := NewOfferService(store, "UZS").Update(tt.userID, 1, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stored := store.offers[1]
+			if !stored.Price.Equal(amount("850.00")) || !stored.NormalizedPrice.Equal(amount("850.00")) {
+				t.Errorf("stored price %s normalized to %s, want 850.00", stored.Price, stored.NormalizedPrice)
+			}
+			if offer.Comments != tt.req.Comments {
+				t.Errorf("returned comments %q, want %q", offer.Comments, tt.req.Comments)
+			}
+			wantEvents(t, store, events.OfferUpdated)
+		})
+	}
+}
+
+func TestUpdateOfferAuthorization(t *testing.T) {
+	org := contractorOrg
+	moved := offerRequest(contractor, nil)
+	moved.TenderID = 2
+
+	tests := []struct {
+		name    string
+		userID  uint
+		offerID uint
+		req     models.OffersRequest
+		want    Kind
+	}{
+		{"by another user", stranger, 1, offerRequest(contractor, nil), Forbidden},
+		{"by the tender client", client, 1, offerRequest(contractor, nil), Forbidden},
+		{"by an editor of an organization the offer is not for", colleague, 1, offerRequest(contractor, &org), Forbidden},
+		{"to another tender", contractor, 1, moved, Invalid},
+		{"to another contractor", contractor, 1, offerRequest(stranger, nil), Invalid},
+		{"that does not exist", contractor, 2, offerRequest(contractor, nil), NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOfferFixture()
+			before := store.offers[1]
+
+			_, err := NewOfferService(store, "UZS").Update(tt.userID, tt.offerID, tt.req)
+			wantKind(t, err, tt.want)
+			if !store.offers[1].Price.Equal(before.Price) {
+				t.Errorf("offer price changed to %s", store.offers[1].Price)
+			}
+			wantEvents(t, store)
+		})
+	}
+}
+
+func TestUpdateOfferAfterDeadline(t *testing.T) {
+	store := newOfferFixture()
+	tender := store.tenders[1]
+	tender.Deadline = at(-time.Hour)
+	store.tenders[1] = tender
+
+	_, err := NewOfferService(store, "UZS").Update(contractor, 1, offerRequest(contractor, nil))
+	wantKind(t, err, Invalid)
+	wantEvents(t, store)
+}
+
+func TestOfferStatsOnlyUnsealedManagedTenders(t *testing.T) {
+	store := newOfferFixture()
+	store.stats = []models.TenderStats{{TenderID: 1}}
+
+	stats, err := NewOfferService(store, "UZS").Stats(client, listing.Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("got %d stats, want 1", len(stats))
+	}
+	if f := store.statsFilter; f == nil || f.ManagerID != client || !f.Unsealed {
+		t.Errorf("stats filtered by %+v, want the client's unsealed tenders", f)
+	}
+}
+
+func TestListOffersVisibleToCaller(t *testing.T) {
+	store := newOfferFixture()
+
+	if _, _, err := NewOfferService(store, "UZS").List(client, listing.Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if f := store.listFilter; f == nil || f.VisibleTo != client || f.TenderID != 0 {
+		t.Errorf("offers filtered by %+v, want those visible to the client", f)
+	}
+}
+
+func TestOfferByContractor(t *testing.T) {
+	store := newOfferFixture()
+	s := NewOfferService(store, "UZS")
+
+	offer, err := s.ByContractor(contractor, contractor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.ID != 1 {
+		t.Errorf("got offer %d, want 1", offer.ID)
+	}
+
+	for _, userID := range []uint{client, colleague, stranger} {
+		_, err := s.ByContractor(userID, contractor)
+		wantKind(t, err, Forbidden)
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"tender_management/models"
+	"tender_management/pkg/rates"
+	"tender_management/storage"
+	"time"
+)
+
+// valuationDate is the date offers on a tender are compared at: its
+// deadline, or now while the deadline is still ahead. Offers valued before
+// the deadline are valued again once it passes.
+func valuationDate(tender models.Tenders) time.Time {
+	if tender.Deadline != nil && tender.Deadline.Before(time.Now()) {
+		return *tender.Deadline
+	}
+	return time.Now()
+}
+
+// normalize sets the normalized price of an offer in the tender currency,
+// with the exchange rate and rate date of table it was converted at.
+func normalize(offer *models.Offers, tender models.Tenders, table rates.Table) error {
+	quote, err := table.Quote(offer.Currency, tender.Currency)
+	if err != nil {
+		return err
+	}
+
+	offer.NormalizedPrice = quote.Convert(offer.Price)
+	offer.ExchangeRate = quote.Rate
+	offer.RateDate = quote.Date
+	return nil
+}
+
+// revalue converts the live offers on an open tender at the rates of its
+// valuation date and stores the ones that changed, so that rankings and
+// statistics after the deadline all use the deadline rates. The
+// Revaluations job runs it as deadlines pass, and evaluating a tender runs
+// it again in case rates were imported since. Offers on an awarded or
+// cancelled tender keep the rates they were evaluated at.
+func revalue(store storage.Store, base string, tender models.Tenders) error {
+	if tender.State != models.TenderStateOpen {
+		return nil
+	}
+
+	offers, err := store.Offers().OnTender(tender.ID)
+	if err != nil {
+		return failed("Failed to fetch offers", err)
+	}
+
+	var table *rates.Table
+	return store.Transaction(func(tx storage.Store) error {
+		for _, offer := range offers {
+			if offer.Currency == tender.Currency {
+				continue
+			}
+
+			if table == nil {
+				t, err := tx.Rates().Table(base, valuationDate(tender))
+				if err != nil {
+					return failed("Failed to load exchange rates", err)
+				}
+				table = &t
+			}
+
+			valued := offer
+			if err := normalize(&valued, tender, *table); err != nil {
+				return rateError(err)
+			}
+			if valued.NormalizedPrice.Equal(offer.NormalizedPrice) && valued.ExchangeRate.Equal(offer.ExchangeRate) &&
+				sameDate(valued.RateDate, offer.RateDate) {
+				continue
+			}
+
+			if err := tx.Offers().Update(offer.ID, map[string]interface{}{
+				"normalized_price": valued.NormalizedPrice,
+				"exchange_rate":    valued.ExchangeRate,
+				"rate_date":        valued.RateDate,
+			}); err != nil {
+				return failed("Failed to revalue offers", err)
+			}
+		}
+		return nil
+	})
+}
+
+// rateError reports a missing exchange rate as invalid input and anything
+// else as a failure.
+func rateError(err error) error {
+	if errors.Is(err, rates.ErrNoRate) {
+		return invalid("Exchange rate unavailable: "+err.Error(), err)
+	}
+	return failed("Failed to load exchange rates", err)
+}
+
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
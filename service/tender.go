@@ -0,0 +1,547 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/evaluation"
+	"tender_management/pkg/events"
+	"tender_management/pkg/listing"
+	"tender_management/pkg/rates"
+	"tender_management/pkg/search"
+	"tender_management/storage"
+	"tender_management/validation"
+	"time"
+)
+
+type TenderService struct {
+	store storage.Store
+	// currency is the ISO 4217 code of tenders that do not name one, and
+	// the base currency of the exchange rates.
+	currency string
+}
+
+func NewTenderService(store storage.Store, currency string) *TenderService {
+	return &TenderService{store: store, currency: currency}
+}
+
+// Create publishes a new tender for the user, who becomes its client. A
+// tender of an organization may only be created by its editors.
+func (s *TenderService) Create(userID uint, req models.TenderRequest) (models.Tenders, error) {
+	deadline, err := validation.ParseFutureTime(req.Deadline)
+	if err != nil {
+		return models.Tenders{}, invalid("invalid deadline time format", err)
+	}
+
+	if err := checkOrganization(s.store, req.OrganizationID, userID, constants.RoleClient); err != nil {
+		return models.Tenders{}, err
+	}
+
+	tender, err := s.fromRequest(req)
+	if err != nil {
+		return models.Tenders{}, err
+	}
+	tender.ClientID = userID
+	tender.Deadline = deadline
+
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Tenders().Create(&tender); err != nil {
+			return err
+		}
+
+		return tx.Publish(events.Event{
+			Type:     events.TenderPublished,
+			TenderID: tender.ID,
+			ActorID:  userID,
+		})
+	})
+	if err != nil {
+		return models.Tenders{}, failed("Failed to create tender", err)
+	}
+	return tender, nil
+}
+
+// fromRequest builds a tender, without its deadline, from req and checks
+// its budget, currencies, categories and criteria.
+func (s *TenderService) fromRequest(req models.TenderRequest) (models.Tenders, error) {
+	if err := validation.ValidateAmount(req.Budget); err != nil {
+		return models.Tenders{}, invalid("Invalid budget: "+err.Error(), err)
+	}
+
+	if req.Currency == "" {
+		req.Currency = s.currency
+	}
+	currency, ok := validation.NormalizeCurrency(req.Currency)
+	if !ok {
+		return models.Tenders{}, invalid("Currency must be an ISO 4217 code such as UZS", nil)
+	}
+
+	accepted, err := s.acceptedCurrencies(currency, req.AcceptedCurrencies)
+	if err != nil {
+		return models.Tenders{}, err
+	}
+
+	cats, err := s.store.Tenders().ResolveCategories(req.Categories)
+	if err != nil {
+		return models.Tenders{}, invalid(err.Error(), err)
+	}
+
+	criteria, err := evaluation.Criteria(req.Criteria)
+	if err != nil {
+		return models.Tenders{}, invalid(err.Error(), err)
+	}
+
+	return models.Tenders{
+		Title:                req.Title,
+		Description:          req.Description,
+		Budget:               req.Budget,
+		Currency:             currency,
+		HideBudget:           req.HideBudget,
+		AcceptedCurrencies:   strings.Join(accepted, ","),
+		AcceptedCurrencyList: accepted,
+		FileURL:              req.FileURL,
+		OrganizationID:       req.OrganizationID,
+		Qualifications:       tenderQualifications(req.Qualifications),
+		Categories:           cats,
+		Criteria:             criteria,
+	}, nil
+}
+
+// acceptedCurrencies normalizes the currencies a tender accepts besides
+// its own, which comes first, and checks that each has an exchange rate.
+func (s *TenderService) acceptedCurrencies(currency string, codes []string) ([]string, error) {
+	accepted := []string{currency}
+	seen := map[string]bool{currency: true}
+
+	for _, code := range codes {
+		normalized, ok := validation.NormalizeCurrency(code)
+		if !ok {
+			return nil, invalid("Accepted currencies must be ISO 4217 codes such as USD", nil)
+		}
+		if !seen[normalized] {
+			seen[normalized] = true
+			accepted = append(accepted, normalized)
+		}
+	}
+	if len(accepted) == 1 {
+		return accepted, nil
+	}
+	if len(strings.Join(accepted, ",")) > 100 {
+		return nil, invalid("Too many accepted currencies", nil)
+	}
+
+	table, err := s.store.Rates().Table(s.currency, time.Now())
+	if err != nil {
+		return nil, failed("Failed to load exchange rates", err)
+	}
+	for _, code := range accepted[1:] {
+		if _, err := table.Quote(code, currency); err != nil {
+			return nil, rateError(err)
+		}
+	}
+	return accepted, nil
+}
+
+// tenderQualifications normalizes the required qualification names and
+// drops duplicates.
+func tenderQualifications(names []string) []models.TenderQualification {
+	seen := make(map[string]bool, len(names))
+	quals := make([]models.TenderQualification, 0, len(names))
+
+	for _, name := range names {
+		code := validation.NormalizeQualification(name)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		quals = append(quals, models.TenderQualification{Qualification: code})
+	}
+	return quals
+}
+
+// List returns a page of live tenders, only those of the client unless
+// clientID is 0, and only those in any of the category codes if given.
+func (s *TenderService) List(clientID uint, categoryCodes []string, params listing.Params) ([]models.Tenders, listing.Meta, error) {
+	filter, err := s.filter(clientID, categoryCodes)
+	if err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	tenders, meta, err := s.store.Tenders().List(filter, params)
+	if err != nil {
+		return nil, listing.Meta{}, failed("Failed to fetch tenders", err)
+	}
+	return tenders, meta, nil
+}
+
+// Search runs a full-text query in the given language, or all languages
+// when lang is empty. Without a sort hits come by relevance, which can
+// only be paged by offset.
+func (s *TenderService) Search(text, lang string, categoryCodes []string, params listing.Params) ([]models.TenderSearchHit, listing.Meta, error) {
+	q, err := search.Parse(text, lang)
+	if err != nil {
+		return nil, listing.Meta{}, invalid(err.Error(), err)
+	}
+
+	if params.Keyset() && len(params.Sort) == 0 {
+		return nil, listing.Meta{}, invalid("cursor paging needs a sort, relevance order only pages by offset", nil)
+	}
+
+	filter, err := s.filter(0, categoryCodes)
+	if err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	hits, meta, err := s.store.Tenders().Search(q, filter, params)
+	if err != nil {
+		return nil, listing.Meta{}, failed("Failed to search tenders", err)
+	}
+	return hits, meta, nil
+}
+
+func (s *TenderService) filter(clientID uint, categoryCodes []string) (storage.TenderFilter, error) {
+	filter := storage.TenderFilter{ClientID: clientID}
+	if len(categoryCodes) == 0 {
+		return filter, nil
+	}
+
+	cats, err := s.store.Tenders().ResolveCategories(categoryCodes)
+	if err != nil {
+		return filter, invalid(err.Error(), err)
+	}
+	filter.Categories = cats
+	return filter, nil
+}
+
+// Offers returns a page of the offers on a tender the user manages, once
+// its deadline has unsealed them.
+func (s *TenderService) Offers(userID, tenderID uint, params listing.Params) ([]models.Offers, listing.Meta, error) {
+	tender, err := s.unsealed(userID, tenderID)
+	if err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	offers, meta, err := s.store.Offers().List(storage.OfferFilter{TenderID: tender.ID}, params)
+	if err != nil {
+		return nil, listing.Meta{}, failed("Failed to fetch offers", err)
+	}
+	return offers, meta, nil
+}
+
+// Compare scores the unsealed offers on a tender the user manages, at
+// their prices in the tender currency as of its deadline.
+func (s *TenderService) Compare(userID, tenderID uint) (models.TenderComparison, error) {
+	tender, err := s.unsealed(userID, tenderID)
+	if err != nil {
+		return models.TenderComparison{}, err
+	}
+
+	cmp, err := s.store.Tenders().Compare(tender)
+	if err != nil {
+		return models.TenderComparison{}, failed("Failed to compare offers", err)
+	}
+	ratesAsOf := rates.Day(valuationDate(tender))
+	cmp.RatesAsOf = &ratesAsOf
+	return cmp, nil
+}
+
+// Stats computes the offer statistics of a tender the user manages once
+// its offers are unsealed. A tender without offers has empty statistics.
+func (s *TenderService) Stats(userID, tenderID uint) (models.TenderStats, error) {
+	tender, err := s.unsealed(userID, tenderID)
+	if err != nil {
+		return models.TenderStats{}, err
+	}
+
+	stats, err := s.store.Offers().Stats(storage.OfferFilter{TenderID: tender.ID}, listing.Params{})
+	if err != nil {
+		return models.TenderStats{}, failed("Failed to fetch statistics", err)
+	}
+
+	if len(stats) == 0 {
+		return models.TenderStats{TenderID: tender.ID, Budget: tender.Budget, Currency: tender.Currency}, nil
+	}
+	return stats[0], nil
+}
+
+// unsealed loads a live tender with its criteria and checks that the user
+// manages it and that its bids may be opened. Its offers are then valued
+// at the exchange rates of its deadline.
+func (s *TenderService) unsealed(userID, tenderID uint) (models.Tenders, error) {
+	tender, err := s.managed(userID, tenderID)
+	if err != nil {
+		return tender, err
+	}
+
+	if tender.Deadline != nil && tender.Deadline.After(time.Now()) {
+		return tender, forbidden("Offers are sealed until the tender deadline")
+	}
+	return tender, revalue(s.store, s.currency, tender)
+}
+
+// Update replaces the details of a tender the user manages, together with
+// its qualifications, categories and criteria, while it is open and before
+// its deadline. Its client stays the same.
+// Moving the deadline re-arms the deadline reminder. It returns the tender
+// as updated.
+func (s *TenderService) Update(userID, tenderID uint, req models.TenderRequest) (models.Tenders, error) {
+	deadline, err := validation.ParseFutureTime(req.Deadline)
+	if err != nil {
+		return models.Tenders{}, invalid("Invalid deadline format", err)
+	}
+
+	current, err := s.managed(userID, tenderID)
+	if err != nil {
+		return models.Tenders{}, err
+	}
+	if !acceptsOffers(current) {
+		return models.Tenders{}, invalid("Tender cannot change once it is closed or past its deadline", nil)
+	}
+
+	// Offers are compared in the tender currency, so it stays as published.
+	if req.Currency == "" {
+		req.Currency = current.Currency
+	}
+	tender, err := s.fromRequest(req)
+	if err != nil {
+		return models.Tenders{}, err
+	}
+	if tender.Currency != current.Currency {
+		return models.Tenders{}, invalid("Tender currency cannot be changed from "+current.Currency, nil)
+	}
+	tender.ID = current.ID
+	tender.ClientID = current.ClientID
+	tender.Deadline = deadline
+
+	if err := checkOrganization(s.store, req.OrganizationID, userID, constants.RoleClient); err != nil {
+		return models.Tenders{}, err
+	}
+
+	fields := map[string]interface{}{
+		"title":               tender.Title,
+		"description":         tender.Description,
+		"deadline":            tender.Deadline,
+		"budget":              tender.Budget,
+		"hide_budget":         tender.HideBudget,
+		"accepted_currencies": tender.AcceptedCurrencies,
+		"file_url":            tender.FileURL,
+		"organization_id":     tender.OrganizationID,
+	}
+
+	if !deadline.Equal(*current.Deadline) {
+		fields["reminder_sent_at"] = nil
+	}
+
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Tenders().Update(&tender, fields); err != nil {
+			return err
+		}
+
+		return tx.Publish(events.Event{
+			Type:     events.TenderAmended,
+			TenderID: tender.ID,
+			ActorID:  userID,
+		})
+	})
+	if err != nil {
+		return models.Tenders{}, failed("Failed to update tender", err)
+	}
+	return tender, nil
+}
+
+// Delete soft deletes a tender the user manages.
+func (s *TenderService) Delete(userID, tenderID uint) error {
+	tender, err := s.store.Tenders().GetUnscoped(tenderID)
+	if err != nil {
+		return lookup(constants.ErrRecordNotFound, "Failed to fetch tender", err)
+	}
+
+	if tender.DeletedAt != nil {
+		return missing("Tender has been deleted")
+	}
+
+	if err := s.authorize(userID, tender); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.store.Tenders().SetDeletedAt(&tender, &now); err != nil {
+		return failed("Failed to soft delete tender", err)
+	}
+	return nil
+}
+
+// Restore brings back a soft deleted tender the user manages.
+func (s *TenderService) Restore(userID, tenderID uint) error {
+	tender, err := s.store.Tenders().GetUnscoped(tenderID)
+	if err != nil {
+		return lookup("Failed to find tender or it may not be soft deleted", "Failed to fetch tender", err)
+	}
+
+	if tender.DeletedAt == nil {
+		return invalid("Tender is not soft deleted", nil)
+	}
+
+	if err := s.authorize(userID, tender); err != nil {
+		return err
+	}
+
+	if err := s.store.Tenders().SetDeletedAt(&tender, nil); err != nil {
+		return failed("Failed to restore tender", err)
+	}
+	return nil
+}
+
+// Award closes an open tender the user manages after its deadline and
+// awards it to one of its offers.
+func (s *TenderService) Award(userID, tenderID, offerID uint) (models.Tenders, error) {
+	tender, err := s.managed(userID, tenderID)
+	if err != nil {
+		return tender, err
+	}
+
+	if tender.State != models.TenderStateOpen {
+		return tender, invalid("Tender is already "+tender.State, nil)
+	}
+
+	if tender.Deadline.After(time.Now()) {
+		return tender, invalid("Tender can only be awarded after its deadline", nil)
+	}
+
+	offer, err := s.store.Offers().GetOnTender(offerID, tender.ID)
+	if err != nil {
+		return tender, lookup("Offer not found on this tender", "Failed to fetch offer", err)
+	}
+
+	// The award fixes the valuation the offers were compared at.
+	if err := revalue(s.store, s.currency, tender); err != nil {
+		return tender, err
+	}
+
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Tenders().SetFields(&tender, map[string]interface{}{
+			"state":            models.TenderStateAwarded,
+			"status":           false,
+			"awarded_offer_id": offer.ID,
+			"awarded_at":       time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		return tx.Publish(events.Event{
+			Type:     events.TenderAwarded,
+			TenderID: tender.ID,
+			OfferID:  offer.ID,
+			ActorID:  userID,
+		})
+	})
+	if err != nil {
+		return tender, failed("Failed to award tender", err)
+	}
+	return tender, nil
+}
+
+// Revalue values the offers on open tenders whose deadline passed since
+// the given time at the rates of their deadline, so that offer lists rank
+// them as statistics and awards compare them. It carries on past a tender
+// that fails and returns the errors together.
+func (s *TenderService) Revalue(since time.Time) error {
+	tenders, err := s.store.Tenders().PastDeadline(since, time.Now())
+	if err != nil {
+		return failed("Failed to fetch tenders past their deadline", err)
+	}
+
+	var errs []error
+	for _, tender := range tenders {
+		if err := revalue(s.store, s.currency, tender); err != nil {
+			errs = append(errs, fmt.Errorf("tender %d: %w", tender.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Cancel cancels an open tender the user manages.
+func (s *TenderService) Cancel(userID, tenderID uint) (models.Tenders, error) {
+	tender, err := s.managed(userID, tenderID)
+	if err != nil {
+		return tender, err
+	}
+
+	if tender.State != models.TenderStateOpen {
+		return tender, invalid("Tender is already "+tender.State, nil)
+	}
+
+	if err := s.cancel(&tender, userID); err != nil {
+		return tender, failed("Failed to cancel tender", err)
+	}
+	return tender, nil
+}
+
+// Overdue returns the tenders still open although their deadline passed
+// before the given time, e.g. because their client never awarded them.
+func (s *TenderService) Overdue(before time.Time) ([]models.Tenders, error) {
+	tenders, err := s.store.Tenders().Overdue(before)
+	if err != nil {
+		return nil, failed("Failed to fetch overdue tenders", err)
+	}
+	return tenders, nil
+}
+
+// CancelOverdue cancels the tenders Overdue returns on behalf of an
+// operator and returns them. Bidders are notified as for any cancellation.
+func (s *TenderService) CancelOverdue(before time.Time) ([]models.Tenders, error) {
+	tenders, err := s.Overdue(before)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tenders {
+		if err := s.cancel(&tenders[i], 0); err != nil {
+			return tenders[:i], failed("Failed to cancel tender", err)
+		}
+	}
+	return tenders, nil
+}
+
+// cancel marks the tender cancelled and publishes the event, actorID 0
+// standing for the system.
+func (s *TenderService) cancel(tender *models.Tenders, actorID uint) error {
+	return s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Tenders().SetFields(tender, map[string]interface{}{
+			"state":  models.TenderStateCancelled,
+			"status": false,
+		}); err != nil {
+			return err
+		}
+
+		return tx.Publish(events.Event{
+			Type:     events.TenderCancelled,
+			TenderID: tender.ID,
+			ActorID:  actorID,
+		})
+	})
+}
+
+// managed loads a live tender and checks that the user manages it.
+func (s *TenderService) managed(userID, tenderID uint) (models.Tenders, error) {
+	tender, err := s.store.Tenders().Get(tenderID)
+	if err != nil {
+		return tender, lookup("Tender not found", "Failed to fetch tender", err)
+	}
+	return tender, s.authorize(userID, tender)
+}
+
+// authorize checks that the user may manage the tender, either as its
+// client or as an editor of its organization.
+func (s *TenderService) authorize(userID uint, tender models.Tenders) error {
+	allowed, err := canManage(s.store, userID, tender.ClientID, tender.OrganizationID, constants.RoleClient)
+	if err != nil {
+		return failed("Failed to check tender ownership", err)
+	}
+	if !allowed {
+		return forbidden("You are not allowed to manage this tender")
+	}
+	return nil
+}
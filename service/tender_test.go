@@ -0,0 +1,251 @@
+package service
+
+import (
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/events"
+	"testing"
+	"time"
+)
+
+// newAwardFixture is newOfferFixture with the tender deadline passed, a
+// second offer on it and an offer on another tender. colleague edits
+// clientOrg, which the tender belongs to.
+func newAwardFixture() *fakeStore {
+	store := newOfferFixture()
+	org := clientOrg
+
+	tender := store.tenders[1]
+	tender.Deadline = at(-time.Hour)
+	tender.OrganizationID = &org
+	store.tenders[1] = tender
+	store.tenders[2] = models.Tenders{
+		ID:       2,
+		ClientID: stranger,
+		Deadline: at(-time.Hour),
+		Currency: "UZS",
+		State:    models.TenderStateOpen,
+	}
+
+	store.offers[2] = models.Offers{ID: 2, TenderID: 1, ContractorID: stranger, Currency: "UZS"}
+	store.offers[3] = models.Offers{ID: 3, TenderID: 2, ContractorID: contractor, Currency: "UZS"}
+
+	store.addMember(clientOrg, constants.RoleClient, colleague, models.OrgRoleEditor)
+	store.addMember(clientOrg, constants.RoleClient, stranger, models.OrgRoleViewer)
+	return store
+}
+
+func TestAwardTender(t *testing.T) {
+	for _, userID := range []uint{client, colleague} {
+		store := newAwardFixture()
+
+		tender, err := NewTenderService(store, "UZS").Award(userID, 1, 2)
+		if err != nil {
+			t.Fatalf("user %d: %v", userID, err)
+		}
+
+		stored := store.tenders[1]
+		if stored.State != models.TenderStateAwarded || stored.Status {
+			t.Errorf("user %d: tender is %s with status %t, want awarded and inactive", userID, stored.State, stored.Status)
+		}
+		if stored.AwardedOfferID == nil || *stored.AwardedOfferID != 2 || stored.AwardedAt == nil {
+			t.Errorf("user %d: tender awarded to offer %v at %v, want offer 2", userID, stored.AwardedOfferID, stored.AwardedAt)
+		}
+		if tender.State != models.TenderStateAwarded {
+			t.Errorf("user %d: returned tender is %s", userID, tender.State)
+		}
+		wantEvents(t, store, events.TenderAwarded)
+	}
+}
+
+func TestAwardTenderRejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		offerID uint
+		change  func(*models.Tenders)
+		want    Kind
+	}{
+		{"by a contractor", contractor, 1, nil, Forbidden},
+		{"by a viewer of its organization", stranger, 1, nil, Forbidden},
+		{"before its deadline", client, 1, func(t *models.Tenders) { t.Deadline = at(time.Hour) }, Invalid},
+		{"when already awarded", client, 1, func(t *models.Tenders) { t.State = models.TenderStateAwarded }, Invalid},
+		{"when cancelled", client, 1, func(t *models.Tenders) { t.State = models.TenderStateCancelled }, Invalid},
+		{"to an offer on another tender", client, 3, nil, NotFound},
+		{"to an offer that does not exist", client, 9, nil, NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newAwardFixture()
+			if tt.change != nil {
+				tender := store.tenders[1]
+				tt.change(&tender)
+				store.tenders[1] = tender
+			}
+			state := store.tenders[1].State
+
+			_, err := NewTenderService(store, "UZS").Award(tt.userID, 1, tt.offerID)
+			wantKind(t, err, tt.want)
+			if got := store.tenders[1]; got.State != state || got.AwardedOfferID != nil {
+				t.Errorf("tender changed to %s awarded to %v", got.State, got.AwardedOfferID)
+			}
+			wantEvents(t, store)
+		})
+	}
+}
+
+func TestTenderStatsSealedUntilDeadline(t *testing.T) {
+	store := newOfferFixture()
+	store.stats = []models.TenderStats{{TenderID: 1, OfferCount: 1}}
+	s := NewTenderService(store, "UZS")
+
+	_, err := s.Stats(client, 1)
+	wantKind(t, err, Forbidden)
+	if store.statsFilter != nil {
+		t.Errorf("statistics were computed for %+v before the deadline", store.statsFilter)
+	}
+
+	tender := store.tenders[1]
+	tender.Deadline = at(-time.Hour)
+	store.tenders[1] = tender
+
+	stats, err := s.Stats(client, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.OfferCount != 1 || store.statsFilter == nil || store.statsFilter.TenderID != 1 {
+		t.Errorf("got %+v filtered by %+v, want the stats of tender 1", stats, store.statsFilter)
+	}
+}
+
+func TestTenderStatsManagersOnly(t *testing.T) {
+	store := newAwardFixture()
+
+	for _, userID := range []uint{contractor, stranger} {
+		_, err := NewTenderService(store, "UZS").Stats(userID, 1)
+		wantKind(t, err, Forbidden)
+	}
+	if store.statsFilter != nil {
+		t.Errorf("statistics were computed for %+v", store.statsFilter)
+	}
+}
+
+func TestTenderStatsWithoutOffers(t *testing.T) {
+	store := newAwardFixture()
+
+	stats, err := NewTenderService(store, "UZS").Stats(client, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TenderID != 1 || stats.OfferCount != 0 || stats.Currency != "UZS" {
+		t.Errorf("got %+v, want empty stats of tender 1", stats)
+	}
+}
+
+func TestRevalueTendersPastDeadline(t *testing.T) {
+	store := newFakeStore()
+	deadlines := map[uint]*time.Time{
+		1: at(-time.Hour),
+		2: at(-2 * time.Hour),
+		3: at(time.Hour),
+		4: at(-100 * time.Hour),
+	}
+	for id, deadline := range deadlines {
+		store.tenders[id] = models.Tenders{ID: id, Deadline: deadline, Currency: "UZS", State: models.TenderStateOpen}
+		store.offers[id] = models.Offers{ID: id, TenderID: id, Price: amount("100.00"), Currency: "USD"}
+	}
+	store.tenders[5] = models.Tenders{ID: 5, Deadline: at(-time.Hour), Currency: "UZS", State: models.TenderStateAwarded}
+	store.offers[5] = models.Offers{ID: 5, TenderID: 5, Price: amount("100.00"), Currency: "USD"}
+
+	err := NewTenderService(store, "UZS").Revalue(time.Now().Add(-72 * time.Hour))
+	if err == nil {
+		t.Fatal("got no error, want the missing rates of tenders 1 and 2")
+	}
+	for _, want := range []string{"tender 1:", "tender 2:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	// Only tenders 1 and 2 are open with a deadline in the window; each is
+	// valued at the rates of its deadline, one failing not stopping the other.
+	if len(store.rateDates) != 2 {
+		t.Fatalf("loaded rates for %v, want the deadlines of tenders 1 and 2", store.rateDates)
+	}
+	for _, date := range store.rateDates {
+		if !date.Equal(*deadlines[1]) && !date.Equal(*deadlines[2]) {
+			t.Errorf("loaded rates for %s, want a deadline of tender 1 or 2", date)
+		}
+	}
+}
+
+func tenderRequest() models.TenderRequest {
+	return models.TenderRequest{
+		Title:       "Office furniture",
+		Description: "Desks and chairs",
+		Deadline:    time.Now().Add(48 * time.Hour).Format(constants.Layout),
+		Budget:      amount("1000.00"),
+	}
+}
+
+func TestCreateTenderOwnedByCaller(t *testing.T) {
+	store := newFakeStore()
+
+	tender, err := NewTenderService(store, "UZS").Create(client, tenderRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := store.tenders[tender.ID].ClientID; got != client {
+		t.Errorf("tender stored for client %d, want the caller %d", got, client)
+	}
+	wantEvents(t, store, events.TenderPublished)
+}
+
+func TestUpdateTenderKeepsClient(t *testing.T) {
+	store := newAwardFixture()
+	tender := store.tenders[1]
+	tender.Deadline = at(time.Hour)
+	store.tenders[1] = tender
+	org := clientOrg
+	req := tenderRequest()
+	req.OrganizationID = &org
+
+	updated, err := NewTenderService(store, "UZS").Update(colleague, 1, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := store.tenders[1].ClientID; got != client || updated.ClientID != client {
+		t.Errorf("tender handed to client %d (returned %d), want %d", got, updated.ClientID, client)
+	}
+	if store.tenders[1].Title != req.Title {
+		t.Errorf("title is %q, want %q", store.tenders[1].Title, req.Title)
+	}
+	wantEvents(t, store, events.TenderAmended)
+}
+
+func TestUpdateTenderOnlyWhileOpen(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(*models.Tenders)
+	}{
+		{"past its deadline", func(t *models.Tenders) { t.Deadline = at(-time.Hour) }},
+		{"awarded", func(t *models.Tenders) { t.State = models.TenderStateAwarded }},
+		{"cancelled", func(t *models.Tenders) { t.State = models.TenderStateCancelled }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newOfferFixture()
+			tender := store.tenders[1]
+			tt.change(&tender)
+			store.tenders[1] = tender
+
+			_, err := NewTenderService(store, "UZS").Update(client, 1, tenderRequest())
+			wantKind(t, err, Invalid)
+			if store.tenders[1].Title != tender.Title {
+				t.Errorf("title changed to %q", store.tenders[1].Title)
+			}
+			wantEvents(t, store)
+		})
+	}
+}
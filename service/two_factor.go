@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/db/password"
+	"tender_management/pkg/totp"
+	"tender_management/pkg/utils"
+	"tender_management/storage"
+	"time"
+)
+
+const recoveryCodeCount = 10
+
+// UsedCodes remembers spent TOTP codes so that a code cannot be replayed
+// within its validity window. The Redis client implements it.
+type UsedCodes interface {
+	// SetNX sets key unless it exists, expiring after duration, and
+	// reports whether it was set.
+	SetNX(ctx context.Context, key string, value interface{}, duration time.Duration) (bool, error)
+}
+
+// EnrollTwoFactor generates a new TOTP secret for the user and returns it
+// with its otpauth URI. Two-factor authentication stays off until
+// ConfirmTwoFactor.
+func (s *UserService) EnrollTwoFactor(userID uint) (secret, uri string, err error) {
+	user, err := s.activeUser(userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if user.TwoFactorEnabled {
+		return "", "", invalid("Two-factor authentication already enabled", nil)
+	}
+
+	secret, err = totp.GenerateSecret()
+	if err != nil {
+		return "", "", failed("Failed to generate secret", err)
+	}
+
+	if err := s.store.Users().SetTwoFactor(user.ID, secret, false); err != nil {
+		return "", "", failed("Failed to save secret", err)
+	}
+	return secret, totp.URI(constants.TwoFactorIssuer, user.Email, secret), nil
+}
+
+// ConfirmTwoFactor turns two-factor authentication on once code matches
+// the secret from EnrollTwoFactor, and returns one-time recovery codes.
+func (s *UserService) ConfirmTwoFactor(userID uint, code string) ([]string, error) {
+	user, err := s.activeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, invalid("Two-factor authentication already enabled", nil)
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, invalid("Two-factor enrolment not started", nil)
+	}
+	if !totp.Validate(code, user.TwoFactorSecret, time.Now()) {
+		return nil, invalid("Invalid two-factor code", nil)
+	}
+
+	var codes []string
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Users().SetTwoFactor(user.ID, user.TwoFactorSecret, true); err != nil {
+			return err
+		}
+		codes, err = replaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, failed("Failed to enable two-factor authentication", err)
+	}
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user with
+// two-factor authentication on, given a current TOTP code.
+func (s *UserService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.activeUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled {
+		return nil, invalid("Two-factor authentication is not enabled", nil)
+	}
+	if !totp.Validate(code, user.TwoFactorSecret, time.Now()) {
+		return nil, invalid("Invalid two-factor code", nil)
+	}
+
+	codes, err := replaceRecoveryCodes(s.store, user.ID)
+	if err != nil {
+		return nil, failed("Failed to generate recovery codes", err)
+	}
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off for a user who
+// confirms their password and a second factor, unless their role requires
+// it.
+func (s *UserService) DisableTwoFactor(ctx context.Context, userID uint, plain, code string) error {
+	user, err := s.activeUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled {
+		return invalid("Two-factor authentication is not enabled", nil)
+	}
+
+	required, err := s.TwoFactorRequired(user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return forbidden("Two-factor authentication is mandatory for this role")
+	}
+
+	if !password.CheckPasswordHash(plain, user.Password) {
+		return &Error{Kind: Unauthorized, Message: "Invalid password"}
+	}
+
+	ok, err := s.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &Error{Kind: Unauthorized, Message: "Invalid two-factor code"}
+	}
+
+	err = s.store.Transaction(func(tx storage.Store) error {
+		if err := tx.Users().SetTwoFactor(user.ID, "", false); err != nil {
+			return err
+		}
+		return tx.Users().ReplaceRecoveryCodes(user.ID, nil)
+	})
+	if err != nil {
+		return failed("Failed to disable two-factor authentication", err)
+	}
+	return nil
+}
+
+// SecondFactor returns the verified user and whether code is a valid
+// second factor for them: a current TOTP code or an unused recovery code.
+func (s *UserService) SecondFactor(ctx context.Context, userID uint, code string) (models.Users, bool, error) {
+	user, err := s.activeUser(userID)
+	if err != nil {
+		return models.Users{}, false, err
+	}
+
+	ok, err := s.verifySecondFactor(ctx, user, code)
+	return user, ok, err
+}
+
+// TwoFactorRequired reports whether the policy of the role makes
+// two-factor authentication mandatory.
+func (s *UserService) TwoFactorRequired(role string) (bool, error) {
+	policy, err := s.store.Users().TwoFactorPolicy(role)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, failed("Failed to check two-factor policy", err)
+	}
+	return policy.Required, nil
+}
+
+// SetTwoFactorPolicy makes two-factor authentication mandatory, or
+// optional again, for every user of the role.
+func (s *UserService) SetTwoFactorPolicy(role string, required bool) (models.TwoFactorPolicy, error) {
+	policy := models.TwoFactorPolicy{Role: role, Required: required}
+	if err := s.store.Users().SaveTwoFactorPolicy(&policy); err != nil {
+		return models.TwoFactorPolicy{}, failed("Failed to save two-factor policy", err)
+	}
+	return policy, nil
+}
+
+// TwoFactorPolicies returns the policy of every role that has one.
+func (s *UserService) TwoFactorPolicies() ([]models.TwoFactorPolicy, error) {
+	policies, err := s.store.Users().TwoFactorPolicies()
+	if err != nil {
+		return nil, failed("Failed to fetch two-factor policies", err)
+	}
+	return policies, nil
+}
+
+// activeUser returns the verified user, failing as unauthorized when there
+// is none as the user comes from a token.
+func (s *UserService) activeUser(userID uint) (models.Users, error) {
+	user, err := s.store.Users().GetActive(userID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return models.Users{}, &Error{Kind: Unauthorized, Message: "User not found", Err: err}
+	}
+	if err != nil {
+		return models.Users{}, failed("Failed to fetch user", err)
+	}
+	return user, nil
+}
+
+// verifySecondFactor accepts either a current TOTP code, which cannot be
+// replayed within its validity window, or an unused recovery code.
+func (s *UserService) verifySecondFactor(ctx context.Context, user models.Users, code string) (bool, error) {
+	if totp.Validate(code, user.TwoFactorSecret, time.Now()) {
+		key := fmt.Sprintf("totp:%d:%s", user.ID, code)
+
+		// Marking the code used is the check, so concurrent requests
+		// cannot both spend it.
+		window := time.Duration(2*totp.Skew+1) * totp.Period
+		ok, err := s.used.SetNX(ctx, key, 1, window)
+		if err != nil {
+			return false, failed("Failed to verify two-factor code", err)
+		}
+		return ok, nil
+	}
+
+	ok, err := s.store.Users().UseRecoveryCode(user.ID, utils.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, failed("Failed to verify two-factor code", err)
+	}
+	return ok, nil
+}
+
+// replaceRecoveryCodes stores new recovery codes for the user in place of
+// the old ones and returns them; only their hashes are kept.
+func replaceRecoveryCodes(store storage.Store, userID uint) ([]string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := utils.RandomHex(5)
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, utils.HashToken(raw))
+	}
+
+	if err := store.Users().ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/db/password"
+	"tender_management/pkg/totp"
+	"testing"
+	"time"
+)
+
+const userPassword = "Secret123!"
+
+// userPasswordHash is hashed once, as bcrypt is slow on purpose.
+var userPasswordHash = sync.OnceValues(func() (string, error) {
+	return password.HashPassword(userPassword)
+})
+
+// newTwoFactorFixture stores client as a verified user without 2FA.
+func newTwoFactorFixture(t *testing.T) (*fakeStore, *UserService) {
+	t.Helper()
+
+	hash, err := userPasswordHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := newFakeStore()
+	store.users[client] = models.Users{
+		ID:       client,
+		Email:    "client@example.com",
+		Password: hash,
+		Role:     constants.RoleClient,
+		IsActive: true,
+	}
+	return store, NewUserService(store, fakeUsedCodes{})
+}
+
+// enable turns 2FA on for client and returns its secret.
+func enable(t *testing.T, store *fakeStore) string {
+	t.Helper()
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := store.users[client]
+	user.TwoFactorSecret = secret
+	user.TwoFactorEnabled = true
+	store.users[client] = user
+	return secret
+}
+
+func currentCode(t *testing.T, secret string) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestTwoFactorEnrolment(t *testing.T) {
+	store, s := newTwoFactorFixture(t)
+
+	secret, uri, err := s.EnrollTwoFactor(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user := store.users[client]; user.TwoFactorSecret != secret || user.TwoFactorEnabled {
+		t.Fatalf("stored secret %q enabled %t, want %q pending", user.TwoFactorSecret, user.TwoFactorEnabled, secret)
+	}
+	if !strings.HasPrefix(uri, "otpauth://totp/") || !strings.Contains(uri, "secret="+secret) {
+		t.Errorf("URI %q does not carry the secret", uri)
+	}
+
+	_, err = s.ConfirmTwoFactor(client, "000000")
+	wantKind(t, err, Invalid)
+	if store.users[client].TwoFactorEnabled {
+		t.Fatal("enabled with a wrong code")
+	}
+
+	codes, err := s.ConfirmTwoFactor(client, currentCode(t, secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !store.users[client].TwoFactorEnabled {
+		t.Error("not enabled after confirmation")
+	}
+	if len(codes) != recoveryCodeCount || len(store.recoveryCodes[client]) != recoveryCodeCount {
+		t.Errorf("got %d recovery codes, stored %d, want %d", len(codes), len(store.recoveryCodes[client]), recoveryCodeCount)
+	}
+	for hash := range store.recoveryCodes[client] {
+		for _, code := range codes {
+			if strings.Contains(hash, strings.ReplaceAll(code, "-", "")) {
+				t.Errorf("recovery code %s stored in plain text", code)
+			}
+		}
+	}
+
+	_, _, err = s.EnrollTwoFactor(client)
+	wantKind(t, err, Invalid)
+}
+
+func TestConfirmTwoFactorBeforeEnrolment(t *testing.T) {
+	_, s := newTwoFactorFixture(t)
+
+	_, err := s.ConfirmTwoFactor(client, "123456")
+	wantKind(t, err, Invalid)
+}
+
+func TestTwoFactorUnknownUser(t *testing.T) {
+	store, s := newTwoFactorFixture(t)
+	user := store.users[client]
+	user.IsActive = false
+	store.users[client] = user
+
+	_, _, err := s.EnrollTwoFactor(client)
+	wantKind(t, err, Unauthorized)
+	_, _, err = s.SecondFactor(context.Background(), stranger, "123456")
+	wantKind(t, err, Unauthorized)
+}
+
+func TestSecondFactorCodeNotReplayable(t *testing.T) {
+	store, s := newTwoFactorFixture(t)
+	code := currentCode(t, enable(t, store))
+
+	user, ok, err := s.SecondFactor(context.Background(), client, code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || user.ID != client {
+		t.Fatalf("code accepted %t for user %d, want accepted for %d", ok, user.ID, client)
+	}
+
+	if _, ok, err := s.SecondFactor(context.Background(), client, code); err != nil || ok {
+		t.Errorf("replayed code accepted %t (%v), want rejected", ok, err)
+	}
+}
+
+func TestSecondFactorRecoveryCodeUsedOnce(t *testing.T) {
+	store, s := newTwoFactorFixture(t)
+	secret := enable(t, store)
+
+	codes, err := s.RegenerateRecoveryCodes(client, currentCode(t, secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := " " + strings.ToUpper(codes[0]) + " "
+
+	if _, ok, err := s.SecondFactor(context.Background(), client, code); err != nil || !ok {
+		t.Fatalf("recovery code accepted %t (%v), want accepted", ok, err)
+	}
+	if _, ok, err := s.SecondFactor(context.Background(), client, code); err != nil || ok {
+		t.Errorf("used recovery code accepted %t (%v), want rejected", ok, err)
+	}
+	if _, ok, _ := s.SecondFactor(context.Background(), client, "aaaaa-bbbbb"); ok {
+		t.Error("unknown recovery code accepted")
+	}
+}
+
+func TestRegenerateRecoveryCodesRequiresCode(t *testing.T) {
+	store, s := newTwoFactorFixture(t)
+
+	_, err := s.RegenerateRecoveryCodes(client, "123456")
+	wantKind(t, err, Invalid)
+
+	enable(t, store)
+	_, err = s.RegenerateRecoveryCodes(client, "not a code")
+	wantKind(t, err, Invalid)
+	if len(store.recoveryCodes[client]) != 0 {
+		t.Errorf("stored %d recovery codes", len(store.recoveryCodes[client]))
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	store, s := newTwoFactorFixture(t)
+	secret := enable(t, store)
+	store.recoveryCodes[client] = map[string]bool{"hash": false}
+
+	if err := s.DisableTwoFactor(context.Background(), client, userPassword, currentCode(t, secret)); err != nil {
+		t.Fatal(err)
+	}
+	if user := store.users[client]; user.TwoFactorEnabled || user.TwoFactorSecret != "" {
+		t.Errorf("2FA enabled %t with secret %q after disabling", user.TwoFactorEnabled, user.TwoFactorSecret)
+	}
+	if len(store.recoveryCodes[client]) != 0 {
+		t.Errorf("%d recovery codes left", len(store.recoveryCodes[client]))
+	}
+}
+
+func TestDisableTwoFactorRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		required bool
+		password string
+		code     string
+		want     Kind
+	}{
+		{"when the role requires it", true, userPassword, "", Forbidden},
+		{"with a wrong password", false, "wrong", "", Unauthorized},
+		{"with a wrong code", false, userPassword, "000000", Unauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, s := newTwoFactorFixture(t)
+			secret := enable(t, store)
+			if tt.required {
+				if _, err := s.SetTwoFactorPolicy(constants.RoleClient, true); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := tt.code
+			if code == "" {
+				code = currentCode(t, secret)
+			}
+
+			err := s.DisableTwoFactor(context.Background(), client, tt.password, code)
+			wantKind(t, err, tt.want)
+			if !store.users[client].TwoFactorEnabled {
+				t.Error("2FA was disabled")
+			}
+		})
+	}
+}
+
+func TestTwoFactorRequired(t *testing.T) {
+	_, s := newTwoFactorFixture(t)
+
+	if required, err := s.TwoFactorRequired(constants.RoleClient); err != nil || required {
+		t.Fatalf("required %t (%v) without a policy, want optional", required, err)
+	}
+	if _, err := s.SetTwoFactorPolicy(constants.RoleClient, true); err != nil {
+		t.Fatal(err)
+	}
+	if required, err := s.TwoFactorRequired(constants.RoleClient); err != nil || !required {
+		t.Errorf("required %t (%v), want required", required, err)
+	}
+	if required, _ := s.TwoFactorRequired(constants.RoleContractor); required {
+		t.Error("policy of clients applies to contractors")
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/db/password"
+	"tender_management/storage"
+	"tender_management/validation"
+)
+
+type UserService struct {
+	store storage.Store
+	used  UsedCodes
+}
+
+// NewUserService returns the user service. used may be nil where second
+// factors are never verified, as in command line tools.
+func NewUserService(store storage.Store, used UsedCodes) *UserService {
+	return &UserService{store: store, used: used}
+}
+
+// CheckRegistration validates a registration and returns the hash of its
+// password. The account is only created once the phone number is verified.
+func (s *UserService) CheckRegistration(req models.UserRegister) (string, error) {
+	if !validation.IsValidPhoneNumber(req.PhoneNumber) {
+		return "", invalid("Invalid phone number format. Must start with +998 and be 12 digits long", nil)
+	}
+
+	if err := validation.ValidatePassword(req.Password); err != nil {
+		return "", invalid("Password validation failed", err)
+	}
+
+	hash, err := password.HashPassword(req.Password)
+	if err != nil {
+		return "", failed("Password hashing failed", err)
+	}
+	return hash, nil
+}
+
+// Create stores a user whose password is already hashed.
+func (s *UserService) Create(user models.Users) (models.Users, error) {
+	if err := s.store.Users().Create(&user); err != nil {
+		return models.Users{}, failed("Failed to create user", err)
+	}
+	return user, nil
+}
+
+// Authenticate returns the verified user with the email and password.
+func (s *UserService) Authenticate(email, plain string) (models.Users, error) {
+	user, err := s.store.Users().FindByEmail(email)
+	if err != nil {
+		return models.Users{}, lookup("User not found", "Failed to fetch user", err)
+	}
+
+	if !user.IsActive {
+		return models.Users{}, &Error{Kind: Unauthorized, Message: "User is not verified yet"}
+	}
+
+	if !password.CheckPasswordHash(plain, user.Password) {
+		return models.Users{}, &Error{Kind: Unauthorized, Message: "Invalid email or password"}
+	}
+	return user, nil
+}
+
+// ChangePassword replaces the password of a verified user who confirms the
+// current one.
+func (s *UserService) ChangePassword(userID uint, current, next string) error {
+	user, err := s.store.Users().GetActive(userID)
+	if err != nil {
+		return lookup("User not found for reset", "Failed to fetch user", err)
+	}
+
+	if !password.CheckPasswordHash(current, user.Password) {
+		return &Error{Kind: Unauthorized, Message: "Invalid password"}
+	}
+
+	hash, err := password.HashPassword(next)
+	if err != nil {
+		return failed("Password hashing failed", err)
+	}
+
+	if err := s.store.Users().SetPassword(user.ID, hash); err != nil {
+		return failed("Failed to reset password user", err)
+	}
+	return nil
+}
+
+// SetPassword replaces the password of a verified user without asking for
+// the current one, after a reset code or by an operator.
+func (s *UserService) SetPassword(userID uint, next string) error {
+	if err := validation.ValidatePassword(next); err != nil {
+		return invalid("NewPassword validation failed", err)
+	}
+
+	hash, err := password.HashPassword(next)
+	if err != nil {
+		return failed("NewPassword hashing failed", err)
+	}
+
+	if _, err := s.store.Users().GetActive(userID); err != nil {
+		return lookup("User not found for forgot password", "Failed to fetch user", err)
+	}
+
+	if err := s.store.Users().SetPassword(userID, hash); err != nil {
+		return failed("Failed to reset password user", err)
+	}
+	return nil
+}
+
+// FindByPhone returns the user registered with the phone number, which
+// must be well formed.
+func (s *UserService) FindByPhone(phoneNumber string) (models.Users, error) {
+	if !validation.IsValidPhoneNumber(phoneNumber) {
+		return models.Users{}, invalid("Invalid phone number format. Must start with +998 and be 12 digits long", nil)
+	}
+
+	user, err := s.store.Users().FindByPhone(phoneNumber)
+	if err != nil {
+		return models.Users{}, lookup("User not found with this phone number", "Database error", err)
+	}
+	return user, nil
+}
+
+// CreateAccount creates a user of any role on behalf of an operator,
+// skipping phone verification when active is set.
+func (s *UserService) CreateAccount(req models.UserRegister, active bool) (models.Users, error) {
+	switch req.Role {
+	case constants.RoleAdmin, constants.RoleClient, constants.RoleContractor:
+	default:
+		return models.Users{}, invalid("Role must be admin, client or contractor", nil)
+	}
+
+	if err := validation.ValidateEmail(req.Email); err != nil {
+		return models.Users{}, invalid("Invalid email", err)
+	}
+
+	hash, err := s.CheckRegistration(req)
+	if err != nil {
+		return models.Users{}, err
+	}
+
+	return s.Create(models.Users{
+		FirstName:   req.FirstName,
+		Email:       req.Email,
+		PhoneNumber: req.PhoneNumber,
+		Password:    hash,
+		Role:        req.Role,
+		IsActive:    active,
+	})
+}
+
+func (s *UserService) FindByEmail(email string) (models.Users, error) {
+	user, err := s.store.Users().FindByEmail(email)
+	if err != nil {
+		return models.Users{}, lookup("User not found", "Failed to fetch user", err)
+	}
+	return user, nil
+}
+
+// Activate marks the user with the email as verified, as phone
+// verification does.
+func (s *UserService) Activate(email string) (models.Users, error) {
+	user, err := s.FindByEmail(email)
+	if err != nil {
+		return user, err
+	}
+
+	if err := s.store.Users().Activate(user.ID); err != nil {
+		return user, failed("Failed to activate user", err)
+	}
+	user.IsActive = true
+	return user, nil
+}
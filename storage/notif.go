@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"tender_management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InboxFilter selects the notifications of an inbox page.
+type InboxFilter struct {
+	Type     models.NotifType
+	Unread   bool
+	Archived bool
+}
+
+// NotifRepository reads and changes the notifications of one user at a
+// time: a notification of another user is reported as ErrNotFound.
+type NotifRepository interface {
+	Create(notif *models.Notif) error
+	// ForRelation returns the visible notifications about one tender or
+	// offer, newest first.
+	ForRelation(userID, relationID uint) ([]models.Notif, error)
+	Inbox(userID uint, filter InboxFilter, page, pageSize int) ([]models.Notif, int64, error)
+	UnreadCount(userID uint) (int64, error)
+
+	// MarkRead and Archive leave notifications that already are alone.
+	MarkRead(userID, id uint, at time.Time) error
+	Archive(userID, id uint, at time.Time) error
+	MarkAllRead(userID uint, at time.Time) (int64, error)
+	Delete(userID, id uint) error
+
+	Preferences(userID uint) ([]models.NotifPreference, error)
+	// SavePreference creates or updates the user's preference for its type.
+	SavePreference(pref *models.NotifPreference) error
+}
+
+type notifStorage struct {
+	db *gorm.DB
+}
+
+func (s *notifStorage) Create(notif *models.Notif) error {
+	return s.db.Create(notif).Error
+}
+
+func (s *notifStorage) ForRelation(userID, relationID uint) ([]models.Notif, error) {
+	var notifs []models.Notif
+	err := s.db.Where("user_id = ? AND relation_id = ? AND inbox_hidden = ?", userID, relationID, false).
+		Order("created_at DESC").Find(&notifs).Error
+	return notifs, err
+}
+
+func (s *notifStorage) Inbox(userID uint, filter InboxFilter, page, pageSize int) ([]models.Notif, int64, error) {
+	query := s.db.Model(&models.Notif{}).Where("user_id = ? AND inbox_hidden = ?", userID, false)
+
+	if filter.Archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	if filter.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var totalRecords int64
+	if err := query.Count(&totalRecords).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifs []models.Notif
+	err := query.Order("created_at DESC").Order("id DESC").
+		Limit(pageSize).Offset((page - 1) * pageSize).Find(&notifs).Error
+	return notifs, totalRecords, err
+}
+
+func (s *notifStorage) UnreadCount(userID uint) (int64, error) {
+	var count int64
+	err := s.db.Model(&models.Notif{}).
+		Where("user_id = ? AND read_at IS NULL AND archived_at IS NULL AND inbox_hidden = ?", userID, false).
+		Count(&count).Error
+	return count, err
+}
+
+func (s *notifStorage) MarkRead(userID, id uint, at time.Time) error {
+	return s.updateOwn(userID, id, "read_at", at)
+}
+
+func (s *notifStorage) Archive(userID, id uint, at time.Time) error {
+	return s.updateOwn(userID, id, "archived_at", at)
+}
+
+// updateOwn sets a timestamp column of one of the user's notifications
+// unless it is set already.
+func (s *notifStorage) updateOwn(userID, id uint, column string, at time.Time) error {
+	var notif models.Notif
+	if err := s.db.Where("id = ? AND user_id = ?", id, userID).First(&notif).Error; err != nil {
+		return notFound(err)
+	}
+
+	return s.db.Model(&notif).Where(column+" IS NULL").Update(column, at).Error
+}
+
+func (s *notifStorage) MarkAllRead(userID uint, at time.Time) (int64, error) {
+	result := s.db.Model(&models.Notif{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (s *notifStorage) Delete(userID, id uint) error {
+	result := s.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Notif{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *notifStorage) Preferences(userID uint) ([]models.NotifPreference, error) {
+	var prefs []models.NotifPreference
+	err := s.db.Where("user_id = ?", userID).Find(&prefs).Error
+	return prefs, err
+}
+
+func (s *notifStorage) SavePreference(pref *models.NotifPreference) error {
+	saved := models.NotifPreference{UserID: pref.UserID, Type: pref.Type}
+	if err := s.db.Where("user_id = ? AND type = ?", pref.UserID, pref.Type).
+		Assign(map[string]interface{}{
+			"in_app":      pref.InApp,
+			"email":       pref.Email,
+			"sms":         pref.SMS,
+			"webhook":     pref.Webhook,
+			"webhook_url": pref.WebhookURL,
+			"mode":        pref.Mode,
+		}).
+		FirstOrCreate(&saved).Error; err != nil {
+		return err
+	}
+
+	*pref = saved
+	return nil
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"tender_management/constants"
+	"tender_management/models"
+	"tender_management/pkg/evaluation"
+	"tender_management/pkg/listing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OfferFilter narrows offer lists on top of the listing filters. Zero
+// values do not filter.
+type OfferFilter struct {
+	TenderID uint
+	// ManagerID keeps the offers on tenders the user manages, as their
+	// client or as an editor of their organization.
+	ManagerID uint
+	// Unsealed keeps the offers on tenders whose deadline has passed.
+	Unsealed bool
+	// VisibleTo keeps the offers the user may see: those they manage, as
+	// their contractor or as an editor of their organization, and those on
+	// tenders they manage whose deadline has passed.
+	VisibleTo uint
+}
+
+type OfferRepository interface {
+	// Get returns a live offer.
+	Get(id uint) (models.Offers, error)
+	// GetUnscoped returns an offer whether or not it is soft deleted.
+	GetUnscoped(id uint) (models.Offers, error)
+	// GetOnTender returns a live offer only if it was made on the tender.
+	GetOnTender(id, tenderID uint) (models.Offers, error)
+	// FindByContractor returns a live offer of the contractor.
+	FindByContractor(contractorID uint) (models.Offers, error)
+	List(filter OfferFilter, params listing.Params) ([]models.Offers, listing.Meta, error)
+	// OnTender returns every live offer on the tender.
+	OnTender(tenderID uint) ([]models.Offers, error)
+	// Each passes every live offer to fn, batchSize at a time in ID order.
+	Each(batchSize int, fn func([]models.Offers) error) error
+	// Stats computes the offer statistics of every tender with matching
+	// offers.
+	Stats(filter OfferFilter, params listing.Params) ([]models.TenderStats, error)
+
+	Create(offer *models.Offers) error
+	Update(id uint, fields map[string]interface{}) error
+	SetDeletedAt(offer *models.Offers, deletedAt *time.Time) error
+}
+
+type offerStorage struct {
+	db *gorm.DB
+}
+
+func (s *offerStorage) Get(id uint) (models.Offers, error) {
+	var offer models.Offers
+	err := s.db.Where("id = ? AND deleted_at IS NULL", id).First(&offer).Error
+	return offer, notFound(err)
+}
+
+func (s *offerStorage) GetUnscoped(id uint) (models.Offers, error) {
+	var offer models.Offers
+	err := s.db.Where("id = ?", id).First(&offer).Error
+	return offer, notFound(err)
+}
+
+func (s *offerStorage) GetOnTender(id, tenderID uint) (models.Offers, error) {
+	var offer models.Offers
+	err := s.db.Where("id = ? AND tender_id = ? AND deleted_at IS NULL", id, tenderID).First(&offer).Error
+	return offer, notFound(err)
+}
+
+func (s *offerStorage) FindByContractor(contractorID uint) (models.Offers, error) {
+	var offer models.Offers
+	err := s.db.Where("contractor_id = ? AND deleted_at IS NULL", contractorID).First(&offer).Error
+	return offer, notFound(err)
+}
+
+func (s *offerStorage) List(filter OfferFilter, params listing.Params) ([]models.Offers, listing.Meta, error) {
+	query := params.Filter(s.filter(s.db.Model(&models.Offers{}).Where("offers.deleted_at IS NULL"), filter))
+
+	totalRecords, err := countTotal(query, params)
+	if err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	var offers []models.Offers
+	if err := params.Paginate(params.Select(query)).Find(&offers).Error; err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	meta, err := params.Finish(&offers, totalRecords)
+	return offers, meta, err
+}
+
+func (s *offerStorage) OnTender(tenderID uint) ([]models.Offers, error) {
+	var offers []models.Offers
+	err := s.db.Where("tender_id = ? AND deleted_at IS NULL", tenderID).Order("id").Find(&offers).Error
+	return offers, err
+}
+
+func (s *offerStorage) Each(batchSize int, fn func([]models.Offers) error) error {
+	var offers []models.Offers
+	return s.db.Where("deleted_at IS NULL").
+		FindInBatches(&offers, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(offers)
+		}).Error
+}
+
+func (s *offerStorage) Stats(filter OfferFilter, params listing.Params) ([]models.TenderStats, error) {
+	return evaluation.Stats(s.db, params.Filter(s.filter(s.db.Model(&models.Offers{}), filter)))
+}
+
+func (s *offerStorage) filter(query *gorm.DB, filter OfferFilter) *gorm.DB {
+	if filter.TenderID != 0 {
+		query = query.Where("offers.tender_id = ?", filter.TenderID)
+	}
+	if filter.ManagerID != 0 || filter.Unsealed {
+		query = query.Where("offers.tender_id IN (?)", s.tenders(filter.ManagerID, filter.Unsealed))
+	}
+	if filter.VisibleTo != 0 {
+		query = query.Where("(offers.contractor_id = ? OR offers.organization_id IN (?) OR offers.tender_id IN (?))",
+			filter.VisibleTo, editedBy(s.db, filter.VisibleTo, constants.RoleContractor), s.tenders(filter.VisibleTo, true))
+	}
+	return query
+}
+
+// tenders selects the IDs of live tenders the manager manages, or of any
+// when managerID is 0, keeping only those past their deadline if unsealed.
+func (s *offerStorage) tenders(managerID uint, unsealed bool) *gorm.DB {
+	tenders := s.db.Model(&models.Tenders{}).Select("tenders.id").Where("tenders.deleted_at IS NULL")
+	if managerID != 0 {
+		tenders = tenders.Where("(tenders.client_id = ? OR tenders.organization_id IN (?))",
+			managerID, editedBy(s.db, managerID, constants.RoleClient))
+	}
+	if unsealed {
+		tenders = tenders.Where("tenders.deadline <= ?", time.Now())
+	}
+	return tenders
+}
+
+func (s *offerStorage) Create(offer *models.Offers) error {
+	return s.db.Create(offer).Error
+}
+
+func (s *offerStorage) Update(id uint, fields map[string]interface{}) error {
+	return s.db.Model(&models.Offers{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (s *offerStorage) SetDeletedAt(offer *models.Offers, deletedAt *time.Time) error {
+	if err := s.db.Model(&models.Offers{}).Where("id = ?", offer.ID).Update("deleted_at", deletedAt).Error; err != nil {
+		return err
+	}
+	offer.DeletedAt = deletedAt
+	return nil
+}
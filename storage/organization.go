@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"errors"
+	"tender_management/models"
+
+	"gorm.io/gorm"
+)
+
+type OrganizationRepository interface {
+	// CanEdit reports whether the user is an owner or editor of the live
+	// organization of the given kind, client or contractor.
+	CanEdit(orgID, userID uint, kind string) (bool, error)
+	// IsMember reports whether the user belongs to the live organization
+	// of the given kind, in any role.
+	IsMember(orgID, userID uint, kind string) (bool, error)
+}
+
+type organizationStorage struct {
+	db *gorm.DB
+}
+
+func (s *organizationStorage) CanEdit(orgID, userID uint, kind string) (bool, error) {
+	var member models.OrganizationMember
+
+	err := s.db.Joins("JOIN organizations ON organizations.id = organization_members.organization_id").
+		Where("organization_members.organization_id = ? AND organization_members.user_id = ?", orgID, userID).
+		Where("organizations.kind = ? AND organizations.deleted_at IS NULL", kind).
+		First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return member.Role == models.OrgRoleOwner || member.Role == models.OrgRoleEditor, nil
+}
+
+func (s *organizationStorage) IsMember(orgID, userID uint, kind string) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.OrganizationMember{}).
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id").
+		Where("organization_members.organization_id = ? AND organization_members.user_id = ?", orgID, userID).
+		Where("organizations.kind = ? AND organizations.deleted_at IS NULL", kind).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// editedBy selects the IDs of the live organizations of the given kind the
+// user owns or edits.
+func editedBy(db *gorm.DB, userID uint, kind string) *gorm.DB {
+	return db.Model(&models.OrganizationMember{}).
+		Select("organization_members.organization_id").
+		Joins("JOIN organizations ON organizations.id = organization_members.organization_id").
+		Where("organization_members.user_id = ? AND organization_members.role IN ?",
+			userID, []string{models.OrgRoleOwner, models.OrgRoleEditor}).
+		Where("organizations.kind = ? AND organizations.deleted_at IS NULL", kind)
+}
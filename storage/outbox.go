@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"tender_management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxRepository interface {
+	// RetryFailed queues the failed messages of the given kinds for
+	// delivery again and returns how many there were.
+	RetryFailed(kinds []string) (int64, error)
+}
+
+type outboxStorage struct {
+	db *gorm.DB
+}
+
+func (s *outboxStorage) RetryFailed(kinds []string) (int64, error) {
+	result := s.db.Model(&models.OutboxMessage{}).
+		Where("status = ? AND kind IN ?", models.OutboxFailed, kinds).
+		Updates(map[string]interface{}{
+			"status":          models.OutboxPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"tender_management/pkg/rates"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RateRepository interface {
+	// Table loads the exchange rates in force on the date of asOf against
+	// the base currency.
+	Table(base string, asOf time.Time) (rates.Table, error)
+}
+
+type rateStorage struct {
+	db *gorm.DB
+}
+
+func (s *rateStorage) Table(base string, asOf time.Time) (rates.Table, error) {
+	return rates.Load(s.db, base, asOf)
+}
@@ -0,0 +1,102 @@
+// Package storage holds the repositories of the domain models. Each
+// repository is an interface so that services can be tested against fakes;
+// New returns the implementation backed by gorm.
+package storage
+
+import (
+	"errors"
+	"tender_management/pkg/events"
+	"tender_management/pkg/listing"
+	"tender_management/pkg/outbox"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested row does not exist or is not
+// visible, e.g. because it was soft deleted.
+var ErrNotFound = errors.New("record not found")
+
+// Store gives access to the repositories. The repositories of the Store a
+// Transaction passes to fn all run in that transaction.
+type Store interface {
+	Users() UserRepository
+	Tenders() TenderRepository
+	Offers() OfferRepository
+	Notifs() NotifRepository
+	Organizations() OrganizationRepository
+	Outbox() OutboxRepository
+	Rates() RateRepository
+
+	// Publish stores a domain event in the outbox. Call it inside a
+	// Transaction so that the event is only delivered if the change commits.
+	Publish(e events.Event) error
+	Transaction(fn func(tx Store) error) error
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+func New(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Users() UserRepository {
+	return &userStorage{db: s.db}
+}
+
+func (s *gormStore) Tenders() TenderRepository {
+	return &tenderStorage{db: s.db}
+}
+
+func (s *gormStore) Offers() OfferRepository {
+	return &offerStorage{db: s.db}
+}
+
+func (s *gormStore) Notifs() NotifRepository {
+	return &notifStorage{db: s.db}
+}
+
+func (s *gormStore) Organizations() OrganizationRepository {
+	return &organizationStorage{db: s.db}
+}
+
+func (s *gormStore) Outbox() OutboxRepository {
+	return &outboxStorage{db: s.db}
+}
+
+func (s *gormStore) Rates() RateRepository {
+	return &rateStorage{db: s.db}
+}
+
+func (s *gormStore) Publish(e events.Event) error {
+	return outbox.Publish(s.db, e)
+}
+
+func (s *gormStore) Transaction(fn func(tx Store) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&gormStore{db: tx})
+	})
+}
+
+// notFound turns gorm's missing row error into ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// countTotal counts the rows query matches when the list request wants a
+// total, and returns nil otherwise.
+func countTotal(query *gorm.DB, params listing.Params) (*int64, error) {
+	if !params.WantTotal() {
+		return nil, nil
+	}
+
+	var totalRecords int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRecords).Error; err != nil {
+		return nil, err
+	}
+	return &totalRecords, nil
+}
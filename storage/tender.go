@@ -0,0 +1,269 @@
+package storage
+
+import (
+	"tender_management/models"
+	"tender_management/pkg/categories"
+	"tender_management/pkg/evaluation"
+	"tender_management/pkg/listing"
+	"tender_management/pkg/search"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TenderFilter narrows tender lists on top of the listing filters. Zero
+// values do not filter.
+type TenderFilter struct {
+	ClientID uint
+	// Categories keeps the tenders tagged with any of them or their
+	// descendants.
+	Categories []models.Category
+}
+
+type TenderRepository interface {
+	// Get returns a live tender with its criteria.
+	Get(id uint) (models.Tenders, error)
+	// GetUnscoped returns a tender whether or not it is soft deleted.
+	GetUnscoped(id uint) (models.Tenders, error)
+	List(filter TenderFilter, params listing.Params) ([]models.Tenders, listing.Meta, error)
+	Search(q search.Query, filter TenderFilter, params listing.Params) ([]models.TenderSearchHit, listing.Meta, error)
+	// Overdue returns the live tenders still open although their deadline
+	// passed before the given time.
+	Overdue(before time.Time) ([]models.Tenders, error)
+	// PastDeadline returns the live tenders still open whose deadline
+	// passed at or after since and before until.
+	PastDeadline(since, until time.Time) ([]models.Tenders, error)
+	// Each passes every live tender with its associations to fn, batchSize
+	// at a time in ID order.
+	Each(batchSize int, fn func([]models.Tenders) error) error
+
+	// Create stores the tender with its qualifications, categories and
+	// criteria and indexes it for search.
+	Create(tender *models.Tenders) error
+	// Update sets fields and replaces the qualifications, categories and
+	// criteria of the tender, then indexes it again.
+	Update(tender *models.Tenders, fields map[string]interface{}) error
+	// SetFields updates columns of the tender without touching its
+	// associations, and reflects them in tender.
+	SetFields(tender *models.Tenders, fields map[string]interface{}) error
+	SetDeletedAt(tender *models.Tenders, deletedAt *time.Time) error
+
+	// ResolveCategories loads the categories with the given codes and
+	// reports any unknown code.
+	ResolveCategories(codes []string) ([]models.Category, error)
+	// MissingQualifications returns the qualifications the tender requires
+	// that the contractor has no unexpired document for.
+	MissingQualifications(tenderID, contractorID uint) ([]string, error)
+	// Compare scores the live offers on the tender against its criteria.
+	Compare(tender models.Tenders) (models.TenderComparison, error)
+}
+
+type tenderStorage struct {
+	db *gorm.DB
+}
+
+func (s *tenderStorage) Get(id uint) (models.Tenders, error) {
+	var tender models.Tenders
+	err := s.db.Preload("Criteria").Where("id = ? AND deleted_at IS NULL", id).First(&tender).Error
+	tender.AcceptedCurrencyList = tender.SplitAcceptedCurrencies()
+	return tender, notFound(err)
+}
+
+func (s *tenderStorage) GetUnscoped(id uint) (models.Tenders, error) {
+	var tender models.Tenders
+	err := s.db.Where("id = ?", id).First(&tender).Error
+	return tender, notFound(err)
+}
+
+func (s *tenderStorage) List(filter TenderFilter, params listing.Params) ([]models.Tenders, listing.Meta, error) {
+	query := params.Filter(s.filter(s.db.Model(&models.Tenders{}).Where("deleted_at IS NULL"), filter))
+
+	totalRecords, err := countTotal(query, params)
+	if err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	var tenders []models.Tenders
+	if err := params.Paginate(params.Select(query)).Preload("Qualifications").Preload("Categories").Preload("Criteria").Find(&tenders).Error; err != nil {
+		return nil, listing.Meta{}, err
+	}
+	for i := range tenders {
+		tenders[i].AcceptedCurrencyList = tenders[i].SplitAcceptedCurrencies()
+	}
+
+	meta, err := params.Finish(&tenders, totalRecords)
+	return tenders, meta, err
+}
+
+func (s *tenderStorage) Search(q search.Query, filter TenderFilter, params listing.Params) ([]models.TenderSearchHit, listing.Meta, error) {
+	query := s.db.Table("tenders").
+		Joins("CROSS JOIN (SELECT "+q.Expr+" AS query) AS q", q.Args...).
+		Where("tenders.search_vector @@ q.query AND tenders.deleted_at IS NULL")
+	query = params.Filter(s.filter(query, filter))
+
+	totalRecords, err := countTotal(query, params)
+	if err != nil {
+		return nil, listing.Meta{}, err
+	}
+
+	query = query.
+		Select("tenders.id, tenders.title, tenders.deadline, tenders.budget, tenders.currency, tenders.state, tenders.client_id, tenders.organization_id, "+
+			"tenders.created_at, tenders.updated_at, "+
+			"ts_rank_cd(tenders.search_vector, q.query) AS rank, "+
+			"ts_headline(?::regconfig, tenders.title, q.query, ?) AS headline, "+
+			"ts_headline(?::regconfig, tenders.description, q.query, ?) AS snippet",
+			q.Config, search.TitleHeadlineOptions, q.Config, search.HeadlineOptions)
+	if len(params.Sort) == 0 {
+		query = query.Order("rank DESC")
+	}
+
+	var hits []models.TenderSearchHit
+	if err := params.Paginate(query).Scan(&hits).Error; err != nil {
+		return nil, listing.Meta{}, err
+	}
+	for i := range hits {
+		hits[i].Headline = search.Highlight(hits[i].Headline)
+		hits[i].Snippet = search.Highlight(hits[i].Snippet)
+	}
+
+	meta, err := params.Finish(&hits, totalRecords)
+	return hits, meta, err
+}
+
+func (s *tenderStorage) filter(query *gorm.DB, filter TenderFilter) *gorm.DB {
+	if filter.ClientID != 0 {
+		query = query.Where("tenders.client_id = ?", filter.ClientID)
+	}
+	if len(filter.Categories) > 0 {
+		query = query.Where("tenders.id IN (?)", categories.TenderIDs(s.db, filter.Categories))
+	}
+	return query
+}
+
+func (s *tenderStorage) Create(tender *models.Tenders) error {
+	if err := s.db.Create(tender).Error; err != nil {
+		return err
+	}
+	return search.RefreshTender(s.db, tender.ID)
+}
+
+func (s *tenderStorage) Update(tender *models.Tenders, fields map[string]interface{}) error {
+	if err := s.db.Model(&models.Tenders{}).Where("id = ?", tender.ID).Updates(fields).Error; err != nil {
+		return err
+	}
+
+	if err := s.db.Where("tender_id = ?", tender.ID).Delete(&models.TenderQualification{}).Error; err != nil {
+		return err
+	}
+	for i := range tender.Qualifications {
+		tender.Qualifications[i].TenderID = tender.ID
+	}
+	if len(tender.Qualifications) > 0 {
+		if err := s.db.Create(&tender.Qualifications).Error; err != nil {
+			return err
+		}
+	}
+
+	association := s.db.Model(tender).Association("Categories")
+	if len(tender.Categories) == 0 {
+		if err := association.Clear(); err != nil {
+			return err
+		}
+	} else if err := association.Replace(tender.Categories); err != nil {
+		return err
+	}
+
+	if err := s.db.Where("tender_id = ?", tender.ID).Delete(&models.TenderCriterion{}).Error; err != nil {
+		return err
+	}
+	for i := range tender.Criteria {
+		tender.Criteria[i].TenderID = tender.ID
+	}
+	if len(tender.Criteria) > 0 {
+		if err := s.db.Create(&tender.Criteria).Error; err != nil {
+			return err
+		}
+	}
+
+	return search.RefreshTender(s.db, tender.ID)
+}
+
+func (s *tenderStorage) Overdue(before time.Time) ([]models.Tenders, error) {
+	var tenders []models.Tenders
+	err := s.db.Where("state = ? AND deleted_at IS NULL AND deadline < ?", models.TenderStateOpen, before).
+		Order("deadline").
+		Find(&tenders).Error
+	return tenders, err
+}
+
+func (s *tenderStorage) PastDeadline(since, until time.Time) ([]models.Tenders, error) {
+	var tenders []models.Tenders
+	err := s.db.Where("state = ? AND deleted_at IS NULL AND deadline >= ? AND deadline < ?", models.TenderStateOpen, since, until).
+		Order("deadline").
+		Find(&tenders).Error
+	return tenders, err
+}
+
+func (s *tenderStorage) Each(batchSize int, fn func([]models.Tenders) error) error {
+	var tenders []models.Tenders
+	return s.db.Where("deleted_at IS NULL").
+		Preload("Qualifications").Preload("Categories").Preload("Criteria").
+		FindInBatches(&tenders, batchSize, func(tx *gorm.DB, batch int) error {
+			for i := range tenders {
+				tenders[i].AcceptedCurrencyList = tenders[i].SplitAcceptedCurrencies()
+			}
+			return fn(tenders)
+		}).Error
+}
+
+func (s *tenderStorage) SetFields(tender *models.Tenders, fields map[string]interface{}) error {
+	return s.db.Model(tender).Updates(fields).Error
+}
+
+func (s *tenderStorage) SetDeletedAt(tender *models.Tenders, deletedAt *time.Time) error {
+	if err := s.db.Model(&models.Tenders{}).Where("id = ?", tender.ID).Update("deleted_at", deletedAt).Error; err != nil {
+		return err
+	}
+	tender.DeletedAt = deletedAt
+	return nil
+}
+
+func (s *tenderStorage) ResolveCategories(codes []string) ([]models.Category, error) {
+	return categories.Resolve(s.db, codes)
+}
+
+func (s *tenderStorage) MissingQualifications(tenderID, contractorID uint) ([]string, error) {
+	var required []string
+	if err := s.db.Model(&models.TenderQualification{}).
+		Where("tender_id = ?", tenderID).
+		Pluck("qualification", &required).Error; err != nil {
+		return nil, err
+	}
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	var held []string
+	if err := s.db.Model(&models.QualificationDocument{}).
+		Where("user_id = ? AND qualification IN ? AND expires_at > ?", contractorID, required, time.Now()).
+		Distinct().Pluck("qualification", &held).Error; err != nil {
+		return nil, err
+	}
+
+	has := make(map[string]bool, len(held))
+	for _, q := range held {
+		has[q] = true
+	}
+
+	var missing []string
+	for _, q := range required {
+		if !has[q] {
+			missing = append(missing, q)
+		}
+	}
+	return missing, nil
+}
+
+func (s *tenderStorage) Compare(tender models.Tenders) (models.TenderComparison, error) {
+	return evaluation.Compare(s.db, tender)
+}
@@ -1,40 +1,138 @@
 package storage
 
-// import (
-// 	"log"
-// 	"tender_management/models"
-
-// 	"gorm.io/gorm"
-// )
-
-
-// type UserStorage struct {
-// 	db *gorm.DB
-// }
-
-// func (s *UserStorage) Create(user *models.Users) error {
-// 	if err := s.db.Create(user).Error; err != nil {
-// 		log.Printf("Create: Error creating user: %v", err)
-// 		return err
-// 	}
-// 	log.Printf("Create: User created with ID %d", user.ID)
-// 	return nil
-// }
-
-// func (s *UserStorage) FindByEmail(email string) (*models.Users, error) {
-// 	var user models.Users
-
-// 	err := s.db.Where("email = ?", email).First(&user).Error
-// 	if err != nil {
-// 		log.Printf("FindByEmail error getting user by email %s: %v", email, err)
-// 		return nil, err
-// 	}
-
-// 	log.Printf("FindByEmail User with email %s retrieved", email)
-// 	return &user, nil
-// }
-
-// func (s *UserStorage) ActivateUser(phoneNumber string) error {
-// 	result := s.db.Model(&models.Users{}).Where("phone_number = ?", phoneNumber).Update("is_active", true)
-// 	return result.Error
-// }
+import (
+	"tender_management/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Create(user *models.Users) error
+	// Get returns the user with the given ID, active or not.
+	Get(id uint) (models.Users, error)
+	// GetActive returns the user only once it is verified.
+	GetActive(id uint) (models.Users, error)
+	FindByEmail(email string) (models.Users, error)
+	FindByPhone(phoneNumber string) (models.Users, error)
+	SetPassword(id uint, hash string) error
+	Activate(id uint) error
+
+	// SetTwoFactor stores the TOTP secret of the user and whether
+	// two-factor authentication is on.
+	SetTwoFactor(id uint, secret string, enabled bool) error
+	// ReplaceRecoveryCodes deletes the recovery codes of the user and
+	// stores the given hashes instead.
+	ReplaceRecoveryCodes(userID uint, hashes []string) error
+	// UseRecoveryCode marks the unused recovery code with the hash used and
+	// reports whether there was one.
+	UseRecoveryCode(userID uint, hash string) (bool, error)
+	// TwoFactorPolicy returns the policy of the role, ErrNotFound if it
+	// has none.
+	TwoFactorPolicy(role string) (models.TwoFactorPolicy, error)
+	TwoFactorPolicies() ([]models.TwoFactorPolicy, error)
+	SaveTwoFactorPolicy(policy *models.TwoFactorPolicy) error
+}
+
+type userStorage struct {
+	db *gorm.DB
+}
+
+func (s *userStorage) Create(user *models.Users) error {
+	return s.db.Create(user).Error
+}
+
+func (s *userStorage) Get(id uint) (models.Users, error) {
+	var user models.Users
+	err := s.db.Where("id = ?", id).First(&user).Error
+	return user, notFound(err)
+}
+
+func (s *userStorage) GetActive(id uint) (models.Users, error) {
+	var user models.Users
+	err := s.db.Where("id = ? AND is_active = ?", id, true).First(&user).Error
+	return user, notFound(err)
+}
+
+func (s *userStorage) FindByEmail(email string) (models.Users, error) {
+	var user models.Users
+	err := s.db.Where("email = ?", email).First(&user).Error
+	return user, notFound(err)
+}
+
+func (s *userStorage) FindByPhone(phoneNumber string) (models.Users, error) {
+	var user models.Users
+	err := s.db.Where("phone_number = ?", phoneNumber).First(&user).Error
+	return user, notFound(err)
+}
+
+func (s *userStorage) SetPassword(id uint, hash string) error {
+	return s.updateUser(id, "password", hash)
+}
+
+func (s *userStorage) Activate(id uint) error {
+	return s.updateUser(id, "is_active", true)
+}
+
+func (s *userStorage) SetTwoFactor(id uint, secret string, enabled bool) error {
+	return s.updateUserFields(id, map[string]interface{}{
+		"two_factor_secret":  secret,
+		"two_factor_enabled": enabled,
+	})
+}
+
+func (s *userStorage) ReplaceRecoveryCodes(userID uint, hashes []string) error {
+	if err := s.db.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	rows := make([]models.RecoveryCode, len(hashes))
+	for i, hash := range hashes {
+		rows[i] = models.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return s.db.Create(&rows).Error
+}
+
+func (s *userStorage) UseRecoveryCode(userID uint, hash string) (bool, error) {
+	result := s.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (s *userStorage) TwoFactorPolicy(role string) (models.TwoFactorPolicy, error) {
+	var policy models.TwoFactorPolicy
+	err := s.db.Where("role = ?", role).First(&policy).Error
+	return policy, notFound(err)
+}
+
+func (s *userStorage) TwoFactorPolicies() ([]models.TwoFactorPolicy, error) {
+	var policies []models.TwoFactorPolicy
+	err := s.db.Find(&policies).Error
+	return policies, err
+}
+
+func (s *userStorage) SaveTwoFactorPolicy(policy *models.TwoFactorPolicy) error {
+	return s.db.Save(policy).Error
+}
+
+func (s *userStorage) updateUser(id uint, column string, value interface{}) error {
+	return s.updateUserFields(id, map[string]interface{}{column: value})
+}
+
+func (s *userStorage) updateUserFields(id uint, fields map[string]interface{}) error {
+	result := s.db.Model(&models.Users{}).Where("id = ?", id).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
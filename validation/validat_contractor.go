@@ -0,0 +1,19 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IsValidINN checks the format of an Uzbek taxpayer identification number:
+// nine digits, not starting with zero.
+func IsValidINN(inn string) bool {
+	regex := regexp.MustCompile(`^[1-9][0-9]{8}$`)
+	return regex.MatchString(inn)
+}
+
+// NormalizeQualification turns a qualification name into the code used to
+// match tender requirements against contractor documents.
+func NormalizeQualification(q string) string {
+	return strings.Join(strings.Fields(strings.ToLower(q)), "-")
+}
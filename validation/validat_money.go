@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxAmount is the first amount a numeric(20,2) column cannot hold.
+var maxAmount = decimal.New(1, 18)
+
+// NormalizeCurrency upper-cases an ISO 4217 code and reports whether it is
+// well formed: three letters.
+func NormalizeCurrency(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	return code, regexp.MustCompile(`^[A-Z]{3}$`).MatchString(code)
+}
+
+// ValidateAmount checks that an amount of money is positive, has at most
+// two decimal places and fits the database columns.
+func ValidateAmount(amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return errors.New("amount must be greater than zero")
+	}
+	if !amount.Equal(amount.Truncate(2)) {
+		return errors.New("amount must have at most two decimal places")
+	}
+	if amount.GreaterThanOrEqual(maxAmount) {
+		return errors.New("amount is too large")
+	}
+	return nil
+}
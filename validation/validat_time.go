@@ -0,0 +1,20 @@
+package validation
+
+import (
+	"errors"
+	"tender_management/constants"
+	"time"
+)
+
+// ParseFutureTime parses a deadline or delivery time in constants.Layout
+// and checks that it has not passed yet.
+func ParseFutureTime(value string) (*time.Time, error) {
+	t, err := time.Parse(constants.Layout, value)
+	if err != nil {
+		return nil, errors.New(constants.ErrFormatInput)
+	}
+	if !t.After(time.Now()) {
+		return nil, errors.New(constants.ErrDeadlinePassed)
+	}
+	return &t, nil
+}
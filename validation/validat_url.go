@@ -0,0 +1,55 @@
+package validation
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// ValidateWebhookURL checks that a user supplied URL the server will post
+// to is http or https and, unless allowPrivate is set, does not name a
+// loopback, private, link-local or otherwise internal host. Hostnames are
+// checked again when dialling, as they may resolve anywhere.
+func ValidateWebhookURL(raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.New("invalid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("URL must use http or https")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("URL must have a host")
+	}
+	if u.User != nil {
+		return errors.New("URL must not contain credentials")
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	lower := strings.ToLower(strings.TrimSuffix(host, "."))
+	if lower == "localhost" || strings.HasSuffix(lower, ".localhost") || strings.HasSuffix(lower, ".local") ||
+		strings.HasSuffix(lower, ".internal") {
+		return errors.New("URL must not point to an internal host")
+	}
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return errors.New("URL must not point to a private or loopback address")
+	}
+	return nil
+}
+
+// IsPublicIP reports whether ip is a globally routable unicast address.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	// Carrier-grade NAT, 100.64.0.0/10, is not covered by IsPrivate.
+	if ip4 := ip.To4(); ip4 != nil && ip4[0] == 100 && ip4[1]&0xc0 == 64 {
+		return false
+	}
+	return true
+}